@@ -39,7 +39,7 @@ mandiff: update-charts
 
 fmt: format-go tidy-go
 
-gen: generate-values generate-types generate-vfs tidy-go mirror-licenses
+gen: generate-values generate-types generate-vfs generate-crd tidy-go mirror-licenses
 
 gen-check: clean gen check-clean-repo
 
@@ -57,6 +57,9 @@ generate-vfs: update-charts
 clean-vfs:
 	@rm -fr pkg/vfs/assets.gen.go
 
+generate-crd: generate-types generate-values
+	@go run ./cmd/mesh.go profile schema --output crd > deploy/crds/istio_v1alpha2_istiocontrolplane_crd.yaml
+
 mesh: generate-vfs
 	# First line is for test environment, second is for target. Since these architectures can differ, the workaround
 	# is to build both. TODO: figure out some way to implement this better, e.g. separate test target.
@@ -17,12 +17,16 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	drm "github.com/openshift/cluster-network-operator/pkg/util/k8s"
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
@@ -71,13 +75,20 @@ func serverCmd() *cobra.Command {
 	return serverCmd
 }
 
-// getWatchNamespace returns the namespace the operator should be watching for changes
-func getWatchNamespace() (string, error) {
-	ns, found := os.LookupEnv("WATCH_NAMESPACE")
+// getWatchNamespaces returns the namespaces the operator should be watching for changes, given as a comma separated
+// list. No namespaces means all namespaces are watched.
+func getWatchNamespaces() ([]string, error) {
+	value, found := os.LookupEnv("WATCH_NAMESPACE")
 	if !found {
-		return "", fmt.Errorf("WATCH_NAMESPACE must be set")
+		return nil, fmt.Errorf("WATCH_NAMESPACE must be set")
 	}
-	return ns, nil
+	var namespaces []string
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces, nil
 }
 
 // getLeaderElectionNamespace returns the namespace in which the leader election configmap will be created
@@ -90,9 +101,9 @@ func getLeaderElectionNamespace() (string, error) {
 }
 
 func run() {
-	watchNS, err := getWatchNamespace()
+	watchNamespaces, err := getWatchNamespaces()
 	if err != nil {
-		log.Fatalf("Failed to get watch namespace: %v", err)
+		log.Fatalf("Failed to get watch namespaces: %v", err)
 	}
 
 	leaderElectionNS, err := getLeaderElectionNamespace()
@@ -106,16 +117,32 @@ func run() {
 		log.Fatalf("Could not get apiserver config: %v", err)
 	}
 
-	// Create a new Cmd to provide shared dependencies and start components
-	mgr, err := manager.New(cfg, manager.Options{
-		Namespace:          watchNS,
+	options := manager.Options{
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metricsHost, metricsPort),
 		// Workaround for https://github.com/kubernetes-sigs/controller-runtime/issues/321
 		MapperProvider:          drm.NewDynamicRESTMapper,
 		LeaderElection:          true,
 		LeaderElectionNamespace: leaderElectionNS,
 		LeaderElectionID:        "istio-operator-lock",
-	})
+	}
+	switch len(watchNamespaces) {
+	case 0:
+		log.Info("Watching all namespaces")
+	case 1:
+		log.Infof("Watching namespace %s", watchNamespaces[0])
+		options.Namespace = watchNamespaces[0]
+	default:
+		log.Infof("Watching namespaces %s", strings.Join(watchNamespaces, ", "))
+		options.NewCache = cache.MultiNamespacedCacheBuilder(watchNamespaces)
+		// The cache of each namespace cannot serve cluster scoped objects, or the objects the operator manages in
+		// other namespaces, so objects are read from the apiserver.
+		options.NewClient = func(_ cache.Cache, config *rest.Config, options client.Options) (client.Client, error) {
+			return client.New(config, options)
+		}
+	}
+
+	// Create a new Cmd to provide shared dependencies and start components
+	mgr, err := manager.New(cfg, options)
 	if err != nil {
 		log.Fatalf("Could not create a controller manager: %v", err)
 	}
@@ -40,9 +40,15 @@ type manifestApplyArgs struct {
 	skipConfirmation bool
 	// force proceeds even if there are validation errors
 	force bool
+	// policyFilename is the path to a rules file which the manifests must pass before they are applied.
+	policyFilename string
 	// set is a string with element format "path=value" where path is an IstioControlPlane path and the value is a
 	// value to set the node at that path to.
 	set []string
+	// pruneDryRun lists the resources which would be pruned instead of deleting them.
+	pruneDryRun bool
+	// pruneBackupDir is a directory the resources are backed up to before they are pruned.
+	pruneBackupDir string
 }
 
 func addManifestApplyFlags(cmd *cobra.Command, args *manifestApplyArgs) {
@@ -51,11 +57,17 @@ func addManifestApplyFlags(cmd *cobra.Command, args *manifestApplyArgs) {
 	cmd.PersistentFlags().StringVar(&args.context, "context", "", "The name of the kubeconfig context to use")
 	cmd.PersistentFlags().BoolVar(&args.skipConfirmation, "skip-confirmation", false, skipConfirmationFlagHelpStr)
 	cmd.PersistentFlags().BoolVar(&args.force, "force", false, "Proceed even with validation errors")
-	cmd.PersistentFlags().DurationVar(&args.readinessTimeout, "readiness-timeout", 300*time.Second, "Maximum seconds to wait for all Istio resources to be ready."+
-		" The --wait flag must be set for this flag to apply")
-	cmd.PersistentFlags().BoolVarP(&args.wait, "wait", "w", false, "Wait, if set will wait until all Pods, Services, and minimum number of Pods "+
-		"of a Deployment are in a ready state before the command exits. It will wait for a maximum duration of --readiness-timeout seconds")
+	cmd.PersistentFlags().StringVarP(&args.policyFilename, "policy", "p", "", policyFlagHelpStr+
+		" Nothing is applied if any rule with ERROR severity fails.")
+	cmd.PersistentFlags().DurationVar(&args.readinessTimeout, "readiness-timeout", 300*time.Second, "Maximum time to wait for Istio resources to be ready,"+
+		" before applying the components which depend on them and, if --wait is set, before the command exits")
+	cmd.PersistentFlags().BoolVarP(&args.wait, "wait", "w", false, "Wait, if set will wait until all workloads, Jobs, CRDs, webhooks and "+
+		"Services are ready before the command exits. It will wait for a maximum duration of --readiness-timeout")
 	cmd.PersistentFlags().StringSliceVarP(&args.set, "set", "s", nil, setFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.pruneDryRun, "prune-dry-run", false, "List the resources which are no "+
+		"longer rendered and would be pruned instead of deleting them")
+	cmd.PersistentFlags().StringVar(&args.pruneBackupDir, "prune-backup-dir", "", "Directory to back up resources to "+
+		"before they are pruned. The backups can be restored with the manifest restore command")
 }
 
 func manifestApplyCmd(rootArgs *rootArgs, maArgs *manifestApplyArgs) *cobra.Command {
@@ -84,7 +96,8 @@ func manifestApply(args *rootArgs, maArgs *manifestApplyArgs, l *logger) {
 		os.Exit(1)
 	}
 	if err := genApplyManifests(maArgs.set, maArgs.inFilename, maArgs.force, args.dryRun, args.verbose,
-		maArgs.kubeConfigPath, maArgs.context, maArgs.readinessTimeout, l); err != nil {
+		maArgs.kubeConfigPath, maArgs.context, maArgs.readinessTimeout, maArgs.policyFilename,
+		maArgs.pruneDryRun, maArgs.pruneBackupDir, l); err != nil {
 		l.logAndFatalf("Failed to generate and apply manifests, error: %v", err)
 	}
 }
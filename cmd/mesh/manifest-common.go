@@ -16,6 +16,7 @@ package mesh
 
 import (
 	"fmt"
+	"io/ioutil"
 	"path"
 	"path/filepath"
 	"strings"
@@ -25,13 +26,17 @@ import (
 
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
 	"istio.io/operator/pkg/component/controlplane"
+	"istio.io/operator/pkg/component/feature"
 	"istio.io/operator/pkg/helm"
 	"istio.io/operator/pkg/manifest"
 	"istio.io/operator/pkg/name"
+	"istio.io/operator/pkg/patch"
+	"istio.io/operator/pkg/policy"
 	"istio.io/operator/pkg/tpath"
 	"istio.io/operator/pkg/translate"
 	"istio.io/operator/pkg/util"
 	"istio.io/operator/pkg/validate"
+	"istio.io/operator/pkg/yamlpos"
 	"istio.io/operator/version"
 )
 
@@ -42,23 +47,44 @@ var (
 	}
 )
 
+// genApplyManifests generates the manifests and applies them to the cluster. If policyFilename is set, the manifests
+// are checked against the rules in that file first and nothing is applied if any rule with ERROR severity fails.
+// Resources which are no longer rendered are pruned, or only listed if pruneDryRun is set. If pruneBackupDir is set,
+// they are backed up to a file in that directory before they are deleted.
 func genApplyManifests(setOverlay []string, inFilename string, force bool, dryRun bool, verbose bool,
-	kubeConfigPath string, context string, waitTimeout time.Duration, l *logger) error {
-	overlayFromSet, err := makeTreeFromSetList(setOverlay, force, l)
+	kubeConfigPath string, context string, waitTimeout time.Duration, policyFilename string, pruneDryRun bool,
+	pruneBackupDir string, l *logger) error {
+	var rules *policy.Rules
+	if policyFilename != "" {
+		var err error
+		if rules, err = policy.ReadRulesFile(policyFilename); err != nil {
+			return err
+		}
+	}
+	overlayFromSet, err := makeTreeFromSetList(setOverlay, inFilename, force, l)
 	if err != nil {
 		return fmt.Errorf("failed to generate tree from the set overlay, error: %v", err)
 	}
 
-	manifests, err := genManifests(inFilename, overlayFromSet, force, l)
+	manifests, icps, err := genManifestsWithSpec(inFilename, overlayFromSet, force, l)
 	if err != nil {
 		return fmt.Errorf("failed to generate manifest: %v", err)
 	}
+	if rules != nil {
+		if err := checkPolicy(rules, manifests, l); err != nil {
+			return fmt.Errorf("manifest failed policy check, nothing was applied: %v", err)
+		}
+	}
 	opts := &manifest.InstallOptions{
-		DryRun:      dryRun,
-		Verbose:     verbose,
-		WaitTimeout: waitTimeout,
-		Kubeconfig:  kubeConfigPath,
-		Context:     context,
+		DryRun:         dryRun,
+		Verbose:        verbose,
+		WaitTimeout:    waitTimeout,
+		Kubeconfig:     kubeConfigPath,
+		Context:        context,
+		PruneDryRun:    pruneDryRun,
+		PruneBackupDir: pruneBackupDir,
+
+		ComponentDependencies: icps.GetComponentDependencies(),
 	}
 	out, err := manifest.ApplyAll(manifests, version.OperatorBinaryVersion, opts)
 	if err != nil {
@@ -66,6 +92,13 @@ func genApplyManifests(setOverlay []string, inFilename string, force bool, dryRu
 	}
 	gotError := false
 	for cn := range manifests {
+		if out[cn].Blocked {
+			cs := fmt.Sprintf("Component %s was not installed because a component it depends on failed:", cn)
+			l.logAndPrintf("\n%s\n%s", cs, strings.Repeat("=", len(cs)))
+			l.logAndPrint("Error: ", out[cn].Err, "\n")
+			gotError = true
+			continue
+		}
 		if out[cn].Err != nil {
 			cs := fmt.Sprintf("Component %s install returned the following errors:", cn)
 			l.logAndPrintf("\n%s\n%s", cs, strings.Repeat("=", len(cs)))
@@ -93,34 +126,81 @@ func genApplyManifests(setOverlay []string, inFilename string, force bool, dryRu
 }
 
 func genManifests(inFilename string, setOverlayYAML string, force bool, l *logger) (name.ManifestMap, error) {
+	manifests, _, err := genManifestsWithAnalysis(inFilename, setOverlayYAML, force, nil, l)
+	return manifests, err
+}
+
+// genManifestsWithSpec is like genManifests but also returns the merged IstioControlPlaneSpec the manifests were
+// rendered from.
+func genManifestsWithSpec(inFilename string, setOverlayYAML string, force bool, l *logger) (name.ManifestMap,
+	*v1alpha2.IstioControlPlaneSpec, error) {
+	return genManifestsWithAnalysis(inFilename, setOverlayYAML, force, nil, l)
+}
+
+// analyzeOverlays renders the manifests for the given spec and returns an analysis of the effect of every k8s overlay
+// in the spec on the rendered output.
+func analyzeOverlays(inFilename string, setOverlayYAML string, force bool, l *logger) (*patch.OverlayAnalysis, error) {
+	analysis := &patch.OverlayAnalysis{}
+	if _, _, err := genManifestsWithAnalysis(inFilename, setOverlayYAML, force, analysis, l); err != nil {
+		return nil, err
+	}
+	return analysis, nil
+}
+
+// genManifestsWithAnalysis is like genManifestsWithSpec but, if analysis is not nil, reports the effect of overlays in
+// analysis rather than failing on overlays which do not apply.
+func genManifestsWithAnalysis(inFilename string, setOverlayYAML string, force bool, analysis *patch.OverlayAnalysis,
+	l *logger) (name.ManifestMap, *v1alpha2.IstioControlPlaneSpec, error) {
 	mergedYAML, err := genProfile(false, inFilename, "", setOverlayYAML, "", force, l)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	mergedICPS, err := unmarshalAndValidateICPS(mergedYAML, force, l)
+	pos, err := readPositions(inFilename)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	mergedICPS, err := unmarshalAndValidateICPS(mergedYAML, force, pos, l)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	t, err := translate.NewTranslator(version.OperatorBinaryVersion.MinorVersion)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := fetchInstallPackageFromURL(mergedICPS); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	cp := controlplane.NewIstioControlPlane(mergedICPS, t)
+	cp := controlplane.NewIstioControlPlaneWithOptions(&feature.Options{
+		InstallSpec:     mergedICPS,
+		Translator:      t,
+		OverlayAnalysis: analysis,
+	})
 	if err := cp.Run(); err != nil {
-		return nil, fmt.Errorf("failed to create Istio control plane with spec: \n%v\nerror: %s", mergedICPS, err)
+		return nil, nil, fmt.Errorf("failed to create Istio control plane with spec: \n%v\nerror: %s", mergedICPS, err)
 	}
 
 	manifests, errs := cp.RenderManifest()
 	if errs != nil {
-		return manifests, errs.ToError()
+		s, _ := sourceErrorsString(errs, pos)
+		return manifests, nil, fmt.Errorf("%s", s)
 	}
-	return manifests, nil
+	return manifests, mergedICPS, nil
+}
+
+// readPositions returns the source positions of the IstioControlPlaneSpec in the CR file at filename, or nil if
+// filename is empty.
+func readPositions(filename string) (*yamlpos.Positions, error) {
+	if filename == "" {
+		return nil, nil
+	}
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("could not read values from file %s: %s", filename, err)
+	}
+	return yamlpos.Parse(filename, b, util.Path{"spec"})
 }
 
 func ignoreError(stderr string) bool {
@@ -153,7 +233,10 @@ func fetchInstallPackageFromURL(mergedICPS *v1alpha2.IstioControlPlaneSpec) erro
 }
 
 // makeTreeFromSetList creates a YAML tree from a string slice containing key-value pairs in the format key=value.
-func makeTreeFromSetList(setOverlay []string, force bool, l *logger) (string, error) {
+// Paths containing list selectors like [*], [0] or [+], or recursive descent (**), can only be resolved against the
+// full IstioControlPlane tree. If any are present, the returned tree is the complete tree generated from inFilename
+// with all the values set.
+func makeTreeFromSetList(setOverlay []string, inFilename string, force bool, l *logger) (string, error) {
 	if len(setOverlay) == 0 {
 		return "", nil
 	}
@@ -162,36 +245,97 @@ func makeTreeFromSetList(setOverlay []string, force bool, l *logger) (string, er
 	if err := tpath.WriteNode(tree, util.PathFromString("defaultNamespace"), "istio-system"); err != nil {
 		return "", err
 	}
+	var selectorKVs []string
 	for _, kv := range setOverlay {
-		kvv := strings.Split(kv, "=")
-		if len(kvv) != 2 {
-			return "", fmt.Errorf("bad argument %s: expect format key=value", kv)
+		k, v, err := splitSetKV(kv)
+		if err != nil {
+			return "", err
+		}
+		if hasSelector(k) {
+			selectorKVs = append(selectorKVs, kv)
+			continue
+		}
+		if err := tpath.WriteNode(tree, k, v); err != nil {
+			return "", err
 		}
-		k := kvv[0]
-		v := util.ParseValue(kvv[1])
-		if err := tpath.WriteNode(tree, util.PathFromString(k), v); err != nil {
+		if err := checkSetTree(tree, kv, force, l); err != nil {
 			return "", err
 		}
-		// To make errors more user friendly, test the path and error out immediately if we cannot unmarshal.
-		testTree, err := yaml.Marshal(tree)
+	}
+	out, err := yaml.Marshal(tree)
+	if err != nil {
+		return "", err
+	}
+	if len(selectorKVs) == 0 {
+		return string(out), nil
+	}
+
+	fullYAML, _, err := genICPS(inFilename, "", string(out), force, l)
+	if err != nil {
+		return "", err
+	}
+	fullTree := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(fullYAML), &fullTree); err != nil {
+		return "", err
+	}
+	for _, kv := range selectorKVs {
+		k, v, _ := splitSetKV(kv)
+		pc, found, err := tpath.GetPathContext(fullTree, k)
 		if err != nil {
 			return "", err
 		}
-		icps := &v1alpha2.IstioControlPlaneSpec{}
-		if err := util.UnmarshalWithJSONPB(string(testTree), icps); err != nil {
-			return "", fmt.Errorf("bad path=value: %s", kv)
+		if !found {
+			return "", fmt.Errorf("bad path=value %s: path not found", kv)
+		}
+		if err := tpath.WritePathContext(pc, v); err != nil {
+			return "", err
 		}
-		if errs := validate.CheckIstioControlPlaneSpec(icps, true); len(errs) != 0 {
-			if !force {
-				l.logAndError("Run the command with the --force flag if you want to ignore the validation error and proceed.")
-				return "", fmt.Errorf("bad path=value (%s): %s", kv, errs)
-			}
+		if err := checkSetTree(fullTree, kv, force, l); err != nil {
+			return "", err
 		}
-
 	}
-	out, err := yaml.Marshal(tree)
+	out, err = yaml.Marshal(fullTree)
 	if err != nil {
 		return "", err
 	}
 	return string(out), nil
 }
+
+// splitSetKV splits a --set argument in the format key=value into its path and parsed value.
+func splitSetKV(kv string) (util.Path, interface{}, error) {
+	kvv := strings.Split(kv, "=")
+	if len(kvv) != 2 {
+		return nil, nil, fmt.Errorf("bad argument %s: expect format key=value", kv)
+	}
+	return util.PathFromString(kvv[0]), util.ParseValue(kvv[1]), nil
+}
+
+// hasSelector reports whether path contains any list selector or recursive descent path elements.
+func hasSelector(path util.Path) bool {
+	for _, pe := range path {
+		if _, ok := util.RemoveBrackets(pe); ok || pe == util.RecursiveDescentPathElement {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSetTree tests that tree, after setting the path and value in kv, is a valid IstioControlPlaneSpec. This makes
+// errors more user friendly by reporting the --set argument that caused them.
+func checkSetTree(tree map[string]interface{}, kv string, force bool, l *logger) error {
+	testTree, err := yaml.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	icps := &v1alpha2.IstioControlPlaneSpec{}
+	if err := util.UnmarshalWithJSONPB(string(testTree), icps); err != nil {
+		return fmt.Errorf("bad path=value %s: %s", kv, err)
+	}
+	if errs := validate.CheckIstioControlPlaneSpec(icps, true); len(errs) != 0 {
+		if !force {
+			l.logAndError("Run the command with the --force flag if you want to ignore the validation error and proceed.")
+			return fmt.Errorf("bad path=value (%s): %s", kv, errs)
+		}
+	}
+	return nil
+}
@@ -35,6 +35,8 @@ type manifestGenerateArgs struct {
 	set []string
 	// force proceeds even if there are validation errors
 	force bool
+	// analyzeOverlays outputs a report of the effect of each k8s overlay instead of the manifest.
+	analyzeOverlays bool
 }
 
 func addManifestGenerateFlags(cmd *cobra.Command, args *manifestGenerateArgs) {
@@ -42,6 +44,8 @@ func addManifestGenerateFlags(cmd *cobra.Command, args *manifestGenerateArgs) {
 	cmd.PersistentFlags().StringVarP(&args.outFilename, "output", "o", "", "Manifest output directory path")
 	cmd.PersistentFlags().StringSliceVarP(&args.set, "set", "s", nil, setFlagHelpStr)
 	cmd.PersistentFlags().BoolVar(&args.force, "force", false, "Proceed even with validation errors")
+	cmd.PersistentFlags().BoolVar(&args.analyzeOverlays, "analyze-overlays", false,
+		"Output a report of whether each k8s overlay matched its target and changed it, instead of the manifest")
 }
 
 func manifestGenerateCmd(rootArgs *rootArgs, mgArgs *manifestGenerateArgs) *cobra.Command {
@@ -68,11 +72,25 @@ func manifestGenerate(args *rootArgs, mgArgs *manifestGenerateArgs, l *logger) {
 		os.Exit(1)
 	}
 
-	overlayFromSet, err := makeTreeFromSetList(mgArgs.set, mgArgs.force, l)
+	overlayFromSet, err := makeTreeFromSetList(mgArgs.set, mgArgs.inFilename, mgArgs.force, l)
 	if err != nil {
 		l.logAndFatal(err.Error())
 	}
-	manifests, err := genManifests(mgArgs.inFilename, overlayFromSet, mgArgs.force, l)
+	if mgArgs.analyzeOverlays {
+		analysis, err := analyzeOverlays(mgArgs.inFilename, overlayFromSet, mgArgs.force, l)
+		if err != nil {
+			l.logAndFatal(err.Error())
+		}
+		for _, r := range analysis.Reports() {
+			l.print(r.String())
+		}
+		if stale := analysis.Stale(); len(stale) != 0 {
+			l.logAndFatalf("%d overlays have no effect on the output manifest", len(stale))
+		}
+		return
+	}
+
+	manifests, icps, err := genManifestsWithSpec(mgArgs.inFilename, overlayFromSet, mgArgs.force, l)
 	if err != nil {
 		l.logAndFatal(err.Error())
 	}
@@ -85,7 +103,7 @@ func manifestGenerate(args *rootArgs, mgArgs *manifestGenerateArgs, l *logger) {
 		if err := os.MkdirAll(mgArgs.outFilename, os.ModePerm); err != nil {
 			l.logAndFatal(err.Error())
 		}
-		if err := manifest.RenderToDir(manifests, mgArgs.outFilename, args.dryRun); err != nil {
+		if err := manifest.RenderToDir(manifests, icps.GetComponentDependencies(), mgArgs.outFilename, args.dryRun); err != nil {
 			l.logAndFatal(err.Error())
 		}
 	}
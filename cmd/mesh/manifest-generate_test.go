@@ -98,6 +98,10 @@ func TestManifestGeneratePilot(t *testing.T) {
 			desc:       "pilot_k8s_settings",
 			diffIgnore: "CustomResourceDefinition:*:*,ConfigMap:*:istio",
 		},
+		{
+			desc:       "pilot_k8s_pod_settings",
+			diffSelect: "Deployment:*:istio-pilot",
+		},
 		{
 			desc:       "pilot_override_values",
 			diffSelect: "Deployment:*:istio-pilot",
@@ -0,0 +1,108 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"istio.io/operator/pkg/name"
+	"istio.io/operator/pkg/policy"
+)
+
+const (
+	policyFlagHelpStr = `Path to a rules file which is evaluated against the rendered manifests. See samples/policy-rules.yaml
+for an example.`
+)
+
+type manifestLintArgs struct {
+	// inFilename is the path to the input IstioControlPlane CR.
+	inFilename string
+	// policyFilename is the path to the rules file.
+	policyFilename string
+	// set is a string with element format "path=value" where path is an IstioControlPlane path and the value is a
+	// value to set the node at that path to.
+	set []string
+	// force proceeds even if there are validation errors
+	force bool
+}
+
+func addManifestLintFlags(cmd *cobra.Command, args *manifestLintArgs) {
+	cmd.PersistentFlags().StringVarP(&args.inFilename, "filename", "f", "", filenameFlagHelpStr)
+	cmd.PersistentFlags().StringVarP(&args.policyFilename, "policy", "p", "", policyFlagHelpStr)
+	cmd.PersistentFlags().StringSliceVarP(&args.set, "set", "s", nil, setFlagHelpStr)
+	cmd.PersistentFlags().BoolVar(&args.force, "force", false, "Proceed even with validation errors")
+}
+
+func manifestLintCmd(rootArgs *rootArgs, mlArgs *manifestLintArgs) *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint",
+		Short: "Checks a generated Istio install manifest against a rules file",
+		Long: "The lint subcommand generates an Istio install manifest and reports every object in it which violates a " +
+			"rule in the rules file. It fails if any violation has ERROR severity.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("lint accepts no positional arguments, got %#v", args)
+			}
+			if mlArgs.policyFilename == "" {
+				return fmt.Errorf("a rules file must be set with --policy")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			l := newLogger(rootArgs.logToStdErr, cmd.OutOrStdout(), cmd.OutOrStderr())
+			manifestLint(rootArgs, mlArgs, l)
+		}}
+}
+
+func manifestLint(args *rootArgs, mlArgs *manifestLintArgs, l *logger) {
+	if err := configLogs(args.logToStdErr); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Could not configure logs: %s", err)
+		os.Exit(1)
+	}
+
+	rules, err := policy.ReadRulesFile(mlArgs.policyFilename)
+	if err != nil {
+		l.logAndFatal(err.Error())
+	}
+	overlayFromSet, err := makeTreeFromSetList(mlArgs.set, mlArgs.inFilename, mlArgs.force, l)
+	if err != nil {
+		l.logAndFatal(err.Error())
+	}
+	manifests, err := genManifests(mlArgs.inFilename, overlayFromSet, mlArgs.force, l)
+	if err != nil {
+		l.logAndFatal(err.Error())
+	}
+	if err := checkPolicy(rules, manifests, l); err != nil {
+		l.logAndFatal(err.Error())
+	}
+}
+
+// checkPolicy prints the violations of rules in manifests and returns an error if any violation has ERROR severity.
+func checkPolicy(rules *policy.Rules, manifests name.ManifestMap, l *logger) error {
+	violations, err := rules.CheckManifests(manifests)
+	if err != nil {
+		return err
+	}
+	if len(violations) != 0 {
+		l.logAndPrint(violations.String())
+	}
+	if errs := violations.Errors(); len(errs) != 0 {
+		return fmt.Errorf("%d policy violations with %s severity", len(errs), errs[0].Severity)
+	}
+	return nil
+}
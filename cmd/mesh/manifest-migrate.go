@@ -17,7 +17,9 @@ package mesh
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"github.com/gogo/protobuf/jsonpb"
@@ -37,26 +39,38 @@ const (
 type manifestMigrateArgs struct {
 	// namespace is the namespace to get the in cluster configMap
 	namespace string
+	// upgradeSpec rewrites an IstioControlPlane CR to replace deprecated fields, rather than migrating Helm values.
+	upgradeSpec bool
 }
 
 func addManifestMigrateFlags(cmd *cobra.Command, args *manifestMigrateArgs) {
 	cmd.PersistentFlags().StringVarP(&args.namespace, "namespace", "n", defaultNamespace,
 		" Default namespace for output IstioControlPlane CustomResource")
+	cmd.PersistentFlags().BoolVar(&args.upgradeSpec, "upgrade-spec", false,
+		"Rewrite the IstioControlPlane CR in <filepath> to move deprecated fields to their replacements")
 }
 
 func manifestMigrateCmd(rootArgs *rootArgs, mmArgs *manifestMigrateArgs) *cobra.Command {
 	return &cobra.Command{
 		Use:   "migrate [<filepath>]",
 		Short: "Migrates a file containing Helm values to IstioControlPlane format",
-		Long:  "The migrate subcommand migrates a configuration from Helm values format to IstioControlPlane format.",
+		Long: "The migrate subcommand migrates a configuration from Helm values format to IstioControlPlane format. " +
+			"With --upgrade-spec, it instead rewrites an IstioControlPlane CR to move deprecated fields to their replacements.",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 1 {
 				return fmt.Errorf("migrate accepts optional single filepath")
 			}
+			if mmArgs.upgradeSpec && len(args) == 0 {
+				return fmt.Errorf("migrate --upgrade-spec requires a filepath")
+			}
 			return nil
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			l := newLogger(rootArgs.logToStdErr, cmd.OutOrStdout(), cmd.OutOrStderr())
+			if mmArgs.upgradeSpec {
+				upgradeSpecFromFile(rootArgs, args[0], l)
+				return
+			}
 			if len(args) == 0 {
 				migrateFromClusterConfig(rootArgs, mmArgs, l)
 			} else {
@@ -109,6 +123,54 @@ func translateFunc(values []byte, l *logger) {
 	l.print(string(isCPYaml) + "\n")
 }
 
+// upgradeSpecFromFile handles upgrading deprecated fields in a local IstioControlPlane CR file.
+func upgradeSpecFromFile(rootArgs *rootArgs, path string, l *logger) {
+	initLogsOrExit(rootArgs)
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		l.logAndFatal(err.Error())
+	}
+	out, err := upgradeSpec(string(b))
+	if err != nil {
+		l.logAndFatal(err.Error())
+	}
+	l.print(out)
+}
+
+// upgradeSpec moves the deprecated fields in the spec of the IstioControlPlane CR in crYAML to their replacements and
+// returns the resulting CR, preceded by a comment listing the changes.
+func upgradeSpec(crYAML string) (string, error) {
+	t, err := translate.NewTranslator(binversion.OperatorBinaryVersion.MinorVersion)
+	if err != nil {
+		return "", err
+	}
+	cr := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(crYAML), &cr); err != nil {
+		return "", fmt.Errorf("could not unmarshal IstioControlPlane CR: %s", err)
+	}
+	spec, ok := cr["spec"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("IstioControlPlane CR has no spec")
+	}
+	applied, err := t.UpgradeSpec(spec)
+	if err != nil {
+		return "", err
+	}
+	out, err := yaml.Marshal(cr)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, d := range applied {
+		sb.WriteString(fmt.Sprintf("# Replaced deprecated %s with %s\n", d.Path, d.Replacement))
+	}
+	for _, d := range t.FindDeprecated(spec) {
+		sb.WriteString(fmt.Sprintf("# %s has no replacement and must be removed manually\n", d.Path))
+	}
+	sb.Write(out)
+	return sb.String(), nil
+}
+
 // migrateFromClusterConfig handles migration for in cluster config.
 func migrateFromClusterConfig(rootArgs *rootArgs, mmArgs *manifestMigrateArgs, l *logger) {
 	initLogsOrExit(rootArgs)
@@ -25,18 +25,23 @@ import (
 func TestManifestMigrate(t *testing.T) {
 	testDataDir = filepath.Join(repoRootDir, "cmd/mesh/testdata/manifest-migrate")
 	tests := []struct {
-		desc string
+		desc  string
+		flags string
 	}{
 		{
 			desc: "values",
 		},
+		{
+			desc:  "upgrade-spec",
+			flags: "--upgrade-spec",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
 			inPath := filepath.Join(testDataDir, "input", tt.desc+".yaml")
 			outPath := filepath.Join(testDataDir, "output", tt.desc+".yaml")
 
-			got, err := runManifestMigrate(inPath)
+			got, err := runManifestMigrate(inPath, tt.flags)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -59,6 +64,9 @@ func TestManifestMigrate(t *testing.T) {
 	}
 }
 
-func runManifestMigrate(path string) (string, error) {
+func runManifestMigrate(path, flags string) (string, error) {
+	if flags != "" {
+		path = flags + " " + path
+	}
 	return runCommand("manifest migrate " + path)
 }
@@ -0,0 +1,105 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"istio.io/operator/pkg/kubectlcmd"
+	"istio.io/operator/pkg/prune"
+)
+
+type manifestRestoreArgs struct {
+	// backupFile is the path to a backup file written when resources were pruned.
+	backupFile string
+	// secret is the name of a backup Secret written by the controller when resources were pruned.
+	secret string
+	// namespace is the namespace of secret.
+	namespace string
+	// kubeConfigPath is the path to kube config file.
+	kubeConfigPath string
+	// context is the cluster context in the kube config
+	context string
+}
+
+func addManifestRestoreFlags(cmd *cobra.Command, args *manifestRestoreArgs) {
+	cmd.PersistentFlags().StringVarP(&args.backupFile, "backup", "b", "",
+		"Path to a backup file written by manifest apply --prune-backup-dir")
+	cmd.PersistentFlags().StringVar(&args.secret, "secret", "",
+		"Name of a backup Secret written by the controller before pruning")
+	cmd.PersistentFlags().StringVarP(&args.namespace, "namespace", "n", defaultNamespace,
+		"Namespace of the backup Secret")
+	cmd.PersistentFlags().StringVarP(&args.kubeConfigPath, "kubeconfig", "c", "", "Path to kube config")
+	cmd.PersistentFlags().StringVar(&args.context, "context", "", "The name of the kubeconfig context to use")
+}
+
+func manifestRestoreCmd(rootArgs *rootArgs, mrArgs *manifestRestoreArgs) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore",
+		Short: "Restores resources which were backed up before they were pruned",
+		Long: "The restore subcommand applies the resources in a backup written by manifest apply --prune-backup-dir, " +
+			"or in a backup Secret written by the controller, to restore resources which were pruned.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("restore takes no arguments")
+			}
+			if (mrArgs.backupFile == "") == (mrArgs.secret == "") {
+				return fmt.Errorf("exactly one of --backup or --secret must be set")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			l := newLogger(rootArgs.logToStdErr, cmd.OutOrStdout(), cmd.OutOrStderr())
+			manifestRestore(rootArgs, mrArgs, l)
+		}}
+}
+
+func manifestRestore(rootArgs *rootArgs, mrArgs *manifestRestoreArgs, l *logger) {
+	initLogsOrExit(rootArgs)
+
+	var backup string
+	kubectl := kubectlcmd.New()
+	if mrArgs.backupFile != "" {
+		b, err := ioutil.ReadFile(mrArgs.backupFile)
+		if err != nil {
+			l.logAndFatal(err.Error())
+		}
+		backup = string(b)
+	} else {
+		out, stderr, err := kubectl.GetSecret(mrArgs.kubeConfigPath, mrArgs.context, mrArgs.secret,
+			mrArgs.namespace, "yaml")
+		if err != nil {
+			l.logAndFatalf("could not get backup Secret %s/%s: %s\n%s", mrArgs.namespace, mrArgs.secret, err, stderr)
+		}
+		backup = out
+	}
+
+	manifest, err := prune.RestoreManifest(backup)
+	if err != nil {
+		l.logAndFatalf("could not read backup: %s", err)
+	}
+	stdout, stderr, err := kubectl.Apply(rootArgs.dryRun, rootArgs.verbose, mrArgs.kubeConfigPath, mrArgs.context,
+		"", manifest)
+	l.logAndPrint(stdout)
+	if stderr != "" {
+		l.logAndPrint("error: ", stderr, "\n")
+	}
+	if err != nil {
+		l.logAndFatalf("failed to restore resources: %s", err)
+	}
+}
@@ -20,7 +20,6 @@ import (
 
 	goversion "github.com/hashicorp/go-version"
 	"github.com/spf13/cobra"
-	"gopkg.in/yaml.v2"
 
 	"istio.io/operator/pkg/httprequest"
 	"istio.io/operator/pkg/util"
@@ -83,29 +82,12 @@ func manifestVersions(args *rootArgs, mvArgs *manifestVersionsArgs, l *logger) {
 
 func getVersionCompatibleMap(versionsURI string, binVersion *goversion.Version,
 	l *logger) (*version.CompatibilityMapping, error) {
-	var b []byte
-	var err error
-
-	b, err = loadCompatibleMapFile(versionsURI, l)
+	b, err := loadCompatibleMapFile(versionsURI, l)
 	if err != nil {
 		return nil, err
 	}
 
-	var versions []*version.CompatibilityMapping
-	if err = yaml.Unmarshal(b, &versions); err != nil {
-		return nil, err
-	}
-	var myVersionMap *version.CompatibilityMapping
-	for _, v := range versions {
-		if v.OperatorVersion.Equal(binVersion) {
-			myVersionMap = v
-			break
-		}
-	}
-	if myVersionMap == nil {
-		return nil, fmt.Errorf("this operator version %s was not found in the version map", binVersion.String())
-	}
-	return myVersionMap, nil
+	return version.FindCompatibilityMapping(b, binVersion)
 }
 
 func loadCompatibleMapFile(versionsURI string, l *logger) ([]byte, error) {
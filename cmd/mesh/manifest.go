@@ -18,12 +18,13 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// ManifestCmd is a group of commands related to manifest generation, installation, diffing and migration.
+// ManifestCmd is a group of commands related to manifest generation, installation, diffing, linting,
+// migration and restoring pruned resources.
 func ManifestCmd() *cobra.Command {
 	mc := &cobra.Command{
 		Use:   "manifest",
 		Short: "Commands related to Istio manifests",
-		Long:  "The manifest subcommand generates, applies, diffs or migrates Istio manifests.",
+		Long:  "The manifest subcommand generates, applies, diffs, lints or migrates Istio manifests, or restores pruned resources.",
 	}
 
 	mgcArgs := &manifestGenerateArgs{}
@@ -31,6 +32,8 @@ func ManifestCmd() *cobra.Command {
 	macArgs := &manifestApplyArgs{}
 	mvArgs := &manifestVersionsArgs{}
 	mmcArgs := &manifestMigrateArgs{}
+	mlcArgs := &manifestLintArgs{}
+	mrcArgs := &manifestRestoreArgs{}
 
 	args := &rootArgs{}
 
@@ -39,6 +42,8 @@ func ManifestCmd() *cobra.Command {
 	mac := manifestApplyCmd(args, macArgs)
 	mvc := manifestVersionsCmd(args, mvArgs)
 	mmc := manifestMigrateCmd(args, mmcArgs)
+	mlc := manifestLintCmd(args, mlcArgs)
+	mrc := manifestRestoreCmd(args, mrcArgs)
 
 	addFlags(mc, args)
 	addFlags(mgc, args)
@@ -46,18 +51,24 @@ func ManifestCmd() *cobra.Command {
 	addFlags(mac, args)
 	addFlags(mvc, args)
 	addFlags(mmc, args)
+	addFlags(mlc, args)
+	addFlags(mrc, args)
 
 	addManifestGenerateFlags(mgc, mgcArgs)
 	addManifestDiffFlags(mdc, mdcArgs)
 	addManifestApplyFlags(mac, macArgs)
 	addManifestVersionsFlags(mvc, mvArgs)
 	addManifestMigrateFlags(mmc, mmcArgs)
+	addManifestLintFlags(mlc, mlcArgs)
+	addManifestRestoreFlags(mrc, mrcArgs)
 
 	mc.AddCommand(mgc)
 	mc.AddCommand(mdc)
 	mc.AddCommand(mac)
 	mc.AddCommand(mmc)
 	mc.AddCommand(mvc)
+	mc.AddCommand(mlc)
+	mc.AddCommand(mrc)
 
 	return mc
 }
@@ -17,6 +17,8 @@ package mesh
 import (
 	"fmt"
 	"io/ioutil"
+	"reflect"
+	"strings"
 
 	"github.com/ghodss/yaml"
 
@@ -27,6 +29,7 @@ import (
 	"istio.io/operator/pkg/translate"
 	"istio.io/operator/pkg/util"
 	"istio.io/operator/pkg/validate"
+	"istio.io/operator/pkg/yamlpos"
 	version2 "istio.io/operator/version"
 	"istio.io/pkg/version"
 )
@@ -52,15 +55,22 @@ func genICPS(inFilename, profile, setOverlayYAML string, force bool, l *logger)
 	if setProfile, ok := set["profile"]; ok {
 		profile = setProfile.(string)
 	}
+	var pos *yamlpos.Positions
 	if inFilename != "" {
 		b, err := ioutil.ReadFile(inFilename)
 		if err != nil {
 			return "", nil, fmt.Errorf("could not read values from file %s: %s", inFilename, err)
 		}
-		overlayICPS, overlayYAML, err = unmarshalAndValidateICP(string(b), force)
+		if pos, err = yamlpos.Parse(inFilename, b, util.Path{"spec"}); err != nil {
+			return "", nil, err
+		}
+		overlayICPS, overlayYAML, err = unmarshalAndValidateICP(string(b), force, pos)
 		if err != nil {
 			return "", nil, err
 		}
+		if err := checkDeprecations(overlayYAML, pos, l); err != nil {
+			return "", nil, err
+		}
 		profile = overlayICPS.Profile
 	}
 
@@ -86,7 +96,7 @@ func genICPS(inFilename, profile, setOverlayYAML string, force bool, l *logger)
 		}
 	}
 
-	_, baseYAML, err := unmarshalAndValidateICP(baseCRYAML, force)
+	_, baseYAML, err := unmarshalAndValidateICP(baseCRYAML, force, nil)
 	if err != nil {
 		return "", nil, err
 	}
@@ -111,7 +121,7 @@ func genICPS(inFilename, profile, setOverlayYAML string, force bool, l *logger)
 	if err != nil {
 		return "", nil, fmt.Errorf("could not overlay user config over base: %s", err)
 	}
-	if _, err := unmarshalAndValidateICPS(mergedYAML, force, l); err != nil {
+	if _, err := unmarshalAndValidateICPS(mergedYAML, force, pos, l); err != nil {
 		return "", nil, err
 	}
 
@@ -121,10 +131,24 @@ func genICPS(inFilename, profile, setOverlayYAML string, force bool, l *logger)
 		return "", nil, fmt.Errorf("could not overlay --set values over merged: %s", err)
 	}
 
-	finalICPS, err := unmarshalAndValidateICPS(finalYAML, force, l)
+	finalICPS, err := unmarshalAndValidateICPS(finalYAML, force, pos, l)
 	if err != nil {
 		return "", nil, err
 	}
+	// The user spec is the user overlay and the --set values, before they are overlaid on the profile.
+	userYAML := setOverlayYAML
+	if overlayYAML != "" {
+		if userYAML, err = util.OverlayYAML(overlayYAML, setOverlayYAML); err != nil {
+			return "", nil, fmt.Errorf("could not overlay --set values over user config: %s", err)
+		}
+	}
+	userICPS := &v1alpha2.IstioControlPlaneSpec{}
+	if err := util.UnmarshalWithJSONPB(userYAML, userICPS); err != nil {
+		return "", nil, err
+	}
+	if err := checkSemantics(finalICPS, userICPS, force, pos, l); err != nil {
+		return "", nil, err
+	}
 	return finalYAML, finalICPS, nil
 }
 
@@ -154,17 +178,25 @@ func genProfile(helmValues bool, inFilename, profile, setOverlayYAML, configPath
 	return finalYAML, err
 }
 
-func unmarshalAndValidateICP(crYAML string, force bool) (*v1alpha2.IstioControlPlaneSpec, string, error) {
+// unmarshalAndValidateICP unmarshals and validates the IstioControlPlane CR in crYAML and returns its spec, also as
+// YAML. pos holds the source positions for crYAML, if it was read from a file, which are added to the errors.
+func unmarshalAndValidateICP(crYAML string, force bool, pos *yamlpos.Positions) (*v1alpha2.IstioControlPlaneSpec, string, error) {
 	// TODO: add GVK handling as appropriate.
 	if crYAML == "" {
 		return &v1alpha2.IstioControlPlaneSpec{}, "", nil
 	}
 	icps, _, err := manifest.ParseK8SYAMLToIstioControlPlaneSpec(crYAML)
 	if err != nil {
+		if s, ok := sourceErrorsString(err, pos); ok {
+			return nil, "", fmt.Errorf("could not unmarshal the overlay file:\n%s", s)
+		}
 		return nil, "", fmt.Errorf("could not unmarshal the overlay file: %s\n\nOriginal YAML:\n%s", err, crYAML)
 	}
 	if errs := validate.CheckIstioControlPlaneSpec(icps, false); len(errs) != 0 {
 		if !force {
+			if s, ok := sourceErrorsString(errs, pos); ok {
+				return nil, "", fmt.Errorf("input file failed validation with the following errors:\n%s", s)
+			}
 			return nil, "", fmt.Errorf("input file failed validation with the following errors: %s\n\nOriginal YAML:\n%s", errs, crYAML)
 		}
 	}
@@ -175,21 +207,100 @@ func unmarshalAndValidateICP(crYAML string, force bool) (*v1alpha2.IstioControlP
 	return icps, icpsYAML, nil
 }
 
-func unmarshalAndValidateICPS(icpsYAML string, force bool, l *logger) (*v1alpha2.IstioControlPlaneSpec, error) {
+// unmarshalAndValidateICPS unmarshals and validates the merged IstioControlPlaneSpec in icpsYAML. pos holds the source
+// positions of the user overlay file, if any, which are added to errors about fields set in that file.
+func unmarshalAndValidateICPS(icpsYAML string, force bool, pos *yamlpos.Positions, l *logger) (*v1alpha2.IstioControlPlaneSpec, error) {
 	icps := &v1alpha2.IstioControlPlaneSpec{}
 	if err := util.UnmarshalWithJSONPB(icpsYAML, icps); err != nil {
+		if s, ok := sourceErrorsString(err, pos); ok {
+			return nil, fmt.Errorf("could not unmarshal the merged YAML:\n%s", s)
+		}
 		return nil, fmt.Errorf("could not unmarshal the merged YAML: %s\n\nYAML:\n%s", err, icpsYAML)
 	}
 	if errs := validate.CheckIstioControlPlaneSpec(icps, true); len(errs) != 0 {
+		s, _ := sourceErrorsString(errs, pos)
 		if !force {
 			l.logAndError("Run the command with the --force flag if you want to ignore the validation error and proceed.")
-			return nil, fmt.Errorf(errs.Error())
+			return nil, fmt.Errorf("%s", s)
 		}
-		l.logAndError("Proceeding despite the following validation errors: \n", errs.Error())
+		l.logAndError("Proceeding despite the following validation errors: \n", s)
 	}
 	return icps, nil
 }
 
+// sourceErrorsString returns err, which may be a util.Errors, as a string with the source position from pos added to
+// each error about a field whose position is known. If any position is known, the errors are listed one per line in
+// the file:line:column: message format used by compilers, so that editors can jump to them, and true is returned.
+// Otherwise the errors are formatted as usual.
+func sourceErrorsString(err error, pos *yamlpos.Positions) (string, bool) {
+	errs, ok := err.(util.Errors)
+	if !ok {
+		errs = util.Errors{err}
+	}
+	errs = pos.Annotate(errs)
+	for _, e := range errs {
+		if _, ok := e.(*yamlpos.Error); ok {
+			var lines []string
+			for _, e := range errs {
+				lines = append(lines, e.Error())
+			}
+			return strings.Join(lines, "\n"), true
+		}
+	}
+	return errs.Error(), false
+}
+
+// findingsString returns findings one per line, each prefixed with the source position from pos of the field it is
+// about, if known.
+func findingsString(findings validate.Findings, pos *yamlpos.Positions) string {
+	var lines []string
+	for _, f := range findings {
+		path := util.ToJSONPath(reflect.TypeOf(v1alpha2.IstioControlPlaneSpec{}), util.PathFromString(f.Path))
+		if p, ok := pos.Lookup(path); ok {
+			lines = append(lines, fmt.Sprintf("%s: %s", p, f))
+			continue
+		}
+		lines = append(lines, f.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// checkSemantics runs the semantic validation rules over the merged icps and the user spec userICPS. Warnings are
+// logged, errors are returned unless force is set.
+func checkSemantics(icps, userICPS *v1alpha2.IstioControlPlaneSpec, force bool, pos *yamlpos.Positions, l *logger) error {
+	findings := validate.CheckSemantics(icps, userICPS)
+	if w := findings.Warnings(); len(w) != 0 {
+		l.logAndError(findingsString(w, pos))
+	}
+	errs := findings.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	if !force {
+		l.logAndError("Run the command with the --force flag if you want to ignore the validation error and proceed.")
+		return fmt.Errorf("%s", findingsString(errs, pos))
+	}
+	l.logAndError("Proceeding despite the following validation errors: \n", findingsString(errs, pos))
+	return nil
+}
+
+// checkDeprecations logs a warning for each deprecated field set in the user supplied spec in icpsYAML, at its
+// position in pos.
+func checkDeprecations(icpsYAML string, pos *yamlpos.Positions, l *logger) error {
+	t, err := translate.NewTranslator(version2.OperatorBinaryVersion.MinorVersion)
+	if err != nil {
+		return err
+	}
+	findings, err := validate.CheckDeprecations(icpsYAML, t)
+	if err != nil {
+		return err
+	}
+	if len(findings) != 0 {
+		l.logAndError(findingsString(findings, pos))
+	}
+	return nil
+}
+
 func getConfigSubtree(manifest, path string) (string, error) {
 	root := make(map[string]interface{})
 	if err := yaml.Unmarshal([]byte(manifest), &root); err != nil {
@@ -200,7 +311,16 @@ func getConfigSubtree(manifest, path string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	out, err := yaml.Marshal(nc.Node)
+	node := nc.Node
+	if nc.Matches != nil {
+		// Paths matching multiple nodes return a list of all the matching subtrees.
+		nodes := make([]interface{}, 0, len(nc.Matches))
+		for _, m := range nc.Matches {
+			nodes = append(nodes, m.Node)
+		}
+		node = nodes
+	}
+	out, err := yaml.Marshal(node)
 	if err != nil {
 		return "", err
 	}
@@ -19,6 +19,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
 	"istio.io/operator/pkg/util"
 )
 
@@ -33,6 +34,9 @@ func TestProfileDump(t *testing.T) {
 		{
 			desc: "sds_policy_off",
 		},
+		{
+			desc: "deletion_policy",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
@@ -58,6 +62,10 @@ func TestProfileDump(t *testing.T) {
 			if !util.IsYAMLEqual(got, want) {
 				t.Errorf("profile-dump command(%s): got:\n%s\n\nwant:\n%s\nDiff:\n%s\n", tt.desc, got, want, util.YAMLDiff(got, want))
 			}
+			// The dumped spec must be accepted as input again.
+			if err := util.UnmarshalWithJSONPB(got, &v1alpha2.IstioControlPlaneSpec{}); err != nil {
+				t.Errorf("profile-dump command(%s): output does not unmarshal: %s", tt.desc, err)
+			}
 		})
 	}
 }
@@ -0,0 +1,143 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mesh
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+
+	"istio.io/operator/pkg/schema"
+)
+
+const (
+	// schemaOutputJSONSchema outputs a draft-07 JSON Schema, for IDEs and CI validators.
+	schemaOutputJSONSchema = "jsonschema"
+	// schemaOutputOpenAPI outputs an OpenAPI v3 structural schema.
+	schemaOutputOpenAPI = "openapi"
+	// schemaOutputCRD outputs the IstioControlPlane CRD with the OpenAPI v3 schema as its validation.
+	schemaOutputCRD = "crd"
+)
+
+type profileSchemaArgs struct {
+	// output is the schema format, one of jsonschema, openapi or crd.
+	output string
+	// values selects the schema of the Helm values rather than the IstioControlPlane CR.
+	values bool
+}
+
+func addProfileSchemaFlags(cmd *cobra.Command, args *profileSchemaArgs) {
+	cmd.PersistentFlags().StringVarP(&args.output, "output", "o", schemaOutputJSONSchema,
+		"Output format, one of jsonschema, openapi or crd")
+	cmd.PersistentFlags().BoolVar(&args.values, "values", false,
+		"If set, output the schema for the values field of IstioControlPlaneSpec rather than the IstioControlPlane CR")
+}
+
+func profileSchemaCmd(rootArgs *rootArgs, psArgs *profileSchemaArgs) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Outputs the schema for IstioControlPlane or Values",
+		Long: "The schema subcommand outputs a JSON Schema or OpenAPI v3 schema for IstioControlPlane CRs or for the values " +
+			"field, for validating CRs in IDEs and CI without the operator, or the IstioControlPlane CRD with validation.",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("schema accepts no positional arguments, got %#v", args)
+			}
+			switch psArgs.output {
+			case schemaOutputJSONSchema, schemaOutputOpenAPI:
+			case schemaOutputCRD:
+				if psArgs.values {
+					return fmt.Errorf("--values cannot be used with --output %s", schemaOutputCRD)
+				}
+			default:
+				return fmt.Errorf("unknown output format %s, must be one of %s, %s or %s", psArgs.output,
+					schemaOutputJSONSchema, schemaOutputOpenAPI, schemaOutputCRD)
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			l := newLogger(rootArgs.logToStdErr, cmd.OutOrStdout(), cmd.OutOrStderr())
+			profileSchema(rootArgs, psArgs, l)
+		}}
+}
+
+func profileSchema(rootArgs *rootArgs, psArgs *profileSchemaArgs, l *logger) {
+	initLogsOrExit(rootArgs)
+
+	out, err := genSchema(psArgs.output, psArgs.values)
+	if err != nil {
+		l.logAndFatal(err.Error())
+	}
+	l.print(out)
+}
+
+// genSchema returns the schema in the given output format, for Values if values is set and for IstioControlPlane
+// otherwise.
+func genSchema(output string, values bool) (string, error) {
+	if output == schemaOutputCRD {
+		crd, err := schema.IstioControlPlaneCRD()
+		if err != nil {
+			return "", err
+		}
+		// Drop the empty status and creation timestamp, which are set by the API server.
+		b, err := json.Marshal(crd)
+		if err != nil {
+			return "", err
+		}
+		crdMap := make(map[string]interface{})
+		if err := json.Unmarshal(b, &crdMap); err != nil {
+			return "", err
+		}
+		delete(crdMap, "status")
+		delete(crdMap["metadata"].(map[string]interface{}), "creationTimestamp")
+		return toYAMLDoc(crdMap)
+	}
+
+	title := "IstioControlPlane"
+	var s *apiextv1beta1.JSONSchemaProps
+	var err error
+	if values {
+		title = "Values"
+		s, err = schema.ValuesSchema()
+	} else {
+		s, err = schema.IstioControlPlaneSchema()
+	}
+	if err != nil {
+		return "", err
+	}
+	if output == schemaOutputOpenAPI {
+		return toYAMLDoc(s)
+	}
+	js, err := schema.ToJSONSchema(s, title)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.MarshalIndent(js, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+func toYAMLDoc(v interface{}) (string, error) {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return "---\n" + string(b), nil
+}
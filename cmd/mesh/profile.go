@@ -23,26 +23,31 @@ func ProfileCmd() *cobra.Command {
 	pc := &cobra.Command{
 		Use:   "profile",
 		Short: "Commands related to Istio configuration profiles",
-		Long:  "The profile subcommand lists, dumps or diffs Istio configuration profiles.",
+		Long:  "The profile subcommand lists, dumps or diffs Istio configuration profiles, or outputs their schema.",
 	}
 
 	pdArgs := &profileDumpArgs{}
+	psArgs := &profileSchemaArgs{}
 	args := &rootArgs{}
 
 	plc := profileListCmd(args)
 	pdc := profileDumpCmd(args, pdArgs)
 	pdfc := profileDiffCmd(args)
+	psc := profileSchemaCmd(args, psArgs)
 
 	addFlags(pc, args)
 	addFlags(plc, args)
 	addFlags(pdc, args)
 	addFlags(pdfc, args)
+	addFlags(psc, args)
 
 	addProfileDumpFlags(pdc, pdArgs)
+	addProfileSchemaFlags(psc, psArgs)
 
 	pc.AddCommand(plc)
 	pc.AddCommand(pdc)
 	pc.AddCommand(pdfc)
+	pc.AddCommand(psc)
 
 	return pc
 }
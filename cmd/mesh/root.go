@@ -26,7 +26,8 @@ import (
 const (
 	setFlagHelpStr = `Set a value in IstioControlPlane CustomResource. e.g. --set policy.enabled=true.
 Overrides the corresponding path value in the selected profile or passed through IstioControlPlane CR
-customization file`
+customization file. List entries can be selected with [key:value], [N] or [*] and appended with [+], e.g.
+--set trafficManagement.components.pilot.k8s.env.[0].value=bar`
 	skipConfirmationFlagHelpStr = `skipConfirmation determines whether the user is prompted for confirmation. 
 If set to true, the user is not prompted and a Yes response is assumed in all cases.`
 	filenameFlagHelpStr = `Path to file containing IstioControlPlane CustomResource`
@@ -25,12 +25,15 @@ import (
 	"istio.io/operator/pkg/tpath"
 
 	"github.com/ghodss/yaml"
-	goversion "github.com/hashicorp/go-version"
 	"github.com/spf13/cobra"
 
 	"istio.io/operator/pkg/compare"
 	"istio.io/operator/pkg/hooks"
 	"istio.io/operator/pkg/manifest"
+	"istio.io/operator/pkg/policy"
+	"istio.io/operator/pkg/util"
+	"istio.io/operator/pkg/version"
+	"istio.io/operator/pkg/yamlpos"
 	opversion "istio.io/operator/version"
 	"istio.io/pkg/log"
 )
@@ -68,6 +71,8 @@ type upgradeArgs struct {
 	skipConfirmation bool
 	// force means directly applying the upgrade without eligibility checks.
 	force bool
+	// policyFilename is the path to a rules file which the target manifests must pass before anything is changed.
+	policyFilename string
 }
 
 // addUpgradeFlags adds upgrade related flags into cobra command
@@ -90,6 +95,9 @@ func addUpgradeFlags(cmd *cobra.Command, args *upgradeArgs) {
 	cmd.PersistentFlags().BoolVar(&args.force, "force", false,
 		"Apply the upgrade without eligibility checks and testing for changes "+
 			"in profile default values")
+	cmd.PersistentFlags().StringVarP(&args.policyFilename, "policy", "p", "", policyFlagHelpStr+
+		" The target manifests are checked before the pre-upgrade hooks run, and nothing is changed if any rule with"+
+		" ERROR severity fails.")
 }
 
 // Upgrade command upgrades Istio control plane in-place with eligibility checks
@@ -188,6 +196,16 @@ func upgrade(rootArgs *rootArgs, args *upgradeArgs, l *logger) (err error) {
 		return fmt.Errorf("failed to generate override values from file: %v, error: %v", args.inFilename, err)
 	}
 	checkUpgradeValues(currentValues, targetValues, overrideValues, l)
+
+	// Check that the overlays in args.inFilename still apply to the target charts
+	if err := checkUpgradeOverlays(args.inFilename, args.force, l); err != nil {
+		return err
+	}
+
+	// Check the target manifests against the policy before the cluster is changed by the hooks or the apply
+	if err := checkUpgradePolicy(args.inFilename, args.policyFilename, args.force, l); err != nil {
+		return err
+	}
 	waitForConfirmation(args.skipConfirmation, l)
 
 	// Run pre-upgrade hooks
@@ -204,7 +222,7 @@ func upgrade(rootArgs *rootArgs, args *upgradeArgs, l *logger) (err error) {
 
 	// Apply the Istio Control Plane specs reading from inFilename to the cluster
 	err = genApplyManifests(nil, args.inFilename, args.force, rootArgs.dryRun,
-		rootArgs.verbose, args.kubeConfigPath, args.context, upgradeWaitSecWhenApply, l)
+		rootArgs.verbose, args.kubeConfigPath, args.context, upgradeWaitSecWhenApply, "", false, "", l)
 	if err != nil {
 		return fmt.Errorf("failed to apply the Istio Control Plane specs. Error: %v", err)
 	}
@@ -250,6 +268,30 @@ func checkUpgradeValues(curValues, tarValues, ignoreValues string, l *logger) {
 	}
 }
 
+// checkUpgradeOverlays checks that every k8s overlay in inFilename still matches its target object and changes it when
+// rendered against the target charts, so that overlays made stale by chart changes are caught before upgrading.
+func checkUpgradeOverlays(inFilename string, force bool, l *logger) error {
+	analysis, err := analyzeOverlays(inFilename, "", force, l)
+	if err != nil {
+		return fmt.Errorf("failed to analyze overlays from file: %v, error: %v", inFilename, err)
+	}
+	stale := analysis.Stale()
+	if len(stale) == 0 {
+		l.logAndPrintf("Upgrade check: All overlays apply to the target manifest.\n")
+		return nil
+	}
+	var sb strings.Builder
+	for _, r := range stale {
+		sb.WriteString(r.String())
+	}
+	if !force {
+		return fmt.Errorf("the following overlays have no effect on the target manifest, "+
+			"please update them or use --force to proceed:\n%s", sb.String())
+	}
+	l.logAndPrintf("Upgrade check: Warning!!! The following overlays have no effect on the target manifest:\n%s", sb.String())
+	return nil
+}
+
 // waitForConfirmation waits for user's confirmation if skipConfirmation is not set
 func waitForConfirmation(skipConfirmation bool, l *logger) {
 	if skipConfirmation {
@@ -292,26 +334,11 @@ func readValuesFromInjectorConfigMap(kubeClient manifest.ExecClient, istioNamesp
 
 // checkSupportedVersions checks if the upgrade cur -> tar is supported by the tool
 func checkSupportedVersions(cur, tar, versionsURI string, l *logger) error {
-	tarGoVersion, err := goversion.NewVersion(tar)
-	if err != nil {
-		return fmt.Errorf("failed to parse the target version: %v", tar)
-	}
-
-	compatibleMap, err := getVersionCompatibleMap(versionsURI, tarGoVersion, l)
+	b, err := loadCompatibleMapFile(versionsURI, l)
 	if err != nil {
 		return err
 	}
-
-	curGoVersion, err := goversion.NewVersion(cur)
-	if err != nil {
-		return fmt.Errorf("failed to parse the current version: %v, error: %v", cur, err)
-	}
-
-	if !compatibleMap.SupportedIstioVersions.Check(curGoVersion) {
-		return fmt.Errorf("upgrade is currently not supported: %v -> %v", cur, tar)
-	}
-
-	return nil
+	return version.CheckUpgrade(b, cur, tar)
 }
 
 // retrieveControlPlaneVersion retrieves the version number from the Istio control plane
@@ -408,7 +435,11 @@ func genOverlayICPS(filename string, force bool) (string, *v1alpha2.IstioControl
 	if err != nil {
 		return "", nil, fmt.Errorf("could not read from file %s: %s", filename, err)
 	}
-	overlayICPS, _, err := unmarshalAndValidateICP(string(b), force)
+	pos, err := yamlpos.Parse(filename, b, util.Path{"spec"})
+	if err != nil {
+		return "", nil, err
+	}
+	overlayICPS, _, err := unmarshalAndValidateICP(string(b), force, pos)
 	if err != nil {
 		return "", nil, err
 	}
@@ -427,3 +458,23 @@ func genOverlayICPS(filename string, force bool) (string, *v1alpha2.IstioControl
 
 	return string(overlayValues), overlayICPS, nil
 }
+
+// checkUpgradePolicy renders the target manifests from inFilename and checks them against the rules in
+// policyFilename, if it is set. It returns an error if any rule with ERROR severity fails.
+func checkUpgradePolicy(inFilename, policyFilename string, force bool, l *logger) error {
+	if policyFilename == "" {
+		return nil
+	}
+	rules, err := policy.ReadRulesFile(policyFilename)
+	if err != nil {
+		return err
+	}
+	manifests, err := genManifests(inFilename, "", force, l)
+	if err != nil {
+		return fmt.Errorf("failed to generate the target manifests, error: %v", err)
+	}
+	if err := checkPolicy(rules, manifests, l); err != nil {
+		return fmt.Errorf("target manifests failed policy check, nothing was upgraded: %v", err)
+	}
+	return nil
+}
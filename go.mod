@@ -55,6 +55,7 @@ require (
 	google.golang.org/genproto v0.0.0-20190819201941-24fa4b261c55 // indirect
 	google.golang.org/grpc v1.24.0 // indirect
 	gopkg.in/yaml.v2 v2.2.4
+	gopkg.in/yaml.v3 v3.0.1
 	istio.io/pkg v0.0.0-20191029184635-5c2f5ef63692
 	k8s.io/api v0.0.0
 	k8s.io/apiextensions-apiserver v0.0.0
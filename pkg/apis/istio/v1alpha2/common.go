@@ -18,6 +18,7 @@ package v1alpha2
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/gogo/protobuf/jsonpb"
 	protobuf "github.com/gogo/protobuf/types"
@@ -82,3 +83,62 @@ func (boolvaluepb *BoolValueForPB) MarshalJSONPB(_ *jsonpb.Marshaler) ([]byte, e
 func (boolvaluepb *BoolValueForPB) UnmarshalJSONPB(_ *jsonpb.Unmarshaler, value []byte) error {
 	return boolvaluepb.UnmarshalJSON(value)
 }
+
+// define new type from protobuf.Int64Value to marshal/unmarshal jsonpb, so that a value of 0 can be told from no value
+type Int64ValueForPB struct {
+	protobuf.Int64Value
+}
+
+// MarshalJSON implements the json.JSONMarshaler interface.
+func (int64valuepb *Int64ValueForPB) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64valuepb.GetValue())
+}
+
+// UnmarshalJSON implements the json.JSONUnmarshaler interface.
+func (int64valuepb *Int64ValueForPB) UnmarshalJSON(value []byte) error {
+	return json.Unmarshal(value, &(int64valuepb.Value))
+}
+
+// MarshalJSONPB implements the jsonpb.JSONPBMarshaler interface.
+func (int64valuepb *Int64ValueForPB) MarshalJSONPB(_ *jsonpb.Marshaler) ([]byte, error) {
+	return int64valuepb.MarshalJSON()
+}
+
+// UnmarshalJSONPB implements the jsonpb.JSONPBUnmarshaler interface.
+func (int64valuepb *Int64ValueForPB) UnmarshalJSONPB(_ *jsonpb.Unmarshaler, value []byte) error {
+	return int64valuepb.UnmarshalJSON(value)
+}
+
+// MarshalJSON implements the json.JSONMarshaler interface. DeletionPolicy is marshaled by name, which is the form the
+// IstioControlPlane CRD accepts, with both encoding/json and jsonpb.
+func (x DeletionPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(x.String())
+}
+
+// UnmarshalJSON implements the json.JSONUnmarshaler interface. It accepts a DeletionPolicy name or number.
+func (x *DeletionPolicy) UnmarshalJSON(value []byte) error {
+	var n int32
+	if err := json.Unmarshal(value, &n); err == nil {
+		if _, ok := DeletionPolicy_name[n]; !ok {
+			return fmt.Errorf("unknown value %d for enum DeletionPolicy", n)
+		}
+		*x = DeletionPolicy(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return fmt.Errorf("invalid value %s for enum DeletionPolicy", value)
+	}
+	n, ok := DeletionPolicy_value[s]
+	if !ok {
+		return fmt.Errorf("unknown value %q for enum DeletionPolicy", s)
+	}
+	*x = DeletionPolicy(n)
+	return nil
+}
+
+// UnmarshalJSONPB implements the jsonpb.JSONPBUnmarshaler interface. jsonpb resolves enum names with the gogo proto
+// registry, which DeletionPolicy is not registered in, so it unmarshals itself.
+func (x *DeletionPolicy) UnmarshalJSONPB(_ *jsonpb.Unmarshaler, value []byte) error {
+	return x.UnmarshalJSON(value)
+}
@@ -32,6 +32,8 @@
 //     Once a manifest is rendered from InstallSpec, a further customization can be applied by specifying k8s resource
 //     overlays. The concept is similar to kustomize, where JSON patches are applied for object paths. This allows
 //     customization at the lowest level and eliminates the need to create ad-hoc template parameters, or edit templates.
+//     Whole objects can also be added to or removed from a component's rendered manifest using extraObjects and
+//     removeObjects.
 //
 // Here are a few example uses:
 //
@@ -156,6 +158,38 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// DeletionPolicy selects which resources of an IstioControlPlane are kept when it is deleted.
+type DeletionPolicy int32
+
+const (
+	// Delete deletes all the resources. It is the default.
+	DeletionPolicy_Delete DeletionPolicy = 0
+	// Retain keeps all the resources.
+	DeletionPolicy_Retain DeletionPolicy = 1
+	// RetainCRDs keeps the CustomResourceDefinitions, and so the custom resources of users such as VirtualServices.
+	DeletionPolicy_RetainCRDs DeletionPolicy = 2
+)
+
+var DeletionPolicy_name = map[int32]string{
+	0: "Delete",
+	1: "Retain",
+	2: "RetainCRDs",
+}
+
+var DeletionPolicy_value = map[string]int32{
+	"Delete":     0,
+	"Retain":     1,
+	"RetainCRDs": 2,
+}
+
+func (x DeletionPolicy) String() string {
+	return proto.EnumName(DeletionPolicy_name, int32(x))
+}
+
+func (DeletionPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_daac92937abd81a4, []int{0}
+}
+
 type InstallStatus_Status int32
 
 const (
@@ -164,6 +198,8 @@ const (
 	InstallStatus_HEALTHY     InstallStatus_Status = 2
 	InstallStatus_ERROR       InstallStatus_Status = 3
 	InstallStatus_RECONCILING InstallStatus_Status = 4
+	// The component was not applied because a component it depends on failed.
+	InstallStatus_BLOCKED InstallStatus_Status = 5
 )
 
 var InstallStatus_Status_name = map[int32]string{
@@ -172,6 +208,7 @@ var InstallStatus_Status_name = map[int32]string{
 	2: "HEALTHY",
 	3: "ERROR",
 	4: "RECONCILING",
+	5: "BLOCKED",
 }
 
 var InstallStatus_Status_value = map[string]int32{
@@ -180,6 +217,7 @@ var InstallStatus_Status_value = map[string]int32{
 	"HEALTHY":     2,
 	"ERROR":       3,
 	"RECONCILING": 4,
+	"BLOCKED":     5,
 }
 
 func (x InstallStatus_Status) String() string {
@@ -288,6 +326,26 @@ type IstioControlPlaneSpec struct {
 	Values map[string]interface{} `protobuf:"bytes,50,opt,name=values,proto3" json:"values,omitempty"`
 	// Unvalidated overrides for default global values.yaml.
 	UnvalidatedValues map[string]interface{} `protobuf:"bytes,51,opt,name=unvalidatedValues,proto3" json:"unvalidatedValues,omitempty"`
+	// Labels added to every object rendered for every component, including created namespaces.
+	CommonLabels map[string]string `protobuf:"bytes,60,rep,name=common_labels,json=commonLabels,proto3" json:"common_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Annotations added to every object rendered for every component, including created namespaces.
+	CommonAnnotations map[string]string `protobuf:"bytes,61,rep,name=common_annotations,json=commonAnnotations,proto3" json:"common_annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Labels added to the pod template of every workload rendered for every component. A label that the chart already
+	// sets to a different value on a pod template is an error, since selectors may depend on it.
+	CommonPodLabels map[string]string `protobuf:"bytes,62,rep,name=common_pod_labels,json=commonPodLabels,proto3" json:"common_pod_labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Annotations added to the pod template of every workload rendered for every component.
+	CommonPodAnnotations map[string]string `protobuf:"bytes,63,rep,name=common_pod_annotations,json=commonPodAnnotations,proto3" json:"common_pod_annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Overrides the component each component depends on, keyed by component name. A component is applied after the
+	// component it depends on is ready, and is not applied if that component fails. Components depend on Base by
+	// default.
+	ComponentDependencies map[string]string `protobuf:"bytes,64,rep,name=component_dependencies,json=componentDependencies,proto3" json:"component_dependencies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// What happens to the resources of the IstioControlPlane when it is deleted. Kept resources are detached from the
+	// IstioControlPlane.
+	DeletionPolicy DeletionPolicy `protobuf:"varint,65,opt,name=deletion_policy,json=deletionPolicy,proto3,enum=v1alpha2.DeletionPolicy" json:"deletion_policy,omitempty"`
+	// Kinds of resources which are kept when the IstioControlPlane is deleted, in addition to those kept by the
+	// deletion policy, as Kind or Kind.group, e.g. Namespace or ValidatingWebhookConfiguration.admissionregistration.k8s.io.
+	// A Kind without a group matches the kind in any group.
+	RetainKinds []string `protobuf:"bytes,66,rep,name=retain_kinds,json=retainKinds,proto3" json:"retain_kinds,omitempty"`
 	// Path or name for the profile e.g.
 	//     - minimal (looks in profiles dir for a file called minimal.yaml)
 	//     - /tmp/istio/install/values/custom/custom-install.yaml (local file path)
@@ -416,6 +474,55 @@ func (m *IstioControlPlaneSpec) GetUnvalidatedValues() map[string]interface{} {
 	return nil
 }
 
+func (m *IstioControlPlaneSpec) GetCommonLabels() map[string]string {
+	if m != nil {
+		return m.CommonLabels
+	}
+	return nil
+}
+
+func (m *IstioControlPlaneSpec) GetCommonAnnotations() map[string]string {
+	if m != nil {
+		return m.CommonAnnotations
+	}
+	return nil
+}
+
+func (m *IstioControlPlaneSpec) GetCommonPodLabels() map[string]string {
+	if m != nil {
+		return m.CommonPodLabels
+	}
+	return nil
+}
+
+func (m *IstioControlPlaneSpec) GetCommonPodAnnotations() map[string]string {
+	if m != nil {
+		return m.CommonPodAnnotations
+	}
+	return nil
+}
+
+func (m *IstioControlPlaneSpec) GetComponentDependencies() map[string]string {
+	if m != nil {
+		return m.ComponentDependencies
+	}
+	return nil
+}
+
+func (m *IstioControlPlaneSpec) GetDeletionPolicy() DeletionPolicy {
+	if m != nil {
+		return m.DeletionPolicy
+	}
+	return DeletionPolicy_Delete
+}
+
+func (m *IstioControlPlaneSpec) GetRetainKinds() []string {
+	if m != nil {
+		return m.RetainKinds
+	}
+	return nil
+}
+
 func (m *IstioControlPlaneSpec) GetProfile() string {
 	if m != nil {
 		return m.Profile
@@ -1113,11 +1220,17 @@ type GatewayFeatureSpec_Components struct {
 	// Namespace that auto injections components are installed into.
 	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
 	// Ingress/egress gateway configuration.
-	IngressGateway       *IngressGatewayComponentSpec `protobuf:"bytes,31,opt,name=ingress_gateway,json=ingressGateway,proto3" json:"ingress_gateway,omitempty"`
-	EgressGateway        *EgressGatewayComponentSpec  `protobuf:"bytes,32,opt,name=egress_gateway,json=egressGateway,proto3" json:"egress_gateway,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
-	XXX_unrecognized     []byte                       `json:"-"`
-	XXX_sizecache        int32                        `json:"-"`
+	IngressGateway *IngressGatewayComponentSpec `protobuf:"bytes,31,opt,name=ingress_gateway,json=ingressGateway,proto3" json:"ingress_gateway,omitempty"`
+	EgressGateway  *EgressGatewayComponentSpec  `protobuf:"bytes,32,opt,name=egress_gateway,json=egressGateway,proto3" json:"egress_gateway,omitempty"`
+	// Additional named ingress gateways. Each is rendered from the ingress gateway chart and installed as a
+	// separate component.
+	IngressGateways []*GatewaySpec `protobuf:"bytes,33,rep,name=ingress_gateways,json=ingressGateways,proto3" json:"ingress_gateways,omitempty"`
+	// Additional named egress gateways. Each is rendered from the egress gateway chart and installed as a
+	// separate component.
+	EgressGateways       []*GatewaySpec `protobuf:"bytes,34,rep,name=egress_gateways,json=egressGateways,proto3" json:"egress_gateways,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *GatewayFeatureSpec_Components) Reset()         { *m = GatewayFeatureSpec_Components{} }
@@ -1166,6 +1279,20 @@ func (m *GatewayFeatureSpec_Components) GetEgressGateway() *EgressGatewayCompone
 	return nil
 }
 
+func (m *GatewayFeatureSpec_Components) GetIngressGateways() []*GatewaySpec {
+	if m != nil {
+		return m.IngressGateways
+	}
+	return nil
+}
+
+func (m *GatewayFeatureSpec_Components) GetEgressGateways() []*GatewaySpec {
+	if m != nil {
+		return m.EgressGateways
+	}
+	return nil
+}
+
 // Configuration options for cni feature.
 type CNIFeatureSpec struct {
 	// Selects whether CNI feature is installed. Must be set for any sub-component to be installed.
@@ -2133,11 +2260,46 @@ type KubernetesResourcesSpec struct {
 	// k8s toleration
 	// https://kubernetes.io/docs/concepts/configuration/taint-and-toleration/
 	Tolerations []*v1.Toleration `protobuf:"bytes,14,rep,name=tolerations,proto3" json:"tolerations,omitempty"`
+	// k8s container securityContext, applied to the component container.
+	// https://kubernetes.io/docs/tasks/configure-pod-container/security-context/
+	SecurityContext *v1.SecurityContext `protobuf:"bytes,15,opt,name=security_context,json=securityContext,proto3" json:"security_context,omitempty"`
+	// k8s pod securityContext.
+	// https://kubernetes.io/docs/tasks/configure-pod-container/security-context/
+	PodSecurityContext *v1.PodSecurityContext `protobuf:"bytes,16,opt,name=pod_security_context,json=podSecurityContext,proto3" json:"pod_security_context,omitempty"`
+	// k8s imagePullSecrets.
+	// https://kubernetes.io/docs/concepts/containers/images/#specifying-imagepullsecrets-on-a-pod
+	ImagePullSecrets []*v1.LocalObjectReference `protobuf:"bytes,17,rep,name=image_pull_secrets,json=imagePullSecrets,proto3" json:"image_pull_secrets,omitempty"`
+	// k8s serviceAccountName.
+	// https://kubernetes.io/docs/tasks/configure-pod-container/configure-service-account/
+	ServiceAccountName string `protobuf:"bytes,18,opt,name=service_account_name,json=serviceAccountName,proto3" json:"service_account_name,omitempty"`
+	// k8s livenessProbe settings.
+	// https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/
+	LivenessProbe *ReadinessProbe `protobuf:"bytes,19,opt,name=liveness_probe,json=livenessProbe,proto3" json:"liveness_probe,omitempty"`
+	// k8s startupProbe settings.
+	// https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/
+	StartupProbe *ReadinessProbe `protobuf:"bytes,20,opt,name=startup_probe,json=startupProbe,proto3" json:"startup_probe,omitempty"`
+	// k8s container lifecycle hooks, e.g. a preStop hook for graceful drain.
+	// https://kubernetes.io/docs/concepts/containers/container-lifecycle-hooks/
+	Lifecycle *Lifecycle `protobuf:"bytes,21,opt,name=lifecycle,proto3" json:"lifecycle,omitempty"`
+	// k8s pod terminationGracePeriodSeconds.
+	// https://kubernetes.io/docs/concepts/workloads/pods/pod/#termination-of-pods
+	TerminationGracePeriodSeconds *Int64ValueForPB `protobuf:"bytes,22,opt,name=termination_grace_period_seconds,json=terminationGracePeriodSeconds,proto3" json:"termination_grace_period_seconds,omitempty"`
+	// k8s pod topologySpreadConstraints.
+	// https://kubernetes.io/docs/concepts/workloads/pods/pod-topology-spread-constraints/
+	TopologySpreadConstraints []*TopologySpreadConstraint `protobuf:"bytes,23,rep,name=topology_spread_constraints,json=topologySpreadConstraints,proto3" json:"topology_spread_constraints,omitempty"`
+	// Settings for individual containers in the component pod, keyed by container name. The top level container
+	// settings above apply to the main component container.
+	Containers map[string]*ContainerSpec `protobuf:"bytes,24,rep,name=containers,proto3" json:"containers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// Overlays for k8s resources in rendered manifests.
-	Overlays             []*K8SObjectOverlay `protobuf:"bytes,100,rep,name=overlays,proto3" json:"overlays,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	Overlays []*K8SObjectOverlay `protobuf:"bytes,100,rep,name=overlays,proto3" json:"overlays,omitempty"`
+	// Additional k8s objects to add to the rendered manifest for the component, as full resource trees.
+	// Namespaced objects without a namespace are placed in the component namespace.
+	ExtraObjects []map[string]interface{} `protobuf:"bytes,101,rep,name=extra_objects,json=extraObjects,proto3" json:"extra_objects,omitempty"`
+	// k8s objects to remove from the rendered manifest for the component.
+	RemoveObjects        []*K8SObjectReference `protobuf:"bytes,102,rep,name=remove_objects,json=removeObjects,proto3" json:"remove_objects,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *KubernetesResourcesSpec) Reset()         { *m = KubernetesResourcesSpec{} }
@@ -2263,6 +2425,76 @@ func (m *KubernetesResourcesSpec) GetTolerations() []*v1.Toleration {
 	return nil
 }
 
+func (m *KubernetesResourcesSpec) GetSecurityContext() *v1.SecurityContext {
+	if m != nil {
+		return m.SecurityContext
+	}
+	return nil
+}
+
+func (m *KubernetesResourcesSpec) GetPodSecurityContext() *v1.PodSecurityContext {
+	if m != nil {
+		return m.PodSecurityContext
+	}
+	return nil
+}
+
+func (m *KubernetesResourcesSpec) GetImagePullSecrets() []*v1.LocalObjectReference {
+	if m != nil {
+		return m.ImagePullSecrets
+	}
+	return nil
+}
+
+func (m *KubernetesResourcesSpec) GetServiceAccountName() string {
+	if m != nil {
+		return m.ServiceAccountName
+	}
+	return ""
+}
+
+func (m *KubernetesResourcesSpec) GetLivenessProbe() *ReadinessProbe {
+	if m != nil {
+		return m.LivenessProbe
+	}
+	return nil
+}
+
+func (m *KubernetesResourcesSpec) GetStartupProbe() *ReadinessProbe {
+	if m != nil {
+		return m.StartupProbe
+	}
+	return nil
+}
+
+func (m *KubernetesResourcesSpec) GetLifecycle() *Lifecycle {
+	if m != nil {
+		return m.Lifecycle
+	}
+	return nil
+}
+
+func (m *KubernetesResourcesSpec) GetTerminationGracePeriodSeconds() *Int64ValueForPB {
+	if m != nil {
+		return m.TerminationGracePeriodSeconds
+	}
+	return nil
+}
+
+func (m *KubernetesResourcesSpec) GetTopologySpreadConstraints() []*TopologySpreadConstraint {
+	if m != nil {
+		return m.TopologySpreadConstraints
+	}
+	return nil
+}
+
+func (m *KubernetesResourcesSpec) GetContainers() map[string]*ContainerSpec {
+	if m != nil {
+		return m.Containers
+	}
+	return nil
+}
+
 func (m *KubernetesResourcesSpec) GetOverlays() []*K8SObjectOverlay {
 	if m != nil {
 		return m.Overlays
@@ -2270,6 +2502,20 @@ func (m *KubernetesResourcesSpec) GetOverlays() []*K8SObjectOverlay {
 	return nil
 }
 
+func (m *KubernetesResourcesSpec) GetExtraObjects() []map[string]interface{} {
+	if m != nil {
+		return m.ExtraObjects
+	}
+	return nil
+}
+
+func (m *KubernetesResourcesSpec) GetRemoveObjects() []*K8SObjectReference {
+	if m != nil {
+		return m.RemoveObjects
+	}
+	return nil
+}
+
 // Patch for an existing k8s resource.
 type K8SObjectOverlay struct {
 	// Resource API version.
@@ -2396,10 +2642,35 @@ func (m *K8SObjectOverlay_PathValue) GetValue() interface{} {
 
 // Observed state of IstioControlPlane.
 type InstallStatus struct {
-	Status               map[string]*InstallStatus_VersionStatus `protobuf:"bytes,1,rep,name=status,proto3" json:"status,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                                `json:"-"`
-	XXX_unrecognized     []byte                                  `json:"-"`
-	XXX_sizecache        int32                                   `json:"-"`
+	Status map[string]*InstallStatus_VersionStatus `protobuf:"bytes,1,rep,name=status,proto3" json:"status,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Reason the controller has not applied the current generation, e.g. because reconciliation is paused or the
+	// rendered changes are waiting for approval.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Generation whose rendered changes are waiting for approval, if any.
+	PendingGeneration int64 `protobuf:"varint,3,opt,name=pendingGeneration,proto3" json:"pendingGeneration,omitempty"`
+	// Types of the resources applied by the controller, as apiVersion/kind, e.g. apps/v1/Deployment. Resources of these
+	// types are checked for pruning even when they are no longer rendered.
+	ResourceTypes []string `protobuf:"bytes,4,rep,name=resourceTypes,proto3" json:"resourceTypes,omitempty"`
+	// Resources which are no longer rendered but were not pruned, because they are protected by the
+	// operator.istio.io/do-not-prune annotation or prune dry run is enabled, e.g. "Deployment istio-system/foo (dry run)".
+	PruneSkipped []string `protobuf:"bytes,5,rep,name=pruneSkipped,proto3" json:"pruneSkipped,omitempty"`
+	// Namespace and name of the Secret the resources pruned by the last reconcile were backed up to, if any.
+	PruneBackup string `protobuf:"bytes,6,opt,name=pruneBackup,proto3" json:"pruneBackup,omitempty"`
+	// Conditions of the IstioControlPlane, e.g. a Conflict condition if it is not reconciled because it would manage
+	// the same resources as another IstioControlPlane.
+	Conditions []*InstallStatus_Condition `protobuf:"bytes,7,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	// Istio version, i.e. the tag, last applied successfully by the controller.
+	Version string `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`
+	// Install package path last applied successfully by the controller.
+	InstallPackagePath string `protobuf:"bytes,9,opt,name=installPackagePath,proto3" json:"installPackagePath,omitempty"`
+	// Progress and result of the last upgrade from one version or install package to another.
+	Upgrade *InstallStatus_Upgrade `protobuf:"bytes,10,opt,name=upgrade,proto3" json:"upgrade,omitempty"`
+	// Resources which were kept and detached when the IstioControlPlane was deleted, because of its deletion policy or
+	// retained kinds, e.g. "CustomResourceDefinition virtualservices.networking.istio.io".
+	Retained             []string `protobuf:"bytes,11,rep,name=retained,proto3" json:"retained,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *InstallStatus) Reset()         { *m = InstallStatus{} }
@@ -2434,14 +2705,90 @@ func (m *InstallStatus) GetStatus() map[string]*InstallStatus_VersionStatus {
 	return nil
 }
 
+func (m *InstallStatus) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *InstallStatus) GetPendingGeneration() int64 {
+	if m != nil {
+		return m.PendingGeneration
+	}
+	return 0
+}
+
+func (m *InstallStatus) GetResourceTypes() []string {
+	if m != nil {
+		return m.ResourceTypes
+	}
+	return nil
+}
+
+func (m *InstallStatus) GetPruneSkipped() []string {
+	if m != nil {
+		return m.PruneSkipped
+	}
+	return nil
+}
+
+func (m *InstallStatus) GetPruneBackup() string {
+	if m != nil {
+		return m.PruneBackup
+	}
+	return ""
+}
+
+func (m *InstallStatus) GetConditions() []*InstallStatus_Condition {
+	if m != nil {
+		return m.Conditions
+	}
+	return nil
+}
+
+func (m *InstallStatus) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *InstallStatus) GetInstallPackagePath() string {
+	if m != nil {
+		return m.InstallPackagePath
+	}
+	return ""
+}
+
+func (m *InstallStatus) GetUpgrade() *InstallStatus_Upgrade {
+	if m != nil {
+		return m.Upgrade
+	}
+	return nil
+}
+
+func (m *InstallStatus) GetRetained() []string {
+	if m != nil {
+		return m.Retained
+	}
+	return nil
+}
+
 type InstallStatus_VersionStatus struct {
-	Version              string               `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	Status               InstallStatus_Status `protobuf:"varint,2,opt,name=status,proto3,enum=v1alpha2.InstallStatus_Status" json:"status,omitempty"`
-	StatusString         string               `protobuf:"bytes,3,opt,name=statusString,proto3" json:"statusString,omitempty"`
-	Error                string               `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Version      string               `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Status       InstallStatus_Status `protobuf:"varint,2,opt,name=status,proto3,enum=v1alpha2.InstallStatus_Status" json:"status,omitempty"`
+	StatusString string               `protobuf:"bytes,3,opt,name=statusString,proto3" json:"statusString,omitempty"`
+	Error        string               `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// Hash of the manifest rendered for the component when it was last applied.
+	ManifestHash string `protobuf:"bytes,5,opt,name=manifestHash,proto3" json:"manifestHash,omitempty"`
+	// Hash of the generations, or resource versions for objects without a generation, of the objects of the
+	// component after it was last applied. Together with manifestHash, it is used to skip applying components
+	// which have not changed.
+	ObjectsHash          string   `protobuf:"bytes,6,opt,name=objectsHash,proto3" json:"objectsHash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *InstallStatus_VersionStatus) Reset()         { *m = InstallStatus_VersionStatus{} }
@@ -2497,73 +2844,250 @@ func (m *InstallStatus_VersionStatus) GetError() string {
 	return ""
 }
 
-// Mirrors k8s.io.api.core.v1.ResourceRequirements for unmarshaling.
-type Resources struct {
-	Limits               map[string]string `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Requests             map[string]string `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *InstallStatus_VersionStatus) GetManifestHash() string {
+	if m != nil {
+		return m.ManifestHash
+	}
+	return ""
 }
 
-func (m *Resources) Reset()         { *m = Resources{} }
-func (m *Resources) String() string { return proto.CompactTextString(m) }
-func (*Resources) ProtoMessage()    {}
-func (*Resources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_daac92937abd81a4, []int{27}
+func (m *InstallStatus_VersionStatus) GetObjectsHash() string {
+	if m != nil {
+		return m.ObjectsHash
+	}
+	return ""
 }
 
-func (m *Resources) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Resources.Unmarshal(m, b)
+// Condition of the IstioControlPlane as a whole, in the form of Kubernetes object conditions.
+type InstallStatus_Condition struct {
+	// Type of the condition, e.g. Conflict.
+	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	// Status of the condition, one of True, False or Unknown.
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Machine readable reason for the last transition of the condition.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Human readable description of the condition.
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Time of the last transition of the condition, in RFC 3339 form.
+	LastTransitionTime   string   `protobuf:"bytes,5,opt,name=lastTransitionTime,proto3" json:"lastTransitionTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *Resources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Resources.Marshal(b, m, deterministic)
+
+func (m *InstallStatus_Condition) Reset()         { *m = InstallStatus_Condition{} }
+func (m *InstallStatus_Condition) String() string { return proto.CompactTextString(m) }
+func (*InstallStatus_Condition) ProtoMessage()    {}
+func (*InstallStatus_Condition) Descriptor() ([]byte, []int) {
+	return fileDescriptor_daac92937abd81a4, []int{26, 1}
 }
-func (m *Resources) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Resources.Merge(m, src)
+
+func (m *InstallStatus_Condition) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstallStatus_Condition.Unmarshal(m, b)
 }
-func (m *Resources) XXX_Size() int {
-	return xxx_messageInfo_Resources.Size(m)
+func (m *InstallStatus_Condition) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstallStatus_Condition.Marshal(b, m, deterministic)
 }
-func (m *Resources) XXX_DiscardUnknown() {
-	xxx_messageInfo_Resources.DiscardUnknown(m)
+func (m *InstallStatus_Condition) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstallStatus_Condition.Merge(m, src)
+}
+func (m *InstallStatus_Condition) XXX_Size() int {
+	return xxx_messageInfo_InstallStatus_Condition.Size(m)
+}
+func (m *InstallStatus_Condition) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstallStatus_Condition.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Resources proto.InternalMessageInfo
+var xxx_messageInfo_InstallStatus_Condition proto.InternalMessageInfo
 
-func (m *Resources) GetLimits() map[string]string {
+func (m *InstallStatus_Condition) GetType() string {
 	if m != nil {
-		return m.Limits
+		return m.Type
 	}
-	return nil
+	return ""
 }
 
-func (m *Resources) GetRequests() map[string]string {
+func (m *InstallStatus_Condition) GetStatus() string {
 	if m != nil {
-		return m.Requests
+		return m.Status
 	}
-	return nil
+	return ""
 }
 
-// Mirrors k8s.io.api.core.v1.Probe for unmarshaling
-type ReadinessProbe struct {
-	Exec                 *ExecAction      `protobuf:"bytes,1,opt,name=exec,proto3" json:"exec,omitempty"`
-	HttpGet              *HTTPGetAction   `protobuf:"bytes,2,opt,name=httpGet,proto3" json:"httpGet,omitempty"`
-	TcpSocket            *TCPSocketAction `protobuf:"bytes,3,opt,name=tcpSocket,proto3" json:"tcpSocket,omitempty"`
-	InitialDelaySeconds  int32            `protobuf:"varint,4,opt,name=initialDelaySeconds,proto3" json:"initialDelaySeconds,omitempty"`
-	TimeoutSeconds       int32            `protobuf:"varint,5,opt,name=timeoutSeconds,proto3" json:"timeoutSeconds,omitempty"`
-	PeriodSeconds        int32            `protobuf:"varint,6,opt,name=periodSeconds,proto3" json:"periodSeconds,omitempty"`
-	SuccessThreshold     int32            `protobuf:"varint,7,opt,name=successThreshold,proto3" json:"successThreshold,omitempty"`
-	FailureThreshold     int32            `protobuf:"varint,8,opt,name=failureThreshold,proto3" json:"failureThreshold,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+func (m *InstallStatus_Condition) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
 }
 
-func (m *ReadinessProbe) Reset()         { *m = ReadinessProbe{} }
-func (m *ReadinessProbe) String() string { return proto.CompactTextString(m) }
-func (*ReadinessProbe) ProtoMessage()    {}
-func (*ReadinessProbe) Descriptor() ([]byte, []int) {
+func (m *InstallStatus_Condition) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *InstallStatus_Condition) GetLastTransitionTime() string {
+	if m != nil {
+		return m.LastTransitionTime
+	}
+	return ""
+}
+
+// Upgrade of the IstioControlPlane from one version or install package to another.
+type InstallStatus_Upgrade struct {
+	// Version upgraded from.
+	FromVersion string `protobuf:"bytes,1,opt,name=fromVersion,proto3" json:"fromVersion,omitempty"`
+	// Version upgraded to.
+	ToVersion string `protobuf:"bytes,2,opt,name=toVersion,proto3" json:"toVersion,omitempty"`
+	// Phase of the upgrade: PreUpgrade, Applying, PostUpgrade, Succeeded, Failed or Blocked.
+	Phase string `protobuf:"bytes,3,opt,name=phase,proto3" json:"phase,omitempty"`
+	// Human readable description of the phase, e.g. the error an upgrade failed with.
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Time the upgrade started, in RFC 3339 form.
+	StartTime string `protobuf:"bytes,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	// Time the upgrade succeeded or failed, in RFC 3339 form.
+	CompletionTime       string   `protobuf:"bytes,6,opt,name=completionTime,proto3" json:"completionTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InstallStatus_Upgrade) Reset()         { *m = InstallStatus_Upgrade{} }
+func (m *InstallStatus_Upgrade) String() string { return proto.CompactTextString(m) }
+func (*InstallStatus_Upgrade) ProtoMessage()    {}
+func (*InstallStatus_Upgrade) Descriptor() ([]byte, []int) {
+	return fileDescriptor_daac92937abd81a4, []int{26, 2}
+}
+
+func (m *InstallStatus_Upgrade) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InstallStatus_Upgrade.Unmarshal(m, b)
+}
+func (m *InstallStatus_Upgrade) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InstallStatus_Upgrade.Marshal(b, m, deterministic)
+}
+func (m *InstallStatus_Upgrade) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InstallStatus_Upgrade.Merge(m, src)
+}
+func (m *InstallStatus_Upgrade) XXX_Size() int {
+	return xxx_messageInfo_InstallStatus_Upgrade.Size(m)
+}
+func (m *InstallStatus_Upgrade) XXX_DiscardUnknown() {
+	xxx_messageInfo_InstallStatus_Upgrade.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InstallStatus_Upgrade proto.InternalMessageInfo
+
+func (m *InstallStatus_Upgrade) GetFromVersion() string {
+	if m != nil {
+		return m.FromVersion
+	}
+	return ""
+}
+
+func (m *InstallStatus_Upgrade) GetToVersion() string {
+	if m != nil {
+		return m.ToVersion
+	}
+	return ""
+}
+
+func (m *InstallStatus_Upgrade) GetPhase() string {
+	if m != nil {
+		return m.Phase
+	}
+	return ""
+}
+
+func (m *InstallStatus_Upgrade) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *InstallStatus_Upgrade) GetStartTime() string {
+	if m != nil {
+		return m.StartTime
+	}
+	return ""
+}
+
+func (m *InstallStatus_Upgrade) GetCompletionTime() string {
+	if m != nil {
+		return m.CompletionTime
+	}
+	return ""
+}
+
+// Mirrors k8s.io.api.core.v1.ResourceRequirements for unmarshaling.
+type Resources struct {
+	Limits               map[string]string `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Requests             map[string]string `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Resources) Reset()         { *m = Resources{} }
+func (m *Resources) String() string { return proto.CompactTextString(m) }
+func (*Resources) ProtoMessage()    {}
+func (*Resources) Descriptor() ([]byte, []int) {
+	return fileDescriptor_daac92937abd81a4, []int{27}
+}
+
+func (m *Resources) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Resources.Unmarshal(m, b)
+}
+func (m *Resources) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Resources.Marshal(b, m, deterministic)
+}
+func (m *Resources) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Resources.Merge(m, src)
+}
+func (m *Resources) XXX_Size() int {
+	return xxx_messageInfo_Resources.Size(m)
+}
+func (m *Resources) XXX_DiscardUnknown() {
+	xxx_messageInfo_Resources.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Resources proto.InternalMessageInfo
+
+func (m *Resources) GetLimits() map[string]string {
+	if m != nil {
+		return m.Limits
+	}
+	return nil
+}
+
+func (m *Resources) GetRequests() map[string]string {
+	if m != nil {
+		return m.Requests
+	}
+	return nil
+}
+
+// Mirrors k8s.io.api.core.v1.Probe for unmarshaling
+type ReadinessProbe struct {
+	Exec                 *ExecAction      `protobuf:"bytes,1,opt,name=exec,proto3" json:"exec,omitempty"`
+	HttpGet              *HTTPGetAction   `protobuf:"bytes,2,opt,name=httpGet,proto3" json:"httpGet,omitempty"`
+	TcpSocket            *TCPSocketAction `protobuf:"bytes,3,opt,name=tcpSocket,proto3" json:"tcpSocket,omitempty"`
+	InitialDelaySeconds  int32            `protobuf:"varint,4,opt,name=initialDelaySeconds,proto3" json:"initialDelaySeconds,omitempty"`
+	TimeoutSeconds       int32            `protobuf:"varint,5,opt,name=timeoutSeconds,proto3" json:"timeoutSeconds,omitempty"`
+	PeriodSeconds        int32            `protobuf:"varint,6,opt,name=periodSeconds,proto3" json:"periodSeconds,omitempty"`
+	SuccessThreshold     int32            `protobuf:"varint,7,opt,name=successThreshold,proto3" json:"successThreshold,omitempty"`
+	FailureThreshold     int32            `protobuf:"varint,8,opt,name=failureThreshold,proto3" json:"failureThreshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ReadinessProbe) Reset()         { *m = ReadinessProbe{} }
+func (m *ReadinessProbe) String() string { return proto.CompactTextString(m) }
+func (*ReadinessProbe) ProtoMessage()    {}
+func (*ReadinessProbe) Descriptor() ([]byte, []int) {
 	return fileDescriptor_daac92937abd81a4, []int{28}
 }
 
@@ -3049,14 +3573,428 @@ func (m *ObjectMeta) GetNamespace() string {
 	return ""
 }
 
+// Reference to a k8s resource in a rendered manifest.
+type K8SObjectReference struct {
+	// Resource API version.
+	ApiVersion string `protobuf:"bytes,1,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`
+	// Resource kind.
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Name of resource.
+	// Namespace is always the component namespace.
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *K8SObjectReference) Reset()         { *m = K8SObjectReference{} }
+func (m *K8SObjectReference) String() string { return proto.CompactTextString(m) }
+func (*K8SObjectReference) ProtoMessage()    {}
+func (*K8SObjectReference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_daac92937abd81a4, []int{37}
+}
+
+func (m *K8SObjectReference) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_K8SObjectReference.Unmarshal(m, b)
+}
+func (m *K8SObjectReference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_K8SObjectReference.Marshal(b, m, deterministic)
+}
+func (m *K8SObjectReference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_K8SObjectReference.Merge(m, src)
+}
+func (m *K8SObjectReference) XXX_Size() int {
+	return xxx_messageInfo_K8SObjectReference.Size(m)
+}
+func (m *K8SObjectReference) XXX_DiscardUnknown() {
+	xxx_messageInfo_K8SObjectReference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_K8SObjectReference proto.InternalMessageInfo
+
+func (m *K8SObjectReference) GetApiVersion() string {
+	if m != nil {
+		return m.ApiVersion
+	}
+	return ""
+}
+
+func (m *K8SObjectReference) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *K8SObjectReference) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// Mirrors k8s.io.api.core.v1.Lifecycle for unmarshaling
+type Lifecycle struct {
+	PostStart            *LifecycleHandler `protobuf:"bytes,1,opt,name=postStart,proto3" json:"postStart,omitempty"`
+	PreStop              *LifecycleHandler `protobuf:"bytes,2,opt,name=preStop,proto3" json:"preStop,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Lifecycle) Reset()         { *m = Lifecycle{} }
+func (m *Lifecycle) String() string { return proto.CompactTextString(m) }
+func (*Lifecycle) ProtoMessage()    {}
+func (*Lifecycle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_daac92937abd81a4, []int{38}
+}
+
+func (m *Lifecycle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Lifecycle.Unmarshal(m, b)
+}
+func (m *Lifecycle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Lifecycle.Marshal(b, m, deterministic)
+}
+func (m *Lifecycle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Lifecycle.Merge(m, src)
+}
+func (m *Lifecycle) XXX_Size() int {
+	return xxx_messageInfo_Lifecycle.Size(m)
+}
+func (m *Lifecycle) XXX_DiscardUnknown() {
+	xxx_messageInfo_Lifecycle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Lifecycle proto.InternalMessageInfo
+
+func (m *Lifecycle) GetPostStart() *LifecycleHandler {
+	if m != nil {
+		return m.PostStart
+	}
+	return nil
+}
+
+func (m *Lifecycle) GetPreStop() *LifecycleHandler {
+	if m != nil {
+		return m.PreStop
+	}
+	return nil
+}
+
+// Mirrors k8s.io.api.core.v1.Handler for unmarshaling
+type LifecycleHandler struct {
+	Exec                 *ExecAction      `protobuf:"bytes,1,opt,name=exec,proto3" json:"exec,omitempty"`
+	HttpGet              *HTTPGetAction   `protobuf:"bytes,2,opt,name=httpGet,proto3" json:"httpGet,omitempty"`
+	TcpSocket            *TCPSocketAction `protobuf:"bytes,3,opt,name=tcpSocket,proto3" json:"tcpSocket,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *LifecycleHandler) Reset()         { *m = LifecycleHandler{} }
+func (m *LifecycleHandler) String() string { return proto.CompactTextString(m) }
+func (*LifecycleHandler) ProtoMessage()    {}
+func (*LifecycleHandler) Descriptor() ([]byte, []int) {
+	return fileDescriptor_daac92937abd81a4, []int{39}
+}
+
+func (m *LifecycleHandler) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LifecycleHandler.Unmarshal(m, b)
+}
+func (m *LifecycleHandler) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LifecycleHandler.Marshal(b, m, deterministic)
+}
+func (m *LifecycleHandler) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LifecycleHandler.Merge(m, src)
+}
+func (m *LifecycleHandler) XXX_Size() int {
+	return xxx_messageInfo_LifecycleHandler.Size(m)
+}
+func (m *LifecycleHandler) XXX_DiscardUnknown() {
+	xxx_messageInfo_LifecycleHandler.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LifecycleHandler proto.InternalMessageInfo
+
+func (m *LifecycleHandler) GetExec() *ExecAction {
+	if m != nil {
+		return m.Exec
+	}
+	return nil
+}
+
+func (m *LifecycleHandler) GetHttpGet() *HTTPGetAction {
+	if m != nil {
+		return m.HttpGet
+	}
+	return nil
+}
+
+func (m *LifecycleHandler) GetTcpSocket() *TCPSocketAction {
+	if m != nil {
+		return m.TcpSocket
+	}
+	return nil
+}
+
+// Mirrors k8s.io.api.core.v1.TopologySpreadConstraint for unmarshaling
+type TopologySpreadConstraint struct {
+	MaxSkew              int32              `protobuf:"varint,1,opt,name=maxSkew,proto3" json:"maxSkew,omitempty"`
+	TopologyKey          string             `protobuf:"bytes,2,opt,name=topologyKey,proto3" json:"topologyKey,omitempty"`
+	WhenUnsatisfiable    string             `protobuf:"bytes,3,opt,name=whenUnsatisfiable,proto3" json:"whenUnsatisfiable,omitempty"`
+	LabelSelector        *v11.LabelSelector `protobuf:"bytes,4,opt,name=labelSelector,proto3" json:"labelSelector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *TopologySpreadConstraint) Reset()         { *m = TopologySpreadConstraint{} }
+func (m *TopologySpreadConstraint) String() string { return proto.CompactTextString(m) }
+func (*TopologySpreadConstraint) ProtoMessage()    {}
+func (*TopologySpreadConstraint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_daac92937abd81a4, []int{40}
+}
+
+func (m *TopologySpreadConstraint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopologySpreadConstraint.Unmarshal(m, b)
+}
+func (m *TopologySpreadConstraint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopologySpreadConstraint.Marshal(b, m, deterministic)
+}
+func (m *TopologySpreadConstraint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopologySpreadConstraint.Merge(m, src)
+}
+func (m *TopologySpreadConstraint) XXX_Size() int {
+	return xxx_messageInfo_TopologySpreadConstraint.Size(m)
+}
+func (m *TopologySpreadConstraint) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopologySpreadConstraint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopologySpreadConstraint proto.InternalMessageInfo
+
+func (m *TopologySpreadConstraint) GetMaxSkew() int32 {
+	if m != nil {
+		return m.MaxSkew
+	}
+	return 0
+}
+
+func (m *TopologySpreadConstraint) GetTopologyKey() string {
+	if m != nil {
+		return m.TopologyKey
+	}
+	return ""
+}
+
+func (m *TopologySpreadConstraint) GetWhenUnsatisfiable() string {
+	if m != nil {
+		return m.WhenUnsatisfiable
+	}
+	return ""
+}
+
+func (m *TopologySpreadConstraint) GetLabelSelector() *v11.LabelSelector {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return nil
+}
+
+// k8s settings for a single container in a component pod.
+type ContainerSpec struct {
+	// k8s resources settings.
+	Resources *Resources `protobuf:"bytes,1,opt,name=resources,proto3" json:"resources,omitempty"`
+	// Container environment variables.
+	Env []*v1.EnvVar `protobuf:"bytes,2,rep,name=env,proto3" json:"env,omitempty"`
+	// k8s imagePullPolicy.
+	ImagePullPolicy string `protobuf:"bytes,3,opt,name=image_pull_policy,json=imagePullPolicy,proto3" json:"image_pull_policy,omitempty"`
+	// k8s readinessProbe settings.
+	ReadinessProbe *ReadinessProbe `protobuf:"bytes,4,opt,name=readiness_probe,json=readinessProbe,proto3" json:"readiness_probe,omitempty"`
+	// k8s livenessProbe settings.
+	LivenessProbe *ReadinessProbe `protobuf:"bytes,5,opt,name=liveness_probe,json=livenessProbe,proto3" json:"liveness_probe,omitempty"`
+	// k8s startupProbe settings.
+	StartupProbe *ReadinessProbe `protobuf:"bytes,6,opt,name=startup_probe,json=startupProbe,proto3" json:"startup_probe,omitempty"`
+	// k8s container securityContext.
+	SecurityContext *v1.SecurityContext `protobuf:"bytes,7,opt,name=security_context,json=securityContext,proto3" json:"security_context,omitempty"`
+	// k8s container lifecycle hooks.
+	Lifecycle            *Lifecycle `protobuf:"bytes,8,opt,name=lifecycle,proto3" json:"lifecycle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ContainerSpec) Reset()         { *m = ContainerSpec{} }
+func (m *ContainerSpec) String() string { return proto.CompactTextString(m) }
+func (*ContainerSpec) ProtoMessage()    {}
+func (*ContainerSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_daac92937abd81a4, []int{41}
+}
+
+func (m *ContainerSpec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContainerSpec.Unmarshal(m, b)
+}
+func (m *ContainerSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContainerSpec.Marshal(b, m, deterministic)
+}
+func (m *ContainerSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContainerSpec.Merge(m, src)
+}
+func (m *ContainerSpec) XXX_Size() int {
+	return xxx_messageInfo_ContainerSpec.Size(m)
+}
+func (m *ContainerSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContainerSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContainerSpec proto.InternalMessageInfo
+
+func (m *ContainerSpec) GetResources() *Resources {
+	if m != nil {
+		return m.Resources
+	}
+	return nil
+}
+
+func (m *ContainerSpec) GetEnv() []*v1.EnvVar {
+	if m != nil {
+		return m.Env
+	}
+	return nil
+}
+
+func (m *ContainerSpec) GetImagePullPolicy() string {
+	if m != nil {
+		return m.ImagePullPolicy
+	}
+	return ""
+}
+
+func (m *ContainerSpec) GetReadinessProbe() *ReadinessProbe {
+	if m != nil {
+		return m.ReadinessProbe
+	}
+	return nil
+}
+
+func (m *ContainerSpec) GetLivenessProbe() *ReadinessProbe {
+	if m != nil {
+		return m.LivenessProbe
+	}
+	return nil
+}
+
+func (m *ContainerSpec) GetStartupProbe() *ReadinessProbe {
+	if m != nil {
+		return m.StartupProbe
+	}
+	return nil
+}
+
+func (m *ContainerSpec) GetSecurityContext() *v1.SecurityContext {
+	if m != nil {
+		return m.SecurityContext
+	}
+	return nil
+}
+
+func (m *ContainerSpec) GetLifecycle() *Lifecycle {
+	if m != nil {
+		return m.Lifecycle
+	}
+	return nil
+}
+
+// Configuration options for a named gateway.
+type GatewaySpec struct {
+	Enabled   *BoolValueForPB `protobuf:"bytes,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Namespace string              `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Name of the gateway. Used as the name of the gateway k8s resources and must be unique across all gateways.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Labels for the gateway pods and service, used by Gateway resources to select the gateway. The app and istio
+	// labels default to the gateway name.
+	Label                map[string]string        `protobuf:"bytes,4,rep,name=label,proto3" json:"label,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	K8S                  *KubernetesResourcesSpec `protobuf:"bytes,80,opt,name=k8s,proto3" json:"k8s,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *GatewaySpec) Reset()         { *m = GatewaySpec{} }
+func (m *GatewaySpec) String() string { return proto.CompactTextString(m) }
+func (*GatewaySpec) ProtoMessage()    {}
+func (*GatewaySpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_daac92937abd81a4, []int{42}
+}
+
+func (m *GatewaySpec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GatewaySpec.Unmarshal(m, b)
+}
+func (m *GatewaySpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GatewaySpec.Marshal(b, m, deterministic)
+}
+func (m *GatewaySpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GatewaySpec.Merge(m, src)
+}
+func (m *GatewaySpec) XXX_Size() int {
+	return xxx_messageInfo_GatewaySpec.Size(m)
+}
+func (m *GatewaySpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_GatewaySpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GatewaySpec proto.InternalMessageInfo
+
+func (m *GatewaySpec) GetEnabled() *BoolValueForPB {
+	if m != nil {
+		return m.Enabled
+	}
+	return nil
+}
+
+func (m *GatewaySpec) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *GatewaySpec) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GatewaySpec) GetLabel() map[string]string {
+	if m != nil {
+		return m.Label
+	}
+	return nil
+}
+
+func (m *GatewaySpec) GetK8S() *KubernetesResourcesSpec {
+	if m != nil {
+		return m.K8S
+	}
+	return nil
+}
+
+
 
 
 
 
 func init() {
+	proto.RegisterEnum("v1alpha2.DeletionPolicy", DeletionPolicy_name, DeletionPolicy_value)
 	proto.RegisterEnum("v1alpha2.InstallStatus_Status", InstallStatus_Status_name, InstallStatus_Status_value)
 	proto.RegisterType((*IstioControlPlane)(nil), "v1alpha2.IstioControlPlane")
 	proto.RegisterType((*IstioControlPlaneSpec)(nil), "v1alpha2.IstioControlPlaneSpec")
+	proto.RegisterMapType((map[string]string)(nil), "v1alpha2.IstioControlPlaneSpec.CommonAnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "v1alpha2.IstioControlPlaneSpec.CommonLabelsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "v1alpha2.IstioControlPlaneSpec.CommonPodAnnotationsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "v1alpha2.IstioControlPlaneSpec.CommonPodLabelsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "v1alpha2.IstioControlPlaneSpec.ComponentDependenciesEntry")
 	proto.RegisterType((*TrafficManagementFeatureSpec)(nil), "v1alpha2.TrafficManagementFeatureSpec")
 	proto.RegisterType((*TrafficManagementFeatureSpec_Components)(nil), "v1alpha2.TrafficManagementFeatureSpec.Components")
 	proto.RegisterType((*PolicyFeatureSpec)(nil), "v1alpha2.PolicyFeatureSpec")
@@ -3089,6 +4027,7 @@ func init() {
 	proto.RegisterType((*CNIComponentSpec)(nil), "v1alpha2.CNIComponentSpec")
 	proto.RegisterType((*CoreDNSComponentSpec)(nil), "v1alpha2.CoreDNSComponentSpec")
 	proto.RegisterType((*KubernetesResourcesSpec)(nil), "v1alpha2.KubernetesResourcesSpec")
+	proto.RegisterMapType((map[string]*ContainerSpec)(nil), "v1alpha2.KubernetesResourcesSpec.ContainersEntry")
 	proto.RegisterMapType((map[string]string)(nil), "v1alpha2.KubernetesResourcesSpec.NodeSelectorEntry")
 	proto.RegisterMapType((map[string]string)(nil), "v1alpha2.KubernetesResourcesSpec.PodAnnotationsEntry")
 	proto.RegisterType((*K8SObjectOverlay)(nil), "v1alpha2.k8sObjectOverlay")
@@ -3096,6 +4035,8 @@ func init() {
 	proto.RegisterType((*InstallStatus)(nil), "v1alpha2.InstallStatus")
 	proto.RegisterMapType((map[string]*InstallStatus_VersionStatus)(nil), "v1alpha2.InstallStatus.StatusEntry")
 	proto.RegisterType((*InstallStatus_VersionStatus)(nil), "v1alpha2.InstallStatus.VersionStatus")
+	proto.RegisterType((*InstallStatus_Condition)(nil), "v1alpha2.InstallStatus.Condition")
+	proto.RegisterType((*InstallStatus_Upgrade)(nil), "v1alpha2.InstallStatus.Upgrade")
 	proto.RegisterType((*Resources)(nil), "v1alpha2.Resources")
 	proto.RegisterMapType((map[string]string)(nil), "v1alpha2.Resources.LimitsEntry")
 	proto.RegisterMapType((map[string]string)(nil), "v1alpha2.Resources.RequestsEntry")
@@ -3108,6 +4049,13 @@ func init() {
 	proto.RegisterType((*DeploymentStrategy)(nil), "v1alpha2.DeploymentStrategy")
 	proto.RegisterType((*RollingUpdateDeployment)(nil), "v1alpha2.RollingUpdateDeployment")
 	proto.RegisterType((*ObjectMeta)(nil), "v1alpha2.ObjectMeta")
+	proto.RegisterType((*K8SObjectReference)(nil), "v1alpha2.k8sObjectReference")
+	proto.RegisterType((*Lifecycle)(nil), "v1alpha2.Lifecycle")
+	proto.RegisterType((*LifecycleHandler)(nil), "v1alpha2.LifecycleHandler")
+	proto.RegisterType((*TopologySpreadConstraint)(nil), "v1alpha2.TopologySpreadConstraint")
+	proto.RegisterType((*ContainerSpec)(nil), "v1alpha2.ContainerSpec")
+	proto.RegisterType((*GatewaySpec)(nil), "v1alpha2.GatewaySpec")
+	proto.RegisterMapType((map[string]string)(nil), "v1alpha2.GatewaySpec.LabelEntry")
 }
 
 func init() {
@@ -3115,165 +4063,235 @@ func init() {
 }
 
 var fileDescriptor_daac92937abd81a4 = []byte{
-	// 2549 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5a, 0xcf, 0x73, 0x1c, 0x47,
-	0xf5, 0xff, 0xce, 0xea, 0xd7, 0xea, 0xc9, 0xab, 0x1f, 0x2d, 0xc5, 0x9e, 0xac, 0x1d, 0x5b, 0x99,
-	0x24, 0xfe, 0x9a, 0x40, 0x56, 0x91, 0x44, 0x1c, 0x25, 0x86, 0x24, 0xf2, 0x5a, 0x96, 0x54, 0xb1,
-	0x57, 0xcb, 0x48, 0x76, 0x25, 0x17, 0x96, 0xd6, 0x4c, 0x6b, 0x77, 0xa2, 0xd9, 0xe9, 0x49, 0x4f,
-	0xef, 0xc6, 0xcb, 0x89, 0x0b, 0x1c, 0xb8, 0xc1, 0x85, 0x03, 0x45, 0x01, 0x45, 0x51, 0xc0, 0x3f,
-	0xc0, 0x85, 0x2a, 0xae, 0x1c, 0xb9, 0x70, 0xe7, 0xc8, 0x01, 0xce, 0x5c, 0x52, 0x45, 0x51, 0xfd,
-	0x63, 0x7e, 0xed, 0xce, 0xca, 0x96, 0x0a, 0xaa, 0xc4, 0x69, 0x67, 0xde, 0xfb, 0xbc, 0xd7, 0xef,
-	0xbd, 0xee, 0xf7, 0xfa, 0xf5, 0xf4, 0xc2, 0x3b, 0xe1, 0x69, 0x7b, 0x0d, 0x87, 0x5e, 0xb4, 0xe6,
-	0x45, 0xdc, 0xa3, 0x6b, 0xfd, 0x75, 0xec, 0x87, 0x1d, 0xbc, 0xa1, 0x5e, 0x1d, 0x1a, 0x70, 0x46,
-	0xfd, 0xd0, 0xc7, 0x01, 0x69, 0xf1, 0x41, 0x48, 0xa2, 0x5a, 0xc8, 0x28, 0xa7, 0xa8, 0x1c, 0xe3,
-	0xaa, 0xd6, 0xe9, 0x56, 0x54, 0xf3, 0xa8, 0xd0, 0xb1, 0xe6, 0x50, 0x46, 0xd6, 0xfa, 0xeb, 0x6b,
-	0x6d, 0x12, 0x10, 0x86, 0x39, 0x71, 0x15, 0xba, 0x5a, 0xcb, 0x60, 0x70, 0x8f, 0xd3, 0xc8, 0xc1,
-	0xbe, 0x17, 0xb4, 0xd7, 0xfa, 0x1b, 0xc7, 0x84, 0xe3, 0x51, 0xfc, 0xd7, 0x53, 0x7c, 0x17, 0x3b,
-	0x1d, 0x2f, 0x20, 0x6c, 0xb0, 0x96, 0x18, 0xda, 0x25, 0x1c, 0x17, 0x8d, 0xf2, 0x51, 0xdb, 0xe3,
-	0x9d, 0xde, 0x71, 0xcd, 0xa1, 0xdd, 0xb5, 0x36, 0x6d, 0xd3, 0x35, 0x49, 0x3e, 0xee, 0x9d, 0xa4,
-	0x0f, 0x6d, 0x4a, 0xdb, 0x3e, 0x49, 0xdf, 0xbf, 0x60, 0x38, 0x0c, 0x09, 0xd3, 0x5e, 0x59, 0x7f,
-	0x36, 0x60, 0x69, 0x5f, 0xf8, 0x5d, 0x57, 0x7e, 0x37, 0x85, 0xdf, 0x68, 0x13, 0x26, 0xa3, 0x90,
-	0x38, 0xe6, 0xc4, 0xaa, 0x71, 0x67, 0x6e, 0xe3, 0x56, 0x2d, 0x76, 0xbd, 0x36, 0x02, 0x3d, 0x0c,
-	0x89, 0x63, 0x4b, 0x30, 0x5a, 0x83, 0xe9, 0x88, 0x63, 0xde, 0x8b, 0xcc, 0x49, 0x29, 0x76, 0x2d,
-	0x23, 0x16, 0x44, 0x1c, 0xfb, 0xfe, 0xa1, 0x64, 0xdb, 0x1a, 0x86, 0x10, 0x4c, 0x9e, 0x7a, 0x81,
-	0x6b, 0x4e, 0xad, 0x1a, 0x77, 0x66, 0x6d, 0xf9, 0x8c, 0x6e, 0x02, 0xe0, 0xd0, 0x7b, 0x4a, 0x58,
-	0xe4, 0xd1, 0xc0, 0x9c, 0x96, 0x9c, 0x0c, 0x05, 0xad, 0xc2, 0x5c, 0xe8, 0x63, 0x87, 0x74, 0xa8,
-	0xef, 0x12, 0x66, 0x52, 0x09, 0xc8, 0x92, 0xac, 0xef, 0xcd, 0xc0, 0x4b, 0x85, 0x66, 0xa2, 0xaf,
-	0xc2, 0x92, 0x4b, 0x4e, 0x70, 0xcf, 0xe7, 0xad, 0x00, 0x77, 0x49, 0x14, 0x62, 0x87, 0xe8, 0xc1,
-	0x17, 0x35, 0xa3, 0x11, 0xd3, 0xd1, 0x13, 0x40, 0x9c, 0xe1, 0x93, 0x13, 0xcf, 0x69, 0x75, 0x71,
-	0x80, 0xdb, 0xa4, 0x4b, 0x02, 0x6e, 0xbe, 0x2c, 0x3d, 0xbb, 0x9d, 0x7a, 0x76, 0xa4, 0x30, 0x8f,
-	0x13, 0xc8, 0x43, 0x82, 0x79, 0x8f, 0xa9, 0xb8, 0x2c, 0xf1, 0x61, 0x2e, 0xda, 0x84, 0xe9, 0x90,
-	0xfa, 0x9e, 0x33, 0x30, 0xab, 0x52, 0xd5, 0xf5, 0x54, 0x55, 0x53, 0xd2, 0xb3, 0xf2, 0x1a, 0x8a,
-	0xbe, 0x01, 0xb3, 0x9c, 0xf8, 0xa4, 0x4b, 0x38, 0x1b, 0x98, 0xd7, 0xa5, 0xdc, 0xcd, 0x8c, 0x09,
-	0x31, 0x2b, 0x2b, 0x9a, 0x0a, 0xa0, 0xf7, 0xa0, 0x1c, 0x11, 0xa7, 0xc7, 0x3c, 0x3e, 0x30, 0x6f,
-	0x48, 0xe1, 0x57, 0x52, 0xe1, 0x43, 0xcd, 0xc9, 0xca, 0x26, 0x70, 0x64, 0xc3, 0x92, 0x43, 0x83,
-	0x13, 0xaf, 0x9d, 0x8d, 0xc1, 0x2b, 0x52, 0xc7, 0x1b, 0xa9, 0x8e, 0xba, 0x84, 0x14, 0x87, 0x60,
-	0xd1, 0x19, 0x62, 0xa2, 0x7d, 0x98, 0x17, 0x09, 0xd1, 0xf2, 0x82, 0xcf, 0x88, 0xc3, 0xc5, 0x2c,
-	0xdf, 0x94, 0x0a, 0xad, 0x54, 0xe1, 0x76, 0x8f, 0xd3, 0xfd, 0x98, 0x9d, 0xd5, 0x56, 0xc1, 0x59,
-	0x0e, 0xda, 0x82, 0x72, 0x1b, 0x73, 0xf2, 0x05, 0x1e, 0x44, 0xe6, 0x2d, 0xa9, 0xe4, 0x46, 0xaa,
-	0x64, 0x57, 0x71, 0x72, 0x8e, 0xc5, 0x68, 0xf4, 0x26, 0x4c, 0x38, 0x81, 0x67, 0xae, 0x4a, 0x21,
-	0x33, 0xe3, 0x4a, 0x63, 0x3f, 0x2b, 0x20, 0x40, 0xe8, 0x2e, 0xcc, 0x88, 0x2c, 0x7f, 0xd0, 0x38,
-	0x34, 0x5f, 0x1d, 0x1e, 0xa4, 0xae, 0x18, 0x59, 0x99, 0x18, 0x8c, 0xb6, 0x60, 0xba, 0x8f, 0xfd,
-	0x1e, 0x89, 0xcc, 0x0d, 0x29, 0xb6, 0x9a, 0x99, 0xb2, 0x41, 0x48, 0x1e, 0xe3, 0xf0, 0x90, 0x33,
-	0x2f, 0x68, 0xef, 0x07, 0x9c, 0xb0, 0x13, 0xec, 0x10, 0x5b, 0xe3, 0x51, 0x03, 0x96, 0x7a, 0x41,
-	0x1f, 0xfb, 0x9e, 0x2b, 0x72, 0xfd, 0xa9, 0x52, 0xb2, 0xf9, 0x82, 0x4a, 0x46, 0x45, 0x91, 0x09,
-	0x33, 0x21, 0xa3, 0x27, 0x9e, 0x4f, 0x4c, 0x57, 0x2e, 0xf7, 0xf8, 0x15, 0xbd, 0x0d, 0x2b, 0x9e,
-	0xca, 0xcd, 0x56, 0x88, 0x9d, 0x53, 0xdc, 0x26, 0xad, 0x10, 0xf3, 0x8e, 0x79, 0x22, 0x61, 0x48,
-	0xf3, 0x9a, 0x8a, 0xd5, 0xc4, 0xbc, 0x83, 0x16, 0x61, 0xa2, 0xd3, 0x3b, 0x36, 0x03, 0x09, 0x10,
-	0x8f, 0x82, 0xc2, 0x71, 0x5b, 0xa7, 0xa2, 0x78, 0xb4, 0xfe, 0x58, 0x82, 0x1b, 0x67, 0x25, 0x86,
-	0x08, 0x29, 0x09, 0xf0, 0xb1, 0x4f, 0x5c, 0xd3, 0x18, 0x0e, 0xa9, 0x70, 0xeb, 0x3e, 0xa5, 0xbe,
-	0xb4, 0xfd, 0x21, 0x65, 0xcd, 0xfb, 0x76, 0x0c, 0x46, 0xdf, 0x02, 0x70, 0x68, 0x37, 0xa4, 0x01,
-	0x09, 0x78, 0x1c, 0xd6, 0xf5, 0x17, 0x4b, 0xc6, 0x5a, 0x3d, 0x11, 0xb4, 0x33, 0x4a, 0xaa, 0x3f,
-	0x36, 0x00, 0x52, 0x16, 0xba, 0x01, 0xb3, 0x69, 0x6d, 0x30, 0xa4, 0x4b, 0x29, 0x01, 0x6d, 0xc0,
-	0x54, 0xe8, 0xf9, 0x94, 0x9b, 0x2b, 0xc3, 0x56, 0x37, 0x05, 0x39, 0xd1, 0x23, 0x17, 0x82, 0x82,
-	0x4a, 0x19, 0x46, 0x9f, 0x0d, 0xcc, 0x97, 0x46, 0x64, 0x04, 0x79, 0x58, 0x46, 0xd0, 0xac, 0x7f,
-	0x1a, 0xb0, 0x34, 0x52, 0x0e, 0x2e, 0x1c, 0xb5, 0x87, 0x05, 0x51, 0xbb, 0x7d, 0x46, 0xdd, 0x19,
-	0x17, 0x2a, 0x7c, 0x8e, 0x48, 0xbd, 0x93, 0xd4, 0xb9, 0x95, 0xe1, 0x92, 0xa3, 0xc6, 0xcb, 0xfb,
-	0xad, 0xc1, 0xd6, 0xf7, 0x4b, 0xb0, 0x52, 0x54, 0xcf, 0x2e, 0xec, 0xfb, 0x7e, 0x81, 0xef, 0x5f,
-	0x39, 0xbb, 0x76, 0x8e, 0x73, 0xff, 0xb3, 0x73, 0xb8, 0xff, 0x41, 0xb6, 0x62, 0xaf, 0x8c, 0x64,
-	0x6e, 0xcc, 0xca, 0x07, 0x21, 0x15, 0xb1, 0x7e, 0x30, 0x01, 0xcb, 0x05, 0xa5, 0xf9, 0xc2, 0x61,
-	0xd8, 0x2b, 0x08, 0xc3, 0x9d, 0x33, 0x77, 0x81, 0x71, 0x51, 0xf8, 0xc7, 0x79, 0xf2, 0x65, 0x0b,
-	0x66, 0x1c, 0x8f, 0x63, 0x97, 0xf8, 0x3a, 0x08, 0x99, 0x6d, 0xab, 0xae, 0x18, 0xf9, 0x10, 0xc4,
-	0x70, 0xb4, 0x03, 0x57, 0x1c, 0xc2, 0xb8, 0xde, 0x77, 0x98, 0x4e, 0x9e, 0xcc, 0x1e, 0x51, 0x27,
-	0x8c, 0xab, 0x44, 0x67, 0x79, 0x15, 0x73, 0x4e, 0xca, 0x41, 0x1f, 0x02, 0x04, 0xd4, 0x25, 0x2d,
-	0xdc, 0x16, 0x3b, 0xd7, 0xd5, 0xe1, 0x89, 0x68, 0x50, 0x97, 0x6c, 0x0b, 0xd6, 0xd0, 0x44, 0x04,
-	0x31, 0xdd, 0xfa, 0x61, 0x09, 0xae, 0x9f, 0xb1, 0xbf, 0x5d, 0x78, 0x42, 0x9a, 0x05, 0x13, 0xf2,
-	0xf6, 0x0b, 0x6d, 0xa9, 0xff, 0xa1, 0xec, 0x6c, 0x63, 0xdf, 0x27, 0x05, 0xd9, 0xb9, 0x2b, 0xe9,
-	0x43, 0xd9, 0xa9, 0xc0, 0xd6, 0x8f, 0x4a, 0x60, 0x8e, 0xdb, 0x9b, 0x2f, 0x1c, 0x89, 0xc7, 0x05,
-	0x91, 0x78, 0xeb, 0xf9, 0xbd, 0xc0, 0xb8, 0x30, 0x04, 0xe7, 0x08, 0xc3, 0x7d, 0x28, 0xab, 0x2e,
-	0x84, 0x32, 0x1d, 0x88, 0x4c, 0x59, 0x3c, 0xf4, 0x5c, 0xe2, 0x60, 0xb6, 0xaf, 0x01, 0xf9, 0x88,
-	0x24, 0x72, 0xd6, 0x5f, 0x4b, 0x80, 0x46, 0x5b, 0x8d, 0x0b, 0x47, 0x63, 0xb7, 0x20, 0x1a, 0xff,
-	0x7f, 0x56, 0x53, 0x33, 0x2e, 0x0e, 0x7f, 0x3a, 0x4f, 0x9e, 0x36, 0x60, 0xc1, 0x0b, 0xda, 0x8c,
-	0x44, 0x51, 0x4b, 0xb7, 0x48, 0xba, 0x9f, 0x7a, 0x23, 0xdb, 0xc3, 0x4b, 0x80, 0xb6, 0x20, 0x1f,
-	0x8e, 0x79, 0x2f, 0xc7, 0x44, 0x1f, 0xc3, 0x3c, 0xc9, 0xab, 0x53, 0x9d, 0xd6, 0xeb, 0xa9, 0xba,
-	0x9d, 0xf1, 0xda, 0x2a, 0x24, 0xcb, 0xb3, 0xfe, 0x66, 0xc0, 0x7c, 0xbe, 0x2f, 0xbb, 0x70, 0x74,
-	0xeb, 0x05, 0xd1, 0x7d, 0x6d, 0x5c, 0xf7, 0x37, 0x2e, 0xb2, 0x9f, 0x9c, 0x23, 0xb0, 0x5f, 0x53,
-	0x7d, 0xa6, 0x5a, 0x5c, 0xd5, 0xdc, 0x48, 0x79, 0x9f, 0x05, 0xcc, 0xfa, 0xd2, 0x00, 0x34, 0xda,
-	0x51, 0xfe, 0xb7, 0xd6, 0xd2, 0xe8, 0x48, 0xe3, 0x3c, 0x76, 0xcf, 0x59, 0xf2, 0x75, 0xb7, 0x3c,
-	0x5a, 0xf2, 0x15, 0x63, 0xb8, 0xe4, 0x2b, 0xaa, 0xf5, 0x73, 0x03, 0xd0, 0x68, 0x1b, 0x75, 0x61,
-	0xef, 0x73, 0x66, 0x96, 0x86, 0xcd, 0xdc, 0x84, 0x89, 0xd3, 0xad, 0xc8, 0x6c, 0x4a, 0x8d, 0xaf,
-	0xa6, 0x1a, 0x3f, 0xee, 0x1d, 0x13, 0x16, 0x10, 0x4e, 0x22, 0x9b, 0x44, 0xb4, 0xc7, 0x1c, 0x12,
-	0xa9, 0xf9, 0x39, 0xdd, 0x8a, 0x94, 0x85, 0x23, 0x4d, 0xdb, 0x65, 0xb2, 0xf0, 0x77, 0x06, 0xdc,
-	0x38, 0xab, 0x70, 0x5d, 0x26, 0x5b, 0x7f, 0x61, 0xc0, 0x72, 0x41, 0x2f, 0x78, 0x99, 0x4c, 0xfc,
-	0x95, 0x01, 0x57, 0x8b, 0x9b, 0xb5, 0xcb, 0x64, 0xe5, 0x2f, 0x0d, 0x58, 0x29, 0xea, 0xa6, 0x2e,
-	0x93, 0x8d, 0xbf, 0x36, 0xc0, 0x1c, 0xd7, 0xb2, 0x5d, 0xb6, 0x19, 0x2f, 0xee, 0x0a, 0x2f, 0x5b,
-	0xea, 0x14, 0x34, 0x6a, 0x97, 0xc9, 0xc4, 0xdf, 0x1a, 0x70, 0xfd, 0x8c, 0x96, 0xe1, 0x32, 0x99,
-	0xfa, 0x1b, 0x03, 0xaa, 0x3b, 0xff, 0x13, 0x96, 0xfe, 0xcc, 0x80, 0xc5, 0xe1, 0xd6, 0xe1, 0xd2,
-	0x55, 0xa2, 0x82, 0x4d, 0xfe, 0x32, 0xd9, 0xf8, 0x97, 0x32, 0x5c, 0x1b, 0x03, 0x40, 0x5b, 0x50,
-	0xc6, 0x27, 0x27, 0x5e, 0xe0, 0xf1, 0x41, 0x62, 0xa7, 0xfa, 0x30, 0x5f, 0xc3, 0xa1, 0x57, 0x13,
-	0x9d, 0x4a, 0xad, 0xbf, 0x5e, 0xdb, 0xd6, 0x18, 0x3b, 0x41, 0x8b, 0x46, 0x8f, 0x04, 0x7d, 0xb3,
-	0xb4, 0x3a, 0x21, 0x1b, 0xbd, 0x02, 0xa1, 0x9d, 0xa0, 0xff, 0x14, 0x33, 0x5b, 0xc0, 0xd0, 0x53,
-	0x28, 0x77, 0x42, 0xdc, 0xca, 0x7c, 0x63, 0xbf, 0x97, 0x15, 0xc9, 0x5c, 0x18, 0xd4, 0xf4, 0x85,
-	0x41, 0x6d, 0x8f, 0x32, 0xef, 0xbb, 0x34, 0xe0, 0xd8, 0x6f, 0x52, 0x77, 0x5b, 0x03, 0x08, 0x53,
-	0x2d, 0x54, 0x27, 0xc4, 0xd2, 0xfe, 0x37, 0x61, 0xc9, 0xeb, 0xca, 0x8f, 0x78, 0x3d, 0xdf, 0x6f,
-	0xe9, 0x0f, 0x30, 0x93, 0x32, 0x6c, 0x0b, 0x92, 0xd1, 0xec, 0xf9, 0xbe, 0xda, 0x73, 0xd1, 0x27,
-	0x50, 0x91, 0x47, 0xe3, 0x88, 0xf8, 0xea, 0x04, 0x34, 0x25, 0x6d, 0xdf, 0x7c, 0x6e, 0x18, 0xe5,
-	0xa9, 0xf9, 0x50, 0x4b, 0xed, 0x04, 0x9c, 0x0d, 0xec, 0x2b, 0x41, 0x86, 0x84, 0x9e, 0xc0, 0x4b,
-	0x21, 0x75, 0x5b, 0xae, 0x17, 0xb1, 0x5e, 0x28, 0x8e, 0x6d, 0xad, 0xe3, 0x9e, 0xdb, 0x26, 0x5c,
-	0x7e, 0xce, 0xcf, 0x4d, 0x54, 0x93, 0xba, 0x0f, 0x12, 0xd4, 0x7d, 0x09, 0x92, 0x0e, 0x2d, 0x87,
-	0xa3, 0x0c, 0xf4, 0x6d, 0x58, 0x10, 0x6a, 0x71, 0x10, 0x50, 0x8e, 0x05, 0x3d, 0x32, 0x67, 0xa4,
-	0xc9, 0xef, 0x3c, 0xdf, 0x64, 0x11, 0xb3, 0x54, 0x4e, 0x19, 0x3d, 0x1f, 0xe6, 0x88, 0xa8, 0x06,
-	0xcb, 0x21, 0xf3, 0x28, 0xf3, 0xf8, 0xa0, 0xe5, 0xf8, 0x38, 0x8a, 0xe4, 0x2d, 0x81, 0x59, 0x96,
-	0xe1, 0x5b, 0x8a, 0x59, 0x75, 0xc1, 0x69, 0xe0, 0x2e, 0x41, 0xdb, 0xb0, 0xc0, 0x08, 0x76, 0xbd,
-	0x40, 0x9c, 0x73, 0x42, 0x46, 0x8f, 0x89, 0x39, 0x3b, 0xfc, 0x3d, 0xd9, 0x8e, 0x01, 0x4d, 0xc1,
-	0xb7, 0xe7, 0x59, 0xee, 0x1d, 0xbd, 0x06, 0x15, 0x46, 0x42, 0xdf, 0x73, 0x70, 0xcb, 0xa1, 0xbd,
-	0x80, 0x9b, 0xb0, 0x6a, 0xdc, 0xa9, 0xd8, 0x57, 0x34, 0xb1, 0x2e, 0x68, 0x68, 0x1d, 0x66, 0x59,
-	0xec, 0x8c, 0x39, 0x27, 0x47, 0x58, 0xce, 0x8e, 0xa0, 0x59, 0x76, 0x8a, 0x42, 0xef, 0xc1, 0x4c,
-	0x44, 0x58, 0xdf, 0x73, 0x88, 0x79, 0x45, 0x5f, 0xe1, 0x14, 0xac, 0xc8, 0x43, 0x05, 0x51, 0x4b,
-	0x48, 0xe3, 0x45, 0x0a, 0x44, 0x9c, 0x61, 0x4e, 0xda, 0x03, 0xb3, 0x32, 0x9c, 0xaa, 0x0f, 0x48,
-	0xe8, 0xd3, 0x41, 0x57, 0x64, 0xb5, 0xc6, 0xd8, 0x09, 0x1a, 0x7d, 0x04, 0x73, 0x9c, 0xfa, 0x84,
-	0xe9, 0xb9, 0x99, 0x97, 0x73, 0x73, 0xb3, 0x68, 0xe0, 0xa3, 0x04, 0x66, 0x67, 0x45, 0xd0, 0x5d,
-	0x28, 0xd3, 0x3e, 0x61, 0x3e, 0x1e, 0x44, 0xa6, 0xab, 0x33, 0x29, 0x19, 0xfb, 0x74, 0x2b, 0x3a,
-	0x38, 0x16, 0x2d, 0xed, 0x81, 0x82, 0xd8, 0x09, 0xb6, 0xfa, 0x21, 0x2c, 0x8d, 0xac, 0x49, 0xb4,
-	0x08, 0x13, 0xa7, 0x64, 0xa0, 0x0f, 0x28, 0xe2, 0x11, 0xad, 0xc0, 0x94, 0xfc, 0xc0, 0xae, 0x0b,
-	0x89, 0x7a, 0x79, 0xbf, 0xb4, 0x65, 0x54, 0xb7, 0x45, 0x27, 0x3a, 0xb2, 0x42, 0xce, 0xa3, 0xc2,
-	0xfa, 0xbb, 0x01, 0x8b, 0xc3, 0x26, 0xa2, 0x5b, 0x30, 0x87, 0x43, 0xaf, 0xd5, 0xd7, 0xd7, 0x59,
-	0xc6, 0xc8, 0x75, 0x56, 0x7c, 0x05, 0x56, 0xca, 0x5c, 0x81, 0x21, 0x98, 0x94, 0x0b, 0x6f, 0x42,
-	0xd1, 0xc4, 0x33, 0xfa, 0x00, 0x66, 0x42, 0xcc, 0x9d, 0x0e, 0x89, 0xcc, 0x49, 0x19, 0x98, 0xd7,
-	0xc7, 0x07, 0xa6, 0xd6, 0xc4, 0xbc, 0x23, 0x8b, 0xa9, 0x1d, 0x0b, 0x55, 0x1b, 0x30, 0x9b, 0x50,
-	0xc5, 0x00, 0xf2, 0x23, 0xbf, 0x32, 0x47, 0x3e, 0xa3, 0xb7, 0xb2, 0x8e, 0xe5, 0xee, 0xee, 0x44,
-	0x79, 0x4e, 0x6f, 0x17, 0x14, 0xca, 0xfa, 0xc9, 0x04, 0x54, 0x72, 0x97, 0x7a, 0xe8, 0x5e, 0x72,
-	0xfb, 0x67, 0x48, 0x03, 0x5f, 0x1b, 0x73, 0xfb, 0x57, 0x53, 0x3f, 0x2a, 0x05, 0xb5, 0x48, 0xf5,
-	0xa7, 0x06, 0x54, 0x74, 0x48, 0xb4, 0x3a, 0x13, 0x66, 0xf2, 0x51, 0x8b, 0x5f, 0xd1, 0xdd, 0x64,
-	0x20, 0x61, 0xea, 0x7c, 0xf6, 0x7c, 0x59, 0x34, 0x50, 0x72, 0xdb, 0x68, 0xc1, 0x15, 0xf5, 0xa4,
-	0x2e, 0x4c, 0x74, 0x78, 0x73, 0x34, 0x31, 0xbd, 0x84, 0x31, 0xca, 0x74, 0xcd, 0x54, 0x2f, 0xd5,
-	0xef, 0xc0, 0x5c, 0xc6, 0xe8, 0x82, 0x55, 0x71, 0x2f, 0x1f, 0xbc, 0x37, 0xc6, 0x59, 0x94, 0x73,
-	0x31, 0xbb, 0x78, 0xf6, 0x61, 0x5a, 0xfb, 0x5d, 0x86, 0xc9, 0xc6, 0x41, 0x63, 0x67, 0xf1, 0xff,
-	0xd0, 0x15, 0x28, 0x3f, 0x69, 0x3e, 0xd8, 0x3e, 0xda, 0x6f, 0xec, 0x2e, 0x1a, 0x68, 0x0e, 0x66,
-	0xf6, 0x76, 0xb6, 0x1f, 0x1d, 0xed, 0x7d, 0xba, 0x58, 0x42, 0xb3, 0x30, 0xb5, 0x63, 0xdb, 0x07,
-	0xf6, 0xe2, 0x04, 0x5a, 0x80, 0x39, 0x7b, 0xa7, 0x7e, 0xd0, 0xa8, 0xef, 0x3f, 0x12, 0xc0, 0x49,
-	0xeb, 0x4b, 0x03, 0x66, 0x93, 0x9a, 0x80, 0xde, 0x85, 0x69, 0xdf, 0xeb, 0x7a, 0x3c, 0x9e, 0x95,
-	0x5b, 0x05, 0x85, 0xa3, 0xf6, 0x48, 0x22, 0xf4, 0x8c, 0x28, 0x38, 0xfa, 0x26, 0x94, 0x19, 0xf9,
-	0xbc, 0x47, 0x22, 0x1e, 0xe9, 0x4d, 0xed, 0xd5, 0x22, 0x51, 0x5b, 0x63, 0x94, 0x70, 0x22, 0x52,
-	0x7d, 0x0f, 0xe6, 0x32, 0x5a, 0xcf, 0x95, 0x8b, 0xf7, 0xa0, 0x92, 0xd3, 0x7a, 0xae, 0x2c, 0xfc,
-	0x57, 0x09, 0xe6, 0xf3, 0x35, 0x17, 0xdd, 0x81, 0x49, 0xf2, 0x8c, 0x38, 0x7a, 0x3f, 0x5f, 0xc9,
-	0x7c, 0x81, 0x7a, 0x46, 0x9c, 0x6d, 0xf9, 0x59, 0xd1, 0x96, 0x08, 0xb4, 0x0e, 0x33, 0x1d, 0xce,
-	0xc3, 0x5d, 0xc2, 0x47, 0xb3, 0x60, 0xef, 0xe8, 0xa8, 0xb9, 0x4b, 0xb8, 0xc6, 0xc7, 0x38, 0xf4,
-	0x2e, 0xcc, 0x72, 0x27, 0x3c, 0xa4, 0xce, 0x29, 0xe1, 0x7a, 0x27, 0x7f, 0x39, 0x93, 0x3a, 0xf5,
-	0xa6, 0x62, 0x69, 0xb1, 0x14, 0x8b, 0xde, 0x86, 0x65, 0xd1, 0x38, 0x78, 0xd8, 0x7f, 0x40, 0x7c,
-	0x3c, 0x38, 0x24, 0x0e, 0x0d, 0x5c, 0x75, 0x73, 0x3e, 0x65, 0x17, 0xb1, 0xd0, 0x6d, 0x98, 0xe7,
-	0x5e, 0x97, 0xd0, 0x1e, 0x8f, 0xc1, 0x53, 0x12, 0x3c, 0x44, 0x45, 0xaf, 0x43, 0x25, 0x24, 0xcc,
-	0xa3, 0x6e, 0x0c, 0x9b, 0x96, 0xb0, 0x3c, 0x11, 0xbd, 0x09, 0x8b, 0x51, 0xcf, 0x71, 0x48, 0x14,
-	0x1d, 0x75, 0x18, 0x89, 0x3a, 0xd4, 0x77, 0xcd, 0x19, 0x09, 0x1c, 0xa1, 0x0b, 0xec, 0x09, 0xf6,
-	0xfc, 0x1e, 0x23, 0x29, 0xb6, 0xac, 0xb0, 0xc3, 0x74, 0xeb, 0x36, 0x40, 0x1a, 0x57, 0x91, 0xc5,
-	0x0e, 0xed, 0x76, 0x71, 0xe0, 0xca, 0xf5, 0x37, 0x6b, 0xc7, 0xaf, 0xd6, 0x1f, 0x0c, 0xa8, 0xe4,
-	0x62, 0x5a, 0x58, 0x95, 0x36, 0x60, 0x32, 0xa4, 0x2c, 0x9e, 0x8e, 0x9b, 0x23, 0x45, 0xe9, 0x80,
-	0xa9, 0xc4, 0x55, 0x5d, 0xa3, 0xc4, 0x0a, 0x3d, 0x1d, 0x1a, 0xf1, 0xb8, 0x7c, 0x8a, 0x67, 0x74,
-	0x15, 0xa6, 0x23, 0xa7, 0x43, 0xba, 0x44, 0x27, 0xb6, 0x7e, 0x43, 0x77, 0x61, 0x4e, 0xcc, 0xe4,
-	0x1e, 0xc1, 0x2e, 0x61, 0x91, 0xee, 0x80, 0x56, 0xf2, 0xb3, 0xae, 0x98, 0x76, 0x16, 0x68, 0xdd,
-	0x05, 0x48, 0x59, 0x49, 0xc1, 0x36, 0x32, 0x05, 0xbb, 0x70, 0x89, 0x5a, 0x9f, 0xc2, 0xc2, 0xd0,
-	0x9a, 0x48, 0x5c, 0x34, 0x2e, 0xe0, 0x62, 0x29, 0x75, 0xd1, 0xfa, 0xbd, 0x01, 0xd7, 0xc6, 0xb4,
-	0x53, 0xa2, 0xf4, 0x75, 0xbd, 0x60, 0xbb, 0x8f, 0x3d, 0x5f, 0xb4, 0xd5, 0x72, 0xac, 0x8a, 0x9d,
-	0xa3, 0xa1, 0x03, 0x28, 0x27, 0x9d, 0xa0, 0x0a, 0xf7, 0x66, 0x66, 0xeb, 0x4e, 0xfe, 0x93, 0x52,
-	0x0b, 0x4f, 0xdb, 0x82, 0x10, 0xd5, 0xba, 0x84, 0x63, 0xb1, 0x99, 0x3f, 0xc2, 0xc7, 0xc4, 0x8f,
-	0xb7, 0x5e, 0x3b, 0x51, 0x22, 0xd6, 0x6b, 0x17, 0x3f, 0x7b, 0x12, 0xe0, 0x64, 0xd8, 0x09, 0x39,
-	0xec, 0x10, 0xd5, 0xfa, 0x1c, 0xd0, 0x68, 0x5b, 0x21, 0x5c, 0xe4, 0x83, 0x30, 0x89, 0xa9, 0x78,
-	0x46, 0xbb, 0x50, 0x61, 0xd4, 0x17, 0x5d, 0xf1, 0x93, 0xd0, 0xc5, 0x3c, 0x2e, 0xb7, 0xd9, 0xc2,
-	0x94, 0x65, 0xa7, 0x5a, 0xed, 0xbc, 0x9c, 0x38, 0x46, 0x5d, 0x1b, 0x03, 0x45, 0x0f, 0x47, 0xcc,
-	0x7e, 0xb1, 0x99, 0x19, 0x92, 0x42, 0xef, 0x43, 0xb9, 0x8b, 0x9f, 0x1d, 0xf6, 0x58, 0x9b, 0xbc,
-	0xe0, 0xf2, 0x4d, 0xf0, 0xd6, 0x07, 0x00, 0x6a, 0x47, 0x7f, 0x4c, 0x38, 0x4e, 0x96, 0xd7, 0x54,
-	0x66, 0x79, 0xe5, 0xce, 0x45, 0xd3, 0x43, 0xe7, 0x22, 0xcb, 0x84, 0xab, 0xc5, 0x7f, 0x0e, 0xb0,
-	0x16, 0xa0, 0x92, 0xdb, 0xcf, 0xad, 0xab, 0xb0, 0x52, 0x64, 0x8c, 0xb5, 0x02, 0x68, 0xf4, 0x5c,
-	0x76, 0x3c, 0x2d, 0xff, 0x34, 0xb4, 0xf9, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2e, 0xa1, 0x67,
-	0x24, 0x43, 0x25, 0x00, 0x00,
+	// 3675 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5b, 0xcd, 0x73, 0x1b, 0x47,
+	0x76, 0xdf, 0x01, 0xf8, 0x85, 0x47, 0x02, 0x04, 0x5b, 0x10, 0x35, 0x86, 0x64, 0x89, 0x9a, 0xdd,
+	0x75, 0x14, 0x67, 0x17, 0xb4, 0x24, 0x5b, 0x4b, 0xd9, 0x6b, 0xd9, 0x14, 0x48, 0x93, 0x2c, 0xc9,
+	0x14, 0x32, 0xa0, 0x58, 0xf6, 0x21, 0xc1, 0x36, 0x07, 0x0d, 0x60, 0x96, 0x83, 0x99, 0x71, 0x4f,
+	0x83, 0x22, 0x92, 0x73, 0x72, 0xc8, 0x2d, 0xb9, 0xe5, 0x90, 0xaf, 0x4a, 0xa5, 0x92, 0xfc, 0x03,
+	0xb9, 0xa4, 0x6a, 0x73, 0xde, 0x53, 0xfe, 0x84, 0x64, 0x73, 0xc9, 0x21, 0x39, 0xe7, 0x92, 0xaa,
+	0x54, 0xaa, 0x3f, 0xe6, 0x13, 0x03, 0x8a, 0xa0, 0xd7, 0x55, 0xdc, 0x13, 0x31, 0xef, 0xfd, 0xde,
+	0xeb, 0xd7, 0xaf, 0x5f, 0x77, 0xbf, 0x7e, 0xdd, 0x84, 0x8f, 0xfc, 0xd3, 0xfe, 0x26, 0xf6, 0xed,
+	0x60, 0xd3, 0x0e, 0x98, 0xed, 0x6d, 0x9e, 0x3d, 0xc4, 0x8e, 0x3f, 0xc0, 0x8f, 0xe4, 0xa7, 0xe5,
+	0xb9, 0x8c, 0x7a, 0x8e, 0xef, 0x60, 0x97, 0x74, 0xd8, 0xd8, 0x27, 0x41, 0xc3, 0xa7, 0x1e, 0xf3,
+	0xd0, 0x52, 0x88, 0xab, 0x1b, 0xa7, 0x5b, 0x41, 0xc3, 0xf6, 0xb8, 0x8e, 0x4d, 0xcb, 0xa3, 0x64,
+	0xf3, 0xec, 0xe1, 0x66, 0x9f, 0xb8, 0x84, 0x62, 0x46, 0xba, 0x12, 0x5d, 0x6f, 0x24, 0x30, 0x78,
+	0xc4, 0xbc, 0xc0, 0xc2, 0x8e, 0xed, 0xf6, 0x37, 0xcf, 0x1e, 0x9d, 0x10, 0x86, 0x27, 0xf1, 0x1f,
+	0xc6, 0xf8, 0x21, 0xb6, 0x06, 0xb6, 0x4b, 0xe8, 0x78, 0x33, 0x32, 0x74, 0x48, 0x18, 0xce, 0x6b,
+	0xe5, 0xf3, 0xbe, 0xcd, 0x06, 0xa3, 0x93, 0x86, 0xe5, 0x0d, 0x37, 0xfb, 0x5e, 0xdf, 0xdb, 0x14,
+	0xe4, 0x93, 0x51, 0x2f, 0xfe, 0xd1, 0xf7, 0xbc, 0xbe, 0x43, 0xe2, 0xef, 0x37, 0x14, 0xfb, 0x3e,
+	0xa1, 0xaa, 0x57, 0xc6, 0xbf, 0x6a, 0xb0, 0x76, 0xc0, 0xfb, 0xdd, 0x94, 0xfd, 0x6e, 0xf1, 0x7e,
+	0xa3, 0xc7, 0x30, 0x17, 0xf8, 0xc4, 0xd2, 0x8b, 0x1b, 0xda, 0x83, 0xe5, 0x47, 0xf7, 0x1a, 0x61,
+	0xd7, 0x1b, 0x13, 0xd0, 0xb6, 0x4f, 0x2c, 0x53, 0x80, 0xd1, 0x26, 0x2c, 0x04, 0x0c, 0xb3, 0x51,
+	0xa0, 0xcf, 0x09, 0xb1, 0x5b, 0x09, 0x31, 0x37, 0x60, 0xd8, 0x71, 0xda, 0x82, 0x6d, 0x2a, 0x18,
+	0x42, 0x30, 0x77, 0x6a, 0xbb, 0x5d, 0x7d, 0x7e, 0x43, 0x7b, 0x50, 0x32, 0xc5, 0x6f, 0x74, 0x17,
+	0x00, 0xfb, 0xf6, 0x31, 0xa1, 0x81, 0xed, 0xb9, 0xfa, 0x82, 0xe0, 0x24, 0x28, 0x68, 0x03, 0x96,
+	0x7d, 0x07, 0x5b, 0x64, 0xe0, 0x39, 0x5d, 0x42, 0x75, 0x4f, 0x00, 0x92, 0x24, 0xe3, 0x97, 0x15,
+	0xb8, 0x99, 0x6b, 0x26, 0xfa, 0x1d, 0x58, 0xeb, 0x92, 0x1e, 0x1e, 0x39, 0xac, 0xe3, 0xe2, 0x21,
+	0x09, 0x7c, 0x6c, 0x11, 0xd5, 0x78, 0x55, 0x31, 0x0e, 0x43, 0x3a, 0x7a, 0x0d, 0x88, 0x51, 0xdc,
+	0xeb, 0xd9, 0x56, 0x67, 0x88, 0x5d, 0xdc, 0x27, 0x43, 0xe2, 0x32, 0xfd, 0x1d, 0xd1, 0xb3, 0xf7,
+	0xe2, 0x9e, 0x1d, 0x49, 0xcc, 0x97, 0x11, 0xe4, 0x0b, 0x82, 0xd9, 0x88, 0x4a, 0xbf, 0xac, 0xb1,
+	0x2c, 0x17, 0x3d, 0x86, 0x05, 0xdf, 0x73, 0x6c, 0x6b, 0xac, 0xd7, 0x85, 0xaa, 0xdb, 0xb1, 0xaa,
+	0x96, 0xa0, 0x27, 0xe5, 0x15, 0x14, 0xfd, 0x14, 0x4a, 0x8c, 0x38, 0x64, 0x48, 0x18, 0x1d, 0xeb,
+	0xb7, 0x85, 0xdc, 0xdd, 0x84, 0x09, 0x21, 0x2b, 0x29, 0x1a, 0x0b, 0xa0, 0xa7, 0xb0, 0x14, 0x10,
+	0x6b, 0x44, 0x6d, 0x36, 0xd6, 0xef, 0x08, 0xe1, 0x77, 0x63, 0xe1, 0xb6, 0xe2, 0x24, 0x65, 0x23,
+	0x38, 0x32, 0x61, 0xcd, 0xf2, 0xdc, 0x9e, 0xdd, 0x4f, 0xfa, 0xe0, 0x5d, 0xa1, 0xe3, 0x87, 0xb1,
+	0x8e, 0xa6, 0x80, 0xe4, 0xbb, 0xa0, 0x6a, 0x65, 0x98, 0xe8, 0x00, 0x2a, 0x7c, 0x42, 0x74, 0x6c,
+	0xf7, 0xe7, 0xc4, 0x62, 0x7c, 0x94, 0xef, 0x0a, 0x85, 0x46, 0xac, 0x70, 0x7b, 0xc4, 0xbc, 0x83,
+	0x90, 0x9d, 0xd4, 0x56, 0xc6, 0x49, 0x0e, 0xda, 0x82, 0xa5, 0x3e, 0x66, 0xe4, 0x0d, 0x1e, 0x07,
+	0xfa, 0x3d, 0xa1, 0xe4, 0x4e, 0xac, 0x64, 0x4f, 0x72, 0x52, 0x1d, 0x0b, 0xd1, 0xe8, 0x7d, 0x28,
+	0x5a, 0xae, 0xad, 0x6f, 0x08, 0x21, 0x3d, 0xd1, 0x95, 0xc3, 0x83, 0xa4, 0x00, 0x07, 0xa1, 0x27,
+	0xb0, 0xc8, 0x67, 0xf9, 0xce, 0x61, 0x5b, 0xbf, 0x9f, 0x6d, 0xa4, 0x29, 0x19, 0x49, 0x99, 0x10,
+	0x8c, 0xb6, 0x60, 0xe1, 0x0c, 0x3b, 0x23, 0x12, 0xe8, 0x8f, 0x84, 0xd8, 0x46, 0x62, 0xc8, 0xc6,
+	0x3e, 0xf9, 0x12, 0xfb, 0x6d, 0x46, 0x6d, 0xb7, 0x7f, 0xe0, 0x32, 0x42, 0x7b, 0xd8, 0x22, 0xa6,
+	0xc2, 0xa3, 0x43, 0x58, 0x1b, 0xb9, 0x67, 0xd8, 0xb1, 0xbb, 0x7c, 0xae, 0x1f, 0x4b, 0x25, 0x8f,
+	0x2f, 0xa9, 0x64, 0x52, 0x14, 0x1d, 0x43, 0xd9, 0xf2, 0x86, 0x43, 0xcf, 0xed, 0x38, 0xf8, 0x84,
+	0x38, 0x81, 0xfe, 0xd3, 0x8d, 0xe2, 0x83, 0xe5, 0x47, 0x0f, 0xdf, 0x32, 0xaf, 0x1b, 0x4d, 0x21,
+	0xf4, 0x52, 0xc8, 0xec, 0xba, 0x8c, 0x8e, 0xcd, 0x15, 0x2b, 0x41, 0x42, 0x04, 0x90, 0xd2, 0x8b,
+	0x5d, 0xd7, 0x63, 0x98, 0x0f, 0x4a, 0xa0, 0x7f, 0x2a, 0x94, 0x3f, 0xb9, 0x9c, 0xf2, 0xed, 0x58,
+	0x50, 0xb6, 0xb0, 0x66, 0x65, 0xe9, 0xe8, 0x67, 0xa0, 0x88, 0x1d, 0xdf, 0xeb, 0x86, 0x5d, 0x78,
+	0x26, 0x5a, 0xf9, 0xf0, 0x72, 0xad, 0xb4, 0xbc, 0x6e, 0xb2, 0x17, 0xab, 0x56, 0x9a, 0x8a, 0x3c,
+	0x58, 0x4f, 0xb4, 0x90, 0xec, 0xcc, 0x67, 0xa2, 0x99, 0xa7, 0x97, 0x6e, 0x66, 0xa2, 0x3f, 0x35,
+	0x2b, 0x87, 0x85, 0xbe, 0x11, 0x0d, 0xfa, 0x9e, 0x4b, 0x5c, 0xd6, 0xe9, 0x12, 0x9f, 0xb8, 0x5d,
+	0xe2, 0x5a, 0x36, 0x09, 0xf4, 0xcf, 0x45, 0x83, 0x1f, 0x5f, 0xa2, 0x41, 0x29, 0xbd, 0x93, 0x10,
+	0x96, 0x2d, 0xde, 0xb4, 0xf2, 0x78, 0x68, 0x1b, 0x56, 0xbb, 0xc4, 0x21, 0xbc, 0xfd, 0x8e, 0x5a,
+	0x82, 0xb6, 0x37, 0xb4, 0x07, 0x95, 0x64, 0xf8, 0xef, 0x28, 0x80, 0x5c, 0x8a, 0xcc, 0x4a, 0x37,
+	0xf5, 0x8d, 0xee, 0xc3, 0x0a, 0x25, 0x0c, 0xdb, 0x6e, 0x87, 0xaf, 0xd5, 0x81, 0xfe, 0x7c, 0xa3,
+	0xc8, 0x57, 0x5f, 0x49, 0x7b, 0xc1, 0x49, 0x48, 0x87, 0x45, 0x9f, 0x7a, 0x3d, 0xdb, 0x21, 0x7a,
+	0x57, 0xac, 0xac, 0xe1, 0x27, 0xfa, 0x00, 0x6a, 0xb6, 0xdc, 0x06, 0x3a, 0x3e, 0xb6, 0x4e, 0x71,
+	0x9f, 0x74, 0x7c, 0xcc, 0x06, 0x7a, 0x4f, 0xc0, 0x90, 0xe2, 0xb5, 0x24, 0xab, 0x85, 0xd9, 0x00,
+	0x55, 0xa1, 0x38, 0x18, 0x9d, 0xe8, 0xae, 0x00, 0xf0, 0x9f, 0x9c, 0xc2, 0x70, 0x5f, 0xad, 0xfa,
+	0xfc, 0x67, 0xfd, 0x33, 0x58, 0x9b, 0x88, 0x52, 0x0e, 0x3b, 0x25, 0x63, 0x5d, 0x93, 0xb0, 0x53,
+	0x32, 0x46, 0x35, 0x98, 0x17, 0x73, 0x4b, 0x2f, 0x08, 0x9a, 0xfc, 0xf8, 0xb8, 0xb0, 0xa5, 0xd5,
+	0x77, 0x60, 0x3d, 0x3f, 0x12, 0x67, 0xd2, 0xf2, 0x1c, 0x6a, 0x79, 0x91, 0x36, 0x93, 0x8e, 0x3d,
+	0x78, 0x67, 0x6a, 0x18, 0xcd, 0xa4, 0x68, 0x1f, 0xea, 0xd3, 0xc3, 0x63, 0x16, 0x4d, 0xc6, 0x2f,
+	0x0a, 0x70, 0xe7, 0xa2, 0x1d, 0x8e, 0xaf, 0x8d, 0xc4, 0xc5, 0x27, 0x0e, 0xe9, 0xea, 0x5a, 0x76,
+	0x6d, 0xe4, 0xeb, 0xd3, 0x73, 0xcf, 0x73, 0xc4, 0x22, 0xf4, 0x85, 0x47, 0x5b, 0xcf, 0xcd, 0x10,
+	0x8c, 0x7e, 0x17, 0x20, 0x8a, 0xd2, 0x70, 0x7d, 0x7c, 0x78, 0xb9, 0x5d, 0x35, 0x0e, 0xfd, 0xc0,
+	0x4c, 0x28, 0xa9, 0xff, 0x99, 0x06, 0x10, 0xb3, 0xd0, 0x1d, 0x28, 0xc5, 0x9b, 0xbc, 0xec, 0x6c,
+	0x4c, 0x40, 0x8f, 0x60, 0xde, 0xb7, 0x1d, 0x8f, 0xe9, 0xb5, 0xac, 0xd5, 0x2d, 0x4e, 0x8e, 0xf4,
+	0x88, 0x15, 0x5d, 0x42, 0x85, 0x0c, 0xf5, 0xce, 0xc7, 0xfa, 0xcd, 0x09, 0x19, 0x4e, 0xce, 0xca,
+	0x70, 0x9a, 0xf1, 0x3f, 0x1a, 0xac, 0x4d, 0xec, 0xeb, 0x57, 0xf6, 0xda, 0x17, 0x39, 0x5e, 0x7b,
+	0xef, 0x82, 0x04, 0x62, 0x9a, 0xab, 0xf0, 0x0c, 0x9e, 0xfa, 0x28, 0x4a, 0x58, 0x6a, 0xd9, 0xdc,
+	0x41, 0xb6, 0x97, 0xee, 0xb7, 0x02, 0x1b, 0x7f, 0x54, 0x80, 0x5a, 0x5e, 0x62, 0x72, 0xe5, 0xbe,
+	0x1f, 0xe4, 0xf4, 0xfd, 0xb7, 0x2f, 0x4e, 0x82, 0xa6, 0x75, 0xff, 0xe7, 0x33, 0x74, 0xff, 0x59,
+	0x32, 0xf5, 0xaa, 0x4d, 0x6c, 0xc1, 0x21, 0x2b, 0xed, 0x84, 0x58, 0xc4, 0xf8, 0xe3, 0x22, 0xdc,
+	0xc8, 0xc9, 0xb1, 0xae, 0xec, 0x86, 0xfd, 0x1c, 0x37, 0x3c, 0xb8, 0x30, 0x9d, 0x9b, 0xe6, 0x85,
+	0xff, 0x9e, 0x65, 0xbe, 0x6c, 0xc1, 0xa2, 0x65, 0x33, 0xdc, 0x25, 0x8e, 0x5e, 0xcb, 0xe6, 0x9f,
+	0x4d, 0xc9, 0x48, 0xbb, 0x20, 0x84, 0xa3, 0x5d, 0x58, 0xb1, 0x08, 0x65, 0x2a, 0x81, 0xa4, 0xfa,
+	0xcd, 0x6c, 0xb2, 0xd7, 0x24, 0x94, 0xc9, 0x89, 0x4e, 0xd3, 0x2a, 0x96, 0xad, 0x98, 0x83, 0x3e,
+	0x03, 0x70, 0xbd, 0x2e, 0xe9, 0xe0, 0x3e, 0x4f, 0x41, 0xd7, 0xb3, 0x03, 0x71, 0xe8, 0x75, 0xc9,
+	0x36, 0x67, 0x65, 0x06, 0xc2, 0x0d, 0xe9, 0xc6, 0x9f, 0x14, 0xe0, 0xf6, 0x05, 0x89, 0xea, 0x95,
+	0x07, 0xa4, 0x95, 0x33, 0x20, 0x1f, 0x5c, 0x2a, 0x37, 0xfe, 0x35, 0xcd, 0xce, 0x3e, 0x76, 0x1c,
+	0x92, 0x33, 0x3b, 0xf7, 0x04, 0x3d, 0x33, 0x3b, 0x25, 0xd8, 0xf8, 0xd3, 0x02, 0xe8, 0xd3, 0x92,
+	0xec, 0x2b, 0x7b, 0xe2, 0xcb, 0x1c, 0x4f, 0xfc, 0xf8, 0xed, 0x49, 0xfd, 0x34, 0x37, 0xb8, 0x33,
+	0xb8, 0xe1, 0x39, 0x2c, 0xc9, 0xe3, 0x84, 0x47, 0xf5, 0x5a, 0x76, 0x59, 0x6c, 0xdb, 0x5d, 0x62,
+	0x61, 0x7a, 0xa0, 0x00, 0x69, 0x8f, 0x44, 0x72, 0xc6, 0xaf, 0x8a, 0x80, 0x26, 0xcf, 0x0c, 0x57,
+	0xf6, 0xc6, 0x5e, 0x8e, 0x37, 0x7e, 0xeb, 0xa2, 0xd3, 0xc9, 0x34, 0x3f, 0xfc, 0xb2, 0x30, 0x83,
+	0x23, 0x0e, 0x61, 0xd5, 0x76, 0xfb, 0x94, 0x04, 0x41, 0x47, 0x9d, 0x75, 0xf4, 0x7b, 0xd9, 0xe3,
+	0xda, 0x81, 0x04, 0x28, 0x0b, 0xd2, 0xee, 0xa8, 0xd8, 0x29, 0x26, 0x7a, 0x01, 0x15, 0x92, 0x56,
+	0x27, 0x8f, 0x4c, 0x3f, 0x88, 0xd5, 0xed, 0x4e, 0xd7, 0x56, 0x26, 0x29, 0x65, 0x9f, 0x43, 0x35,
+	0x63, 0x5c, 0xa0, 0xdf, 0x17, 0xe9, 0xee, 0xcd, 0x09, 0xc7, 0x08, 0xf9, 0xd5, 0xb4, 0x35, 0x01,
+	0x7a, 0x06, 0xab, 0x24, 0xa3, 0xc0, 0xb8, 0x48, 0x41, 0x25, 0x65, 0x40, 0x60, 0xfc, 0xa7, 0x06,
+	0x95, 0xf4, 0x11, 0xef, 0xca, 0xe3, 0xdb, 0xcc, 0x19, 0xdf, 0xef, 0x4f, 0x3b, 0x48, 0x4e, 0x1b,
+	0xdb, 0xaf, 0x66, 0x18, 0xda, 0x1f, 0xc9, 0x23, 0xab, 0x0c, 0xef, 0x7a, 0xaa, 0xa5, 0xb4, 0xd7,
+	0x39, 0xcc, 0xf8, 0x5f, 0x0d, 0xd0, 0xe4, 0xe1, 0xf4, 0xbb, 0x8a, 0xe6, 0xc9, 0x96, 0xa6, 0xf5,
+	0xb8, 0x3b, 0xe3, 0xa6, 0xa3, 0x0e, 0xde, 0x93, 0x9b, 0x8e, 0x64, 0x64, 0x37, 0x1d, 0x49, 0x35,
+	0xfe, 0x4a, 0x03, 0x34, 0x99, 0xc8, 0x5d, 0xb9, 0xf7, 0x29, 0x33, 0x0b, 0x59, 0x33, 0x1f, 0x43,
+	0xf1, 0x74, 0x2b, 0xd0, 0x5b, 0x42, 0xe3, 0xfd, 0x58, 0xe3, 0x8b, 0xd1, 0x09, 0xa1, 0x2e, 0x61,
+	0x24, 0x30, 0x49, 0xe0, 0x8d, 0xa8, 0x45, 0x02, 0x39, 0x3e, 0xa7, 0x5b, 0x81, 0xb4, 0x70, 0x22,
+	0x6d, 0xbc, 0x4e, 0x16, 0xfe, 0xa3, 0x06, 0x77, 0x2e, 0x5a, 0x3a, 0xaf, 0x93, 0xad, 0x7f, 0xad,
+	0xc1, 0x8d, 0x9c, 0x6c, 0xf4, 0x3a, 0x99, 0xf8, 0xb7, 0x1a, 0xac, 0xe7, 0xa7, 0x8b, 0xd7, 0xc9,
+	0xca, 0xbf, 0xd1, 0xa0, 0x96, 0x97, 0xcf, 0x5d, 0x27, 0x1b, 0xff, 0x4e, 0x03, 0x7d, 0x5a, 0xd2,
+	0x78, 0xdd, 0x46, 0x3c, 0x3f, 0x2f, 0xbd, 0x6e, 0x53, 0x27, 0x27, 0x55, 0xbc, 0x4e, 0x26, 0xfe,
+	0x83, 0x06, 0xb7, 0x2f, 0x48, 0x5a, 0xae, 0x93, 0xa9, 0x7f, 0xaf, 0x41, 0x7d, 0xf7, 0x37, 0xc2,
+	0xd2, 0xbf, 0xd0, 0xa0, 0x9a, 0x4d, 0x1d, 0xae, 0xdd, 0x4a, 0x94, 0xb3, 0xc9, 0x5f, 0x27, 0x1b,
+	0x7f, 0x51, 0x85, 0x5b, 0x53, 0x00, 0xfc, 0x6e, 0x82, 0xd7, 0xb0, 0x5c, 0x7e, 0xeb, 0x12, 0xda,
+	0x29, 0xef, 0xf8, 0x1a, 0xd8, 0xb7, 0x1b, 0x96, 0x47, 0x49, 0xe3, 0xec, 0x61, 0x63, 0x5b, 0x61,
+	0xcc, 0x08, 0xcd, 0x13, 0x3d, 0xe2, 0x9e, 0xe9, 0x05, 0x91, 0xd8, 0xd6, 0xf3, 0x84, 0x76, 0xdd,
+	0xb3, 0x63, 0x4c, 0x4d, 0x0e, 0x43, 0xc7, 0xb0, 0x34, 0xf0, 0x71, 0x27, 0x71, 0x5d, 0xf7, 0x49,
+	0x52, 0x24, 0x71, 0xf7, 0xd8, 0x50, 0x77, 0x8f, 0x8d, 0x7d, 0x8f, 0xda, 0x7f, 0xe0, 0xb9, 0x0c,
+	0x3b, 0xbc, 0xd2, 0xa8, 0x00, 0x84, 0xca, 0x14, 0x6a, 0xe0, 0x63, 0x61, 0xff, 0xfb, 0xb0, 0x66,
+	0x0f, 0x45, 0x91, 0x76, 0xc4, 0x2b, 0xb6, 0xb2, 0x04, 0x34, 0x27, 0xdc, 0xb6, 0x2a, 0x18, 0xad,
+	0x91, 0xe3, 0xa8, 0xba, 0xf0, 0x57, 0x50, 0x16, 0x87, 0xf3, 0x80, 0x38, 0xf2, 0x0c, 0x36, 0x2f,
+	0x6c, 0x7f, 0xfc, 0x56, 0x37, 0x8a, 0x73, 0x7b, 0x5b, 0x49, 0xa9, 0x1b, 0x06, 0x37, 0x41, 0x42,
+	0xaf, 0xe1, 0x26, 0xaf, 0xc8, 0x77, 0xed, 0x80, 0x8e, 0x7c, 0x51, 0xba, 0x3e, 0x19, 0x75, 0xfb,
+	0x84, 0xe9, 0x0b, 0xd9, 0x81, 0x6a, 0x79, 0xdd, 0x9d, 0x08, 0xf5, 0x5c, 0x80, 0x44, 0x87, 0x6e,
+	0xf8, 0x93, 0x0c, 0xf4, 0xfb, 0xb0, 0x9a, 0x2d, 0xf4, 0x2f, 0x0a, 0x93, 0x3f, 0x7a, 0xbb, 0xc9,
+	0x79, 0x45, 0xfe, 0x8a, 0x9f, 0x22, 0xa2, 0x06, 0xdc, 0xf0, 0xa9, 0xed, 0xf1, 0x4a, 0x4c, 0xc7,
+	0x72, 0x70, 0x10, 0x88, 0x0b, 0x47, 0x7d, 0x49, 0xb8, 0x6f, 0x2d, 0x64, 0x35, 0x39, 0x87, 0xdf,
+	0x38, 0xf2, 0xda, 0x3c, 0x25, 0xb8, 0x6b, 0xbb, 0xfc, 0x68, 0xe3, 0x53, 0xef, 0x84, 0xe8, 0xa5,
+	0xec, 0xd5, 0x94, 0x19, 0x02, 0x5a, 0x9c, 0x6f, 0x56, 0x68, 0xea, 0x1b, 0x7d, 0x1f, 0xca, 0x94,
+	0xf8, 0x8e, 0x6d, 0xe1, 0x8e, 0xe5, 0x8d, 0x5c, 0xa6, 0xc3, 0x86, 0xf6, 0xa0, 0x6c, 0xae, 0x28,
+	0x62, 0x93, 0xd3, 0xd0, 0x43, 0x28, 0xd1, 0xb0, 0x33, 0xfa, 0xb2, 0x68, 0xe1, 0x46, 0xb2, 0x05,
+	0xc5, 0x32, 0x63, 0x14, 0x7a, 0x0a, 0x8b, 0x01, 0xa1, 0x67, 0xb6, 0x45, 0xf4, 0x15, 0x75, 0x1b,
+	0x9c, 0x13, 0x91, 0x6d, 0x09, 0x91, 0x21, 0xa4, 0xf0, 0x7c, 0x0a, 0x04, 0x8c, 0x62, 0x46, 0xfa,
+	0x63, 0xbd, 0x9c, 0x9d, 0xaa, 0x3b, 0xc4, 0x77, 0xbc, 0x31, 0x2f, 0x89, 0xb4, 0x15, 0xc6, 0x8c,
+	0xd0, 0xe8, 0x73, 0x58, 0x66, 0x9e, 0x43, 0xa8, 0x1a, 0x9b, 0x8a, 0x18, 0x9b, 0xbb, 0x79, 0x0d,
+	0x1f, 0x45, 0x30, 0x33, 0x29, 0x82, 0x0e, 0xa1, 0x1a, 0xde, 0x62, 0x76, 0xf8, 0x95, 0x3e, 0x39,
+	0x67, 0xfa, 0xaa, 0x3a, 0xa4, 0xe5, 0xda, 0x2f, 0xb1, 0x4d, 0x09, 0x35, 0x57, 0x83, 0x34, 0x01,
+	0x7d, 0x05, 0x35, 0x1e, 0x31, 0x13, 0x3a, 0xab, 0xaa, 0xda, 0x90, 0xa3, 0xb3, 0xe5, 0x75, 0xb3,
+	0x6a, 0x91, 0x3f, 0x41, 0x43, 0xc7, 0x80, 0x12, 0x13, 0x2d, 0x20, 0x16, 0x25, 0x2c, 0xd0, 0xd7,
+	0x44, 0x97, 0x1f, 0xe4, 0xe9, 0x7d, 0xe9, 0x59, 0xd8, 0x79, 0x75, 0xc2, 0x53, 0x72, 0x93, 0xf4,
+	0x08, 0x25, 0xae, 0x45, 0xcc, 0x6a, 0x34, 0x27, 0xdb, 0x52, 0x03, 0xbf, 0x6f, 0x51, 0x03, 0xd1,
+	0xc1, 0x96, 0x08, 0x09, 0x19, 0x84, 0x48, 0xde, 0xb7, 0x28, 0xde, 0xb6, 0x64, 0x89, 0x28, 0xfc,
+	0x0c, 0x2a, 0x8e, 0x7d, 0x46, 0x12, 0x41, 0x78, 0xe3, 0x2d, 0x41, 0x58, 0x0e, 0xf1, 0xe2, 0x13,
+	0x7d, 0x0a, 0xe5, 0x80, 0x61, 0xca, 0x46, 0xbe, 0x92, 0xaf, 0xbd, 0x45, 0x7e, 0x45, 0xc1, 0xa5,
+	0xf8, 0x43, 0x28, 0x39, 0x76, 0x8f, 0x58, 0x63, 0xcb, 0x21, 0xfa, 0xcd, 0x6c, 0x74, 0xbe, 0x0c,
+	0x59, 0x66, 0x8c, 0x42, 0x3d, 0xd8, 0x60, 0x84, 0x0e, 0x6d, 0x57, 0x0c, 0x7b, 0xa7, 0x4f, 0xb1,
+	0x45, 0x3a, 0x3e, 0xa1, 0xb6, 0x1c, 0x2b, 0x8f, 0xdf, 0x52, 0xad, 0x67, 0x2b, 0x63, 0x7c, 0x97,
+	0x38, 0x70, 0xd9, 0x93, 0x0f, 0x13, 0xdb, 0xc4, 0xbb, 0x09, 0x35, 0x7b, 0x5c, 0x4b, 0x4b, 0x28,
+	0x69, 0x4b, 0x1d, 0xe8, 0x04, 0x6e, 0x33, 0xcf, 0xf7, 0x1c, 0xaf, 0x3f, 0xee, 0x04, 0x3e, 0x9f,
+	0x7a, 0x3c, 0x02, 0x78, 0xbc, 0xda, 0xfc, 0x40, 0x7c, 0x6b, 0xa3, 0x98, 0x2e, 0x6a, 0x1e, 0x29,
+	0x70, 0x5b, 0x60, 0x9b, 0x11, 0xd4, 0x7c, 0x87, 0x4d, 0xe1, 0x04, 0xf2, 0x4e, 0xc4, 0xe5, 0x57,
+	0x69, 0x84, 0x06, 0xba, 0x9e, 0xbd, 0xa2, 0x9d, 0xb6, 0x1e, 0x35, 0x23, 0x19, 0xb9, 0x16, 0x25,
+	0x94, 0xa0, 0x27, 0xb0, 0xe4, 0x9d, 0x11, 0xea, 0xf0, 0x42, 0x49, 0x57, 0xed, 0x27, 0x91, 0xc2,
+	0xd3, 0xad, 0x40, 0x46, 0xd1, 0x2b, 0x09, 0x31, 0x23, 0x2c, 0xda, 0x85, 0x32, 0x39, 0x67, 0x14,
+	0x77, 0x3c, 0x01, 0x08, 0x74, 0xb2, 0x51, 0x4c, 0x17, 0x5c, 0xa7, 0x5c, 0x3e, 0xaf, 0x08, 0x31,
+	0xa9, 0x36, 0x40, 0x4d, 0xa8, 0x50, 0x32, 0xf4, 0xce, 0x48, 0xa4, 0xa7, 0xb7, 0x51, 0x4c, 0x2f,
+	0x03, 0x91, 0x11, 0x71, 0x28, 0x97, 0xa5, 0x8c, 0x52, 0xc2, 0x6f, 0xf8, 0x26, 0x76, 0x89, 0x99,
+	0xae, 0xc3, 0xb6, 0xf9, 0xd9, 0xf0, 0xdb, 0xdd, 0xa8, 0x1d, 0xc3, 0x6a, 0xc6, 0xcd, 0x39, 0xe2,
+	0x3f, 0x4e, 0x8a, 0xa7, 0x9e, 0xbf, 0x44, 0xb2, 0xf2, 0x6a, 0x28, 0xbe, 0x5f, 0xfb, 0x2f, 0x0d,
+	0xaa, 0xd9, 0x61, 0x40, 0xf7, 0x60, 0x19, 0xfb, 0x76, 0xe7, 0x4c, 0xbd, 0x81, 0xd1, 0x26, 0xde,
+	0xc0, 0x84, 0xef, 0x66, 0x0a, 0x89, 0x77, 0x33, 0x08, 0xe6, 0xc4, 0xec, 0x2e, 0x4a, 0x1a, 0xff,
+	0x8d, 0x9e, 0xc1, 0xa2, 0x8f, 0x99, 0x35, 0x20, 0xfc, 0x45, 0x4e, 0x31, 0x5d, 0xb5, 0xcb, 0xb6,
+	0xda, 0xe0, 0x37, 0xae, 0x62, 0x3e, 0x98, 0xa1, 0x50, 0xfd, 0x10, 0x4a, 0x11, 0x95, 0x37, 0x20,
+	0xae, 0x6b, 0xa5, 0x39, 0xe2, 0xf7, 0x05, 0x3d, 0x56, 0x53, 0x4c, 0x45, 0x85, 0x44, 0x19, 0xff,
+	0x5e, 0x82, 0x72, 0xea, 0x25, 0x10, 0xfa, 0x24, 0x7a, 0x32, 0xa4, 0x6d, 0x14, 0xd3, 0x05, 0xb4,
+	0x14, 0xb0, 0x21, 0xff, 0xc8, 0x00, 0x57, 0x22, 0xfc, 0xaa, 0x79, 0x48, 0x82, 0x00, 0xf7, 0xc3,
+	0x01, 0x0b, 0x3f, 0xd1, 0x8f, 0x60, 0x8d, 0xdf, 0x7a, 0xda, 0x6e, 0x7f, 0x4f, 0x3e, 0x98, 0xe2,
+	0x7e, 0xe4, 0x9e, 0x29, 0x9a, 0x93, 0x0c, 0xf4, 0x03, 0xbe, 0x73, 0xca, 0x19, 0xc5, 0xcd, 0x96,
+	0xce, 0x2a, 0x99, 0x69, 0x22, 0x32, 0x60, 0xc5, 0xa7, 0x23, 0x97, 0xb4, 0x4f, 0x6d, 0xdf, 0x27,
+	0x5d, 0x91, 0xe2, 0x94, 0xcc, 0x14, 0x4d, 0x3c, 0x4e, 0xe2, 0xdf, 0xcf, 0xb1, 0x75, 0x3a, 0xf2,
+	0xd5, 0xeb, 0xa5, 0x24, 0x09, 0x6d, 0x8b, 0x39, 0xde, 0xb5, 0x93, 0x39, 0xc7, 0xfd, 0x69, 0x9d,
+	0x6e, 0x86, 0x48, 0x33, 0x21, 0xc4, 0xbb, 0x1d, 0x86, 0x86, 0xcc, 0x27, 0xc2, 0x4f, 0xd4, 0x80,
+	0x9c, 0x5b, 0x74, 0xbd, 0x34, 0xf5, 0x7e, 0xfd, 0x29, 0x2c, 0x8e, 0xfc, 0x3e, 0xc5, 0x5d, 0xa2,
+	0x83, 0xda, 0xda, 0xa7, 0x58, 0xf2, 0x5a, 0xc2, 0xcc, 0x10, 0x8f, 0xea, 0xb0, 0x24, 0x6f, 0xfd,
+	0x49, 0x57, 0x5f, 0x16, 0x9e, 0x88, 0xbe, 0xeb, 0xbf, 0xd2, 0xa0, 0xac, 0x42, 0xb5, 0x1d, 0x8d,
+	0x54, 0x3a, 0x9a, 0x23, 0x93, 0x9f, 0x44, 0x01, 0x50, 0x10, 0x6f, 0x11, 0xee, 0x5e, 0x1c, 0x00,
+	0xd1, 0xd8, 0x1b, 0xb0, 0x22, 0x7f, 0xc9, 0x05, 0x48, 0x85, 0x7d, 0x8a, 0xc6, 0xa7, 0x33, 0xa1,
+	0xd4, 0xa3, 0x2a, 0x6b, 0x95, 0x1f, 0x5c, 0x72, 0x88, 0x5d, 0xbb, 0x47, 0x02, 0xb6, 0x8f, 0x83,
+	0x81, 0x7a, 0xff, 0x95, 0xa2, 0xf1, 0x71, 0x54, 0x0b, 0x96, 0x80, 0xa8, 0x71, 0x4c, 0x90, 0xea,
+	0x7f, 0xae, 0x41, 0x29, 0x1a, 0x1e, 0x3e, 0x37, 0xd8, 0xd8, 0x0f, 0x2b, 0x98, 0xe2, 0x37, 0x5a,
+	0x4f, 0xf5, 0xac, 0x14, 0x59, 0xbe, 0x0e, 0x0b, 0x94, 0xe0, 0x40, 0x05, 0x64, 0xc9, 0x54, 0x5f,
+	0xc9, 0x68, 0x9e, 0x4b, 0x47, 0x73, 0x03, 0x90, 0x83, 0x03, 0x76, 0x44, 0xb1, 0x1b, 0x88, 0xf6,
+	0x8e, 0xec, 0x61, 0xf8, 0x6e, 0x2d, 0x87, 0x53, 0xff, 0x17, 0x0d, 0x16, 0xd5, 0x80, 0xf1, 0x9e,
+	0xf4, 0xa8, 0x37, 0x3c, 0x4e, 0x79, 0x3f, 0x49, 0xe2, 0xc7, 0x22, 0xe6, 0x85, 0x7c, 0x75, 0x2c,
+	0x8a, 0x08, 0xdc, 0x87, 0xfe, 0x00, 0x07, 0xe1, 0xba, 0x22, 0x3f, 0x2e, 0xb0, 0xf5, 0x0e, 0x94,
+	0xc4, 0x96, 0x9e, 0x30, 0x31, 0x26, 0xa0, 0xf7, 0xa0, 0xc2, 0x4b, 0xc1, 0xf2, 0x4d, 0x89, 0x80,
+	0x48, 0xd7, 0x66, 0xa8, 0xf5, 0x9f, 0xc1, 0x72, 0x62, 0xc2, 0xe7, 0x2c, 0xb5, 0x9f, 0xa4, 0x17,
+	0x9e, 0x1f, 0x4e, 0x8b, 0x9a, 0x54, 0x18, 0x26, 0x17, 0xde, 0x63, 0x58, 0x50, 0xb1, 0xb9, 0x04,
+	0x73, 0x87, 0xaf, 0x0e, 0x77, 0xab, 0xdf, 0x43, 0x2b, 0xb0, 0xf4, 0xba, 0xb5, 0xb3, 0x7d, 0x74,
+	0x70, 0xb8, 0x57, 0xd5, 0xd0, 0x32, 0x2c, 0xee, 0xef, 0x6e, 0xbf, 0x3c, 0xda, 0xff, 0xba, 0x5a,
+	0x40, 0x25, 0x98, 0xdf, 0x35, 0xcd, 0x57, 0x66, 0xb5, 0x88, 0x56, 0x61, 0xd9, 0xdc, 0x6d, 0xbe,
+	0x3a, 0x6c, 0x1e, 0xbc, 0xe4, 0xc0, 0x39, 0x0e, 0x7c, 0xfe, 0xf2, 0x55, 0xf3, 0xc5, 0xee, 0x4e,
+	0x75, 0x9e, 0x97, 0xdd, 0x4b, 0xd1, 0xf6, 0x8c, 0x7e, 0x02, 0x0b, 0x8e, 0x3d, 0xb4, 0x59, 0xb8,
+	0xbc, 0xdd, 0xcb, 0xc9, 0xb5, 0x1b, 0x2f, 0x05, 0x42, 0x2d, 0x6d, 0x12, 0x8e, 0x3e, 0xe5, 0xd3,
+	0xeb, 0x9b, 0x11, 0x09, 0x58, 0xa0, 0x17, 0xb2, 0x8b, 0x44, 0x2c, 0x6a, 0x2a, 0x8c, 0x14, 0x8e,
+	0x44, 0xea, 0x4f, 0x61, 0x39, 0xa1, 0x75, 0xa6, 0x9d, 0xee, 0x13, 0x28, 0xa7, 0xb4, 0xce, 0x22,
+	0x6c, 0xfc, 0x5f, 0x01, 0x2a, 0xe9, 0x0c, 0x0f, 0x3d, 0x80, 0x39, 0x72, 0x4e, 0x2c, 0x75, 0x04,
+	0xae, 0x25, 0xae, 0x8d, 0xce, 0x89, 0xb5, 0x2d, 0xee, 0x02, 0x4d, 0x81, 0x40, 0x0f, 0x61, 0x71,
+	0xc0, 0x98, 0xbf, 0x47, 0xd8, 0xe4, 0x76, 0xb2, 0x7f, 0x74, 0xd4, 0xda, 0x23, 0x4c, 0xe1, 0x43,
+	0x1c, 0xfa, 0x09, 0x94, 0x98, 0xe5, 0xb7, 0x3d, 0xeb, 0x94, 0x30, 0x75, 0xf8, 0x7d, 0x27, 0xb1,
+	0x07, 0x35, 0x5b, 0x92, 0xa5, 0xc4, 0x62, 0x2c, 0xfa, 0x00, 0x6e, 0xf0, 0xb3, 0xb6, 0x8d, 0x9d,
+	0x1d, 0xe2, 0xe0, 0xb1, 0xca, 0xf2, 0x44, 0x30, 0xcf, 0x9b, 0x79, 0x2c, 0x1e, 0xba, 0xcc, 0x1e,
+	0x12, 0x6f, 0xc4, 0x42, 0xf0, 0xbc, 0x00, 0x67, 0xa8, 0x7c, 0x33, 0xf1, 0x93, 0x99, 0xa3, 0x88,
+	0xf0, 0x79, 0x33, 0x4d, 0x44, 0xef, 0x43, 0x35, 0x18, 0x59, 0x16, 0x09, 0x82, 0xa3, 0x01, 0x25,
+	0x01, 0x7f, 0xb9, 0xaa, 0x2f, 0x0a, 0xe0, 0x04, 0x9d, 0x63, 0x7b, 0xd8, 0x76, 0x46, 0x94, 0xc4,
+	0xd8, 0x25, 0x89, 0xcd, 0xd2, 0x8d, 0xf7, 0x00, 0x62, 0xbf, 0xf2, 0x69, 0xca, 0x1f, 0x9f, 0x61,
+	0xb7, 0x2b, 0xe2, 0xaf, 0x64, 0x86, 0x9f, 0xc6, 0x3f, 0x6b, 0x50, 0x4e, 0xf9, 0x34, 0x77, 0x7b,
+	0x7f, 0x04, 0x73, 0xbe, 0x47, 0xc3, 0xe1, 0xb8, 0x3b, 0xb1, 0xbb, 0xbf, 0xa2, 0x72, 0xa5, 0x95,
+	0x19, 0xb4, 0xc0, 0x72, 0x3d, 0x03, 0x2f, 0x60, 0x61, 0x1e, 0xc2, 0x7f, 0x8b, 0xa5, 0xd0, 0x1a,
+	0x90, 0x61, 0xb8, 0x5a, 0xa8, 0x2f, 0xf4, 0x04, 0x96, 0xf9, 0x48, 0xee, 0x13, 0xdc, 0xe5, 0x19,
+	0xaf, 0x2c, 0x1a, 0xd4, 0xd2, 0xa3, 0x2e, 0x99, 0x66, 0x12, 0x68, 0x3c, 0x01, 0x88, 0x59, 0x51,
+	0xe6, 0xa3, 0x25, 0x32, 0x9f, 0xdc, 0x10, 0x35, 0xbe, 0x86, 0xd5, 0x4c, 0x4c, 0x44, 0x5d, 0xd4,
+	0xae, 0xd0, 0xc5, 0x42, 0xdc, 0x45, 0xe3, 0x9f, 0x34, 0xb8, 0x35, 0xa5, 0x02, 0x21, 0x76, 0x1c,
+	0xdb, 0xdd, 0x3e, 0xc3, 0xb6, 0xc3, 0x2b, 0x51, 0xa2, 0xad, 0xb2, 0x99, 0xa2, 0xa1, 0x57, 0xfc,
+	0x8d, 0xae, 0x2a, 0x9e, 0x48, 0x77, 0x3f, 0x4e, 0x1c, 0xfd, 0xa2, 0x17, 0xe1, 0x0d, 0xff, 0xb4,
+	0xcf, 0x09, 0x41, 0x63, 0x48, 0x18, 0x16, 0x87, 0x41, 0x7c, 0x42, 0x9c, 0x30, 0x37, 0x36, 0x23,
+	0x25, 0x3c, 0x5e, 0x87, 0xf8, 0xfc, 0xb5, 0x8b, 0xa3, 0x66, 0x8b, 0xa2, 0xd9, 0x0c, 0xd5, 0xf8,
+	0x06, 0xd0, 0xe4, 0x49, 0x3c, 0x77, 0x43, 0xdb, 0x83, 0x32, 0xf5, 0x1c, 0x5e, 0x48, 0x7a, 0xed,
+	0x77, 0x31, 0x0b, 0xd7, 0xde, 0xe4, 0xc2, 0x94, 0x64, 0xc7, 0x5a, 0xcd, 0xb4, 0x1c, 0xaf, 0x3c,
+	0xde, 0x9a, 0x02, 0x45, 0x5f, 0x4c, 0x98, 0x7d, 0xb9, 0x91, 0xc9, 0x48, 0xa1, 0x8f, 0x61, 0x69,
+	0x88, 0xcf, 0xdb, 0x23, 0xda, 0x27, 0x97, 0x0c, 0xdf, 0x08, 0x6f, 0x3c, 0x03, 0x90, 0xa9, 0xf1,
+	0x97, 0x84, 0xe1, 0x28, 0xbc, 0xe6, 0x13, 0xe1, 0x95, 0x2a, 0x25, 0x2e, 0x64, 0x4a, 0x89, 0xc6,
+	0xef, 0x01, 0x9a, 0x3c, 0xd5, 0xfc, 0xda, 0xb2, 0x7a, 0xe3, 0x0f, 0xa1, 0x14, 0x1d, 0x85, 0xd1,
+	0x16, 0x94, 0x7c, 0x2f, 0x60, 0x6d, 0xbe, 0xc5, 0x2a, 0x57, 0xd5, 0x73, 0x8e, 0xcc, 0xfb, 0xd8,
+	0xed, 0x3a, 0x84, 0x9a, 0x31, 0x18, 0x7d, 0xc8, 0x1f, 0x6a, 0x92, 0x36, 0xf3, 0x7c, 0xbd, 0xf0,
+	0x56, 0xb9, 0x10, 0xca, 0x4b, 0xf1, 0xd5, 0x2c, 0xf7, 0x7a, 0xae, 0xf1, 0xc6, 0xbf, 0x69, 0xa0,
+	0x4f, 0x3b, 0x86, 0x8b, 0x0c, 0x06, 0x9f, 0xb7, 0x4f, 0xc9, 0x1b, 0x61, 0xf5, 0xbc, 0x19, 0x7e,
+	0xf2, 0x8c, 0x29, 0x3c, 0xa2, 0xbf, 0x20, 0x63, 0x35, 0x1a, 0x49, 0x12, 0x3f, 0x5d, 0xbc, 0x19,
+	0x10, 0xf7, 0xb5, 0x1b, 0x60, 0x66, 0x07, 0x3d, 0x3b, 0x9a, 0x5d, 0x25, 0x73, 0x92, 0x81, 0xbe,
+	0x86, 0xb2, 0x93, 0x9c, 0xa3, 0xfa, 0xdc, 0xd5, 0xa7, 0x77, 0x5a, 0x93, 0xf1, 0x1f, 0x45, 0x28,
+	0xa7, 0x8e, 0x96, 0xe9, 0xfa, 0x9e, 0x76, 0xa9, 0xfa, 0xde, 0x6c, 0xd5, 0xe6, 0xdc, 0xaa, 0x70,
+	0x31, 0xbf, 0x2a, 0x9c, 0x53, 0xd4, 0x9c, 0x9b, 0xb1, 0xa8, 0x39, 0x59, 0x91, 0x9a, 0xff, 0x96,
+	0x15, 0xa9, 0x85, 0x99, 0x2a, 0x52, 0x79, 0x55, 0xc4, 0xc5, 0x6f, 0x51, 0x45, 0x4c, 0x55, 0xb8,
+	0x96, 0x2e, 0x53, 0xe1, 0x32, 0xfe, 0xb2, 0x00, 0xcb, 0x89, 0x27, 0x2d, 0xdf, 0xd1, 0xf5, 0x47,
+	0x5e, 0xf9, 0xe0, 0x09, 0xcc, 0x8b, 0x78, 0xd3, 0xe7, 0xb2, 0xc5, 0x9f, 0x84, 0x3d, 0x32, 0x42,
+	0x65, 0x02, 0x2a, 0xe1, 0x57, 0xba, 0x4a, 0xa9, 0x6f, 0x01, 0xc4, 0x9a, 0x66, 0x4a, 0x3a, 0x75,
+	0x58, 0xcf, 0x2f, 0x46, 0x19, 0xab, 0x50, 0x4e, 0xd5, 0x21, 0x8c, 0x75, 0xa8, 0xe5, 0xad, 0xfd,
+	0x46, 0x0d, 0xd0, 0xa4, 0xeb, 0x8c, 0x9b, 0x70, 0x23, 0xa7, 0x52, 0xf8, 0xfe, 0x16, 0x54, 0xd2,
+	0xcf, 0xe4, 0x11, 0xc0, 0x82, 0xa0, 0x90, 0xea, 0xf7, 0xf8, 0x6f, 0x53, 0x1c, 0x84, 0xab, 0x1a,
+	0xaa, 0x00, 0xc8, 0xdf, 0x4d, 0x73, 0x27, 0xa8, 0x16, 0x4e, 0x16, 0xc4, 0xbf, 0x5c, 0x3d, 0xfe,
+	0xff, 0x01, 0x00, 0xe7, 0x01, 0xaf, 0xb9, 0x81, 0x36, 0x00, 0x00,
 }
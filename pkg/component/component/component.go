@@ -21,6 +21,7 @@ package component
 
 import (
 	"fmt"
+	"strings"
 
 	"istio.io/operator/pkg/tpath"
 
@@ -51,6 +52,9 @@ type Options struct {
 	InstallSpec *v1alpha2.IstioControlPlaneSpec
 	// Translator is the translator for this component.
 	Translator *translate.Translator
+	// OverlayAnalysis, if set, collects a report of the effect of each k8s overlay on the rendered manifest instead of
+	// failing rendering when an overlay does not apply.
+	OverlayAnalysis *patch.OverlayAnalysis
 }
 
 // IstioComponent defines the interface for a component.
@@ -66,9 +70,14 @@ type IstioComponent interface {
 // CommonComponentFields is a struct common to all components.
 type CommonComponentFields struct {
 	*Options
-	name     name.ComponentName
-	started  bool
-	renderer helm.TemplateRenderer
+	name name.ComponentName
+	// instanceName, if set, is the name the component manifest is reported under, for components such as named
+	// gateways which are rendered using the chart and settings paths of the component name.
+	instanceName name.ComponentName
+	// renameObjects, if set, renames the objects rendered from the chart before the k8s settings are applied.
+	renameObjects func(manifest string) (string, error)
+	started       bool
+	renderer      helm.TemplateRenderer
 }
 
 // NewComponent creates a new IstioComponent with the given name and options.
@@ -711,6 +720,107 @@ func (c *TracingComponent) Name() name.ComponentName {
 	return c.CommonComponentFields.name
 }
 
+// GatewayComponent is a named gateway from the Gateways.Components.IngressGateways or EgressGateways lists. It is
+// rendered from the chart of its base ingress or egress gateway component, with the base component settings replaced
+// by those in its GatewaySpec.
+type GatewayComponent struct {
+	*CommonComponentFields
+	gateway *v1alpha2.GatewaySpec
+}
+
+// NewGatewayComponent creates a new GatewayComponent for the gateway gw, which is rendered from the chart of
+// baseComponentName, and returns a pointer to it.
+func NewGatewayComponent(baseComponentName name.ComponentName, gw *v1alpha2.GatewaySpec, opts *Options) *GatewayComponent {
+	return &GatewayComponent{
+		CommonComponentFields: &CommonComponentFields{
+			Options:      opts,
+			name:         baseComponentName,
+			instanceName: name.GatewayComponentName(baseComponentName, gw.GetName()),
+		},
+		gateway: gw,
+	}
+}
+
+// Run implements the IstioComponent interface.
+func (c *GatewayComponent) Run() error {
+	baseMaps := c.Translator.ComponentMaps[c.CommonComponentFields.name]
+	opts, err := gatewayOptions(c.Options, c.CommonComponentFields.name, c.gateway)
+	if err != nil {
+		return err
+	}
+	c.Options = opts
+	c.renameObjects = func(manifest string) (string, error) {
+		return renameGatewayObjects(manifest, baseMaps, c.gateway)
+	}
+	return runComponent(c.CommonComponentFields)
+}
+
+// RenderManifest implements the IstioComponent interface.
+func (c *GatewayComponent) RenderManifest() (string, error) {
+	if !c.started {
+		return "", fmt.Errorf("component %s not started in RenderManifest", c.Name())
+	}
+	return renderManifest(c.CommonComponentFields)
+}
+
+// Name implements the IstioComponent interface.
+func (c *GatewayComponent) Name() name.ComponentName {
+	return c.CommonComponentFields.instanceName
+}
+
+// gatewayOptions returns a copy of opts for rendering the named gateway gw from the chart of baseComponentName. In the
+// returned InstallSpec, the base component settings are replaced with those of gw. The returned Translator maps the
+// base component resources to gw's name.
+func gatewayOptions(opts *Options, baseComponentName name.ComponentName, gw *v1alpha2.GatewaySpec) (*Options, error) {
+	icp := *opts.InstallSpec
+	gf := v1alpha2.GatewayFeatureSpec{}
+	if icp.Gateways != nil {
+		gf = *icp.Gateways
+	}
+	gc := v1alpha2.GatewayFeatureSpec_Components{}
+	if gf.Components != nil {
+		gc = *gf.Components
+	}
+	switch baseComponentName {
+	case name.IngressComponentName:
+		gc.IngressGateway = &v1alpha2.IngressGatewayComponentSpec{Enabled: gw.Enabled, Namespace: gw.Namespace, K8S: gw.K8S}
+	case name.EgressComponentName:
+		gc.EgressGateway = &v1alpha2.EgressGatewayComponentSpec{Enabled: gw.Enabled, Namespace: gw.Namespace, K8S: gw.K8S}
+	default:
+		return nil, fmt.Errorf("gateway %s has unknown base component %s", gw.Name, baseComponentName)
+	}
+	gf.Components = &gc
+	icp.Gateways = &gf
+
+	out := *opts
+	out.InstallSpec = &icp
+	out.Translator = opts.Translator.WithResourceName(baseComponentName, gw.Name)
+	return &out, nil
+}
+
+// renameGatewayObjects renames the objects in manifest, rendered from the chart of the base gateway component with
+// mappings cm, to the name of the named gateway gw. The gateway charts name their objects and set their app and istio
+// labels from fixed values, e.g. istio-ingressgateway and ingressgateway, which are replaced by the gateway name.
+// gw's labels are added to the label sets and selectors of the renamed objects.
+func renameGatewayObjects(manifest string, cm *translate.ComponentMaps, gw *v1alpha2.GatewaySpec) (string, error) {
+	baseName := cm.ResourceName
+	shortName := strings.TrimPrefix(baseName, "istio-")
+	fromLabels := map[string]string{"app": baseName, "istio": shortName}
+	toLabels := map[string]string{"app": gw.Name, "istio": gw.Name}
+	for k, v := range gw.Label {
+		toLabels[k] = v
+	}
+	return patch.RenameObjects(manifest, baseName, gw.Name, []string{shortName}, fromLabels, toLabels)
+}
+
+// reportedName returns the name the manifest for c is reported under.
+func (c *CommonComponentFields) reportedName() name.ComponentName {
+	if c.instanceName != "" {
+		return c.instanceName
+	}
+	return c.name
+}
+
 // runComponent performs startup tasks for the component defined by the given CommonComponentFields.
 func runComponent(c *CommonComponentFields) error {
 	r, err := createHelmRenderer(c)
@@ -725,6 +835,37 @@ func runComponent(c *CommonComponentFields) error {
 	return nil
 }
 
+// addRemoveObjects removes the objects listed in the component K8S.RemoveObjects from manifest and then appends the
+// objects in K8S.ExtraObjects, returning the resulting manifest.
+func addRemoveObjects(c *CommonComponentFields, manifest, namespace string) (string, error) {
+	var removeObjects []*v1alpha2.K8SObjectReference
+	pathToRemoveObjects := fmt.Sprintf("%s.Components.%s.K8S.RemoveObjects", c.FeatureName, c.name)
+	found, err := tpath.SetFromPath(c.InstallSpec, pathToRemoveObjects, &removeObjects)
+	if err != nil {
+		return "", err
+	}
+	if found && len(removeObjects) != 0 {
+		log.Infof("Removing kubernetes objects: %v", removeObjects)
+		if manifest, err = patch.RemoveObjects(manifest, namespace, removeObjects); err != nil {
+			return "", err
+		}
+	}
+
+	var extraObjects []map[string]interface{}
+	pathToExtraObjects := fmt.Sprintf("%s.Components.%s.K8S.ExtraObjects", c.FeatureName, c.name)
+	found, err = tpath.SetFromPath(c.InstallSpec, pathToExtraObjects, &extraObjects)
+	if err != nil {
+		return "", err
+	}
+	if found && len(extraObjects) != 0 {
+		log.Infof("Adding %d extra kubernetes objects", len(extraObjects))
+		if manifest, err = patch.AddObjects(manifest, namespace, extraObjects); err != nil {
+			return "", err
+		}
+	}
+	return manifest, nil
+}
+
 // renderManifest renders the manifest for the component defined by c and returns the resulting string.
 func renderManifest(c *CommonComponentFields) (string, error) {
 	e, err := c.Translator.IsComponentEnabled(c.name, c.InstallSpec)
@@ -732,7 +873,7 @@ func renderManifest(c *CommonComponentFields) (string, error) {
 		return "", err
 	}
 	if !e {
-		return disabledYAMLStr(c.name), nil
+		return disabledYAMLStr(c.reportedName()), nil
 	}
 
 	mergedYAML, err := c.Translator.TranslateHelmValues(c.InstallSpec, c.name)
@@ -751,16 +892,35 @@ func renderManifest(c *CommonComponentFields) (string, error) {
 	if devDbg {
 		log.Infof("Initial manifest with merged values:\n%s\n", my)
 	}
+	if c.renameObjects != nil {
+		if my, err = c.renameObjects(my); err != nil {
+			return "", fmt.Errorf("component %s: %s", c.reportedName(), err)
+		}
+	}
 	// Add the k8s resources from IstioControlPlaneSpec.
 	my, err = c.Translator.OverlayK8sSettings(my, c.InstallSpec, c.name)
 	if err != nil {
 		log.Errorf("Error in OverlayK8sSettings: %s", err)
 		return "", err
 	}
-	my = "# Resources for " + string(c.name) + " component\n\n" + my
+	my = "# Resources for " + string(c.reportedName()) + " component\n\n" + my
 	if devDbg {
 		log.Infof("Manifest after k8s API settings:\n%s\n", my)
 	}
+	ns, err := name.Namespace(c.FeatureName, c.name, c.InstallSpec)
+	if err != nil {
+		return "", err
+	}
+	// Remove and add whole k8s objects from IstioControlPlaneSpec.
+	my, err = addRemoveObjects(c, my, ns)
+	if err != nil {
+		return "", err
+	}
+	// Add the common labels and annotations from IstioControlPlaneSpec.
+	my, err = patch.AddCommonMetadata(my, c.InstallSpec)
+	if err != nil {
+		return "", err
+	}
 	// Add the k8s resource overlays from IstioControlPlaneSpec.
 	pathToK8sOverlay := fmt.Sprintf("%s.Components.%s.K8S.Overlays", c.FeatureName, c.name)
 	var overlays []*v1alpha2.K8SObjectOverlay
@@ -777,13 +937,21 @@ func renderManifest(c *CommonComponentFields) (string, error) {
 		return "", err
 	}
 	log.Infof("Applying kubernetes overlay: \n%s\n", kyo)
-	ns, err := name.Namespace(c.FeatureName, c.name, c.InstallSpec)
-	if err != nil {
-		return "", err
+	if c.OverlayAnalysis != nil {
+		reports, err := patch.AnalyzeYAMLManifestPatch(my, ns, overlays)
+		if err != nil {
+			return "", err
+		}
+		c.OverlayAnalysis.Add(string(c.reportedName()), reports)
 	}
 	ret, err := patch.YAMLManifestPatch(my, ns, overlays)
 	if err != nil {
-		return "", err
+		if c.OverlayAnalysis == nil {
+			return "", err
+		}
+		// The failed overlays are already recorded in the analysis.
+		log.Warnf("Overlays for component %s could not be applied: %s", c.reportedName(), err)
+		return my, nil
 	}
 
 	log.Infof("Manifest after resources and overlay: \n%s\n", ret)
@@ -16,6 +16,8 @@ package controlplane
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
 	"istio.io/operator/pkg/component/feature"
@@ -32,12 +34,24 @@ type IstioControlPlane struct {
 
 // NewIstioControlPlane creates a new IstioControlPlane and returns a pointer to it.
 func NewIstioControlPlane(installSpec *v1alpha2.IstioControlPlaneSpec, translator *translate.Translator) *IstioControlPlane {
-	opts := &feature.Options{
+	return NewIstioControlPlaneWithOptions(&feature.Options{
 		InstallSpec: installSpec,
 		Translator:  translator,
-	}
-	features := make([]feature.IstioFeature, 0, len(translator.FeatureMaps))
+	})
+}
+
+// NewIstioControlPlaneWithOptions creates a new IstioControlPlane with the given feature options and returns a pointer
+// to it.
+func NewIstioControlPlaneWithOptions(opts *feature.Options) *IstioControlPlane {
+	translator := opts.Translator
+	// Features are created in name order so that rendering errors are reported in a stable order.
+	featureNames := make([]name.FeatureName, 0, len(translator.FeatureMaps))
 	for ft := range translator.FeatureMaps {
+		featureNames = append(featureNames, ft)
+	}
+	sort.Slice(featureNames, func(i, j int) bool { return featureNames[i] < featureNames[j] })
+	features := make([]feature.IstioFeature, 0, len(featureNames)+1)
+	for _, ft := range featureNames {
 		features = append(features, feature.NewFeature(ft, opts))
 	}
 	//add third Party feature as well
@@ -58,17 +72,34 @@ func (i *IstioControlPlane) Run() error {
 	return nil
 }
 
-// RenderManifest returns a manifest rendered against
+// RenderManifest returns a manifest rendered against the IstioControlPlane parameters. Features are rendered in
+// parallel, and the results are merged in feature order so that the output does not depend on scheduling.
 func (i *IstioControlPlane) RenderManifest() (manifests name.ManifestMap, errsOut util.Errors) {
 	if !i.started {
 		return nil, util.NewErrs(fmt.Errorf("istioControlPlane must be Run before calling RenderManifest"))
 	}
 
+	type result struct {
+		manifests name.ManifestMap
+		errs      util.Errors
+	}
+	results := make([]result, len(i.features))
+	var wg sync.WaitGroup
+	for idx, f := range i.features {
+		idx, f := idx, f
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ms, errs := f.RenderManifest()
+			results[idx] = result{manifests: ms, errs: errs}
+		}()
+	}
+	wg.Wait()
+
 	manifests = make(name.ManifestMap)
-	for _, f := range i.features {
-		ms, errs := f.RenderManifest()
-		manifests = mergeManifestMaps(manifests, ms)
-		errsOut = util.AppendErrs(errsOut, errs)
+	for _, r := range results {
+		manifests = mergeManifestMaps(manifests, r.manifests)
+		errsOut = util.AppendErrs(errsOut, r.errs)
 	}
 	if len(errsOut) > 0 {
 		return nil, errsOut
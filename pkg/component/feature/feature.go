@@ -18,6 +18,7 @@ import (
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
 	"istio.io/operator/pkg/component/component"
 	"istio.io/operator/pkg/name"
+	"istio.io/operator/pkg/patch"
 	"istio.io/operator/pkg/translate"
 	"istio.io/operator/pkg/util"
 )
@@ -36,6 +37,8 @@ type Options struct {
 	InstallSpec *v1alpha2.IstioControlPlaneSpec
 	// Translator is the translator for this feature.
 	Translator *translate.Translator
+	// OverlayAnalysis, if set, collects a report of the effect of each k8s overlay in the feature's components.
+	OverlayAnalysis *patch.OverlayAnalysis
 }
 
 // CommonFeatureFields are fields common to all features.
@@ -247,6 +250,18 @@ type GatewayFeature struct {
 // NewGatewayFeature creates a new GatewayFeature and returns a pointer to it.
 func NewGatewayFeature(opts *Options) *GatewayFeature {
 	cff := buildCommonFeatureFields(opts, name.GatewayFeatureName)
+	if opts != nil && opts.Translator != nil && opts.Translator.FeatureMaps != nil {
+		// Each named gateway is a separate component, rendered from the chart of the default ingress or egress gateway.
+		gcs := opts.InstallSpec.GetGateways().GetComponents()
+		for _, gw := range gcs.GetIngressGateways() {
+			cff.components = append(cff.components,
+				component.NewGatewayComponent(name.IngressComponentName, gw, newComponentOptions(cff, name.GatewayFeatureName)))
+		}
+		for _, gw := range gcs.GetEgressGateways() {
+			cff.components = append(cff.components,
+				component.NewGatewayComponent(name.EgressComponentName, gw, newComponentOptions(cff, name.GatewayFeatureName)))
+		}
+	}
 	return &GatewayFeature{
 		CommonFeatureFields: *cff,
 	}
@@ -337,9 +352,10 @@ func (f *CoreDNSFeature) RenderManifest() (name.ManifestMap, util.Errors) {
 // newComponentOptions creates a component.ComponentOptions ptr from the given parameters.
 func newComponentOptions(cff *CommonFeatureFields, featureName name.FeatureName) *component.Options {
 	return &component.Options{
-		InstallSpec: cff.InstallSpec,
-		FeatureName: featureName,
-		Translator:  cff.Translator,
+		InstallSpec:     cff.InstallSpec,
+		FeatureName:     featureName,
+		Translator:      cff.Translator,
+		OverlayAnalysis: cff.OverlayAnalysis,
 	}
 }
 
@@ -0,0 +1,150 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istiocontrolplane
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/util"
+)
+
+const (
+	// PausedKey is the annotation which stops the controller from rendering and applying an IstioControlPlane when
+	// set to "true". The status of the IstioControlPlane is still updated to report that reconciliation is paused.
+	PausedKey = MetadataNamespace + "/paused"
+	// ApprovalModeKey is the annotation which selects how changes to an IstioControlPlane are applied. If set to
+	// ApprovalModeManual, a generation is only applied after it is approved through ApprovedGenerationKey.
+	ApprovalModeKey = MetadataNamespace + "/approval-mode"
+	// ApprovedGenerationKey is the annotation naming the generation of an IstioControlPlane which is approved to be
+	// applied in manual approval mode.
+	ApprovedGenerationKey = MetadataNamespace + "/approved-generation"
+
+	// ApprovalModeManual is the ApprovalModeKey value which requires each generation to be approved.
+	ApprovalModeManual = "manual"
+
+	// pendingChangesSuffix is appended to the IstioControlPlane name to give the name of the ConfigMap which holds the
+	// changes waiting for approval.
+	pendingChangesSuffix = "-pending-changes"
+	// pendingGenerationKey is the key of the generation the pending changes were rendered from in the ConfigMap.
+	pendingGenerationKey = "generation"
+	// pendingChangesKey is the key of the pending changes in the ConfigMap.
+	pendingChangesKey = "changes"
+)
+
+// isPaused reports whether reconciliation of obj is paused.
+func isPaused(obj metav1.Object) bool {
+	return obj.GetAnnotations()[PausedKey] == "true"
+}
+
+// isApproved reports whether the current generation of obj may be applied.
+func isApproved(obj metav1.Object) bool {
+	annotations := obj.GetAnnotations()
+	if annotations[ApprovalModeKey] != ApprovalModeManual {
+		return true
+	}
+	return annotations[ApprovedGenerationKey] == strconv.FormatInt(obj.GetGeneration(), 10)
+}
+
+// reportPaused updates the status of icp to report that reconciliation is paused.
+func (r *ReconcileIstioControlPlane) reportPaused(icp *v1alpha2.IstioControlPlane) error {
+	msg := fmt.Sprintf("reconciliation is paused, remove the %s annotation to resume", PausedKey)
+	return r.updateStatusMessage(icp, msg, icp.GetStatus().GetPendingGeneration())
+}
+
+// requestApproval writes the changes the current generation of icp would make to the pending changes ConfigMap, if
+// they have not been written already, and updates the status of icp to report that the generation is waiting for
+// approval.
+func (r *ReconcileIstioControlPlane) requestApproval(icp *v1alpha2.IstioControlPlane) error {
+	generation := strconv.FormatInt(icp.GetGeneration(), 10)
+	cm := &corev1.ConfigMap{}
+	err := r.client.Get(context.TODO(), pendingChangesObjectKey(icp), cm)
+	switch {
+	case err == nil && cm.Data[pendingGenerationKey] == generation:
+		// The changes for this generation have already been rendered.
+	case err == nil || errors.IsNotFound(err):
+		if err := r.writePendingChanges(icp, cm, errors.IsNotFound(err)); err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	msg := fmt.Sprintf("generation %s is waiting for approval, review the changes in ConfigMap %s and set the %s "+
+		"annotation to %s to apply them", generation, pendingChangesObjectKey(icp), ApprovedGenerationKey, generation)
+	return r.updateStatusMessage(icp, msg, icp.GetGeneration())
+}
+
+// writePendingChanges renders the changes the current generation of icp would make into cm, which is created if
+// create is set and updated otherwise.
+func (r *ReconcileIstioControlPlane) writePendingChanges(icp *v1alpha2.IstioControlPlane, cm *corev1.ConfigMap, create bool) error {
+	reconciler, err := r.factory.New(icp, r.client)
+	if err != nil {
+		return fmt.Errorf("failed to create reconciler: %s", err)
+	}
+	changes, err := reconciler.Plan()
+	if err != nil {
+		return fmt.Errorf("failed to render pending changes: %s", err)
+	}
+	if changes == "" {
+		changes = "no changes\n"
+	}
+
+	key := pendingChangesObjectKey(icp)
+	cm.Name, cm.Namespace = key.Name, key.Namespace
+	cm.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(icp, util.IstioOperatorGVK)}
+	cm.Data = map[string]string{
+		pendingGenerationKey: strconv.FormatInt(icp.GetGeneration(), 10),
+		pendingChangesKey:    changes,
+	}
+	if create {
+		return r.client.Create(context.TODO(), cm)
+	}
+	return r.client.Update(context.TODO(), cm)
+}
+
+// deletePendingChanges deletes the pending changes ConfigMap for icp, if any.
+func (r *ReconcileIstioControlPlane) deletePendingChanges(icp *v1alpha2.IstioControlPlane) error {
+	key := pendingChangesObjectKey(icp)
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+	if err := r.client.Delete(context.TODO(), cm); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// updateStatusMessage sets the message and pending generation in the status of icp, if they have changed.
+func (r *ReconcileIstioControlPlane) updateStatusMessage(icp *v1alpha2.IstioControlPlane, message string, pendingGeneration int64) error {
+	if icp.Status == nil {
+		icp.Status = &v1alpha2.InstallStatus{}
+	}
+	if icp.Status.Message == message && icp.Status.PendingGeneration == pendingGeneration {
+		return nil
+	}
+	icp.Status.Message = message
+	icp.Status.PendingGeneration = pendingGeneration
+	return r.client.Status().Update(context.TODO(), icp)
+}
+
+func pendingChangesObjectKey(icp *v1alpha2.IstioControlPlane) client.ObjectKey {
+	return client.ObjectKey{Namespace: icp.GetNamespace(), Name: icp.GetName() + pendingChangesSuffix}
+}
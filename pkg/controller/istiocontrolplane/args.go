@@ -15,6 +15,8 @@
 package istiocontrolplane
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +28,12 @@ type Options struct {
 	// DefaultChartPath is the relative path used added to BaseChartPath when no value is specified in
 	// IstioControlPlane.Spec.ChartPath
 	DefaultChartPath string
+	// ReadinessTimeout is how long to wait for the resources of a component to be ready before the components which
+	// depend on it are applied.
+	ReadinessTimeout time.Duration
+	// VersionsURI is the URI of the versions map used to check that upgrades are supported. The versions map built
+	// into the operator is used if it is empty.
+	VersionsURI string
 }
 
 // ControllerOptions represents the options used by the controller
@@ -33,6 +41,7 @@ var controllerOptions = &Options{
 	// XXX: update this once we add charts to the operator
 	BaseChartPath:    "/etc/istio-operator/helm",
 	DefaultChartPath: "istio",
+	ReadinessTimeout: 5 * time.Minute,
 }
 
 // AttachCobraFlags attaches a set of Cobra flags to the given Cobra command.
@@ -45,4 +54,9 @@ func AttachCobraFlags(cmd *cobra.Command) {
 			"This will be used as the base path for any IstioControlPlane instances specifying a relative ChartPath.")
 	cmd.PersistentFlags().StringVar(&controllerOptions.BaseChartPath, "default-chart-path", "",
 		"A path relative to base-chart-path containing charts to be used when no ChartPath is specified by an IstioControlPlane resource, e.g. 1.1.0/istio")
+	cmd.PersistentFlags().DurationVar(&controllerOptions.ReadinessTimeout, "readiness-timeout", controllerOptions.ReadinessTimeout,
+		"Maximum time to wait for the resources of a component to be ready before the components which depend on it are applied.")
+	cmd.PersistentFlags().StringVar(&controllerOptions.VersionsURI, "versions-uri", "",
+		"URI for operator versions to Istio versions map, used to check that upgrades are supported. "+
+			"The built-in version map is used if it is not set.")
 }
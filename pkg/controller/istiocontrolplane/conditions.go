@@ -0,0 +1,85 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istiocontrolplane
+
+import (
+	"context"
+	"time"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+)
+
+const (
+	// ConditionConflict is the type of the condition set on an IstioControlPlane which is not reconciled because it
+	// would manage the same resources as an IstioControlPlane created before it.
+	ConditionConflict = "Conflict"
+
+	// ConditionTrue is the status of a condition which applies.
+	ConditionTrue = "True"
+	// ConditionFalse is the status of a condition which no longer applies.
+	ConditionFalse = "False"
+)
+
+// findCondition returns the condition of the given type in status, or nil if there is none.
+func findCondition(status *v1alpha2.InstallStatus, conditionType string) *v1alpha2.InstallStatus_Condition {
+	for _, c := range status.GetConditions() {
+		if c.Type == conditionType {
+			return c
+		}
+	}
+	return nil
+}
+
+// setCondition sets the condition of the given type in status, which must not be nil. The last transition time is
+// only updated if the status of the condition changes. It returns false if the condition is unchanged.
+func setCondition(status *v1alpha2.InstallStatus, conditionType, conditionStatus, reason, message string) bool {
+	c := findCondition(status, conditionType)
+	if c == nil {
+		c = &v1alpha2.InstallStatus_Condition{Type: conditionType}
+		status.Conditions = append(status.Conditions, c)
+	}
+	if c.Status == conditionStatus && c.Reason == reason && c.Message == message {
+		return false
+	}
+	if c.Status != conditionStatus {
+		c.LastTransitionTime = time.Now().UTC().Format(time.RFC3339)
+	}
+	c.Status, c.Reason, c.Message = conditionStatus, reason, message
+	return true
+}
+
+// clearCondition sets the condition of the given type in status to False, if it is True. It returns false if the
+// condition is unchanged.
+func clearCondition(status *v1alpha2.InstallStatus, conditionType, reason, message string) bool {
+	if findCondition(status, conditionType).GetStatus() != ConditionTrue {
+		return false
+	}
+	return setCondition(status, conditionType, ConditionFalse, reason, message)
+}
+
+// updateCondition sets the condition of the given type in the status of icp, and the status message to message,
+// updating the status if either has changed.
+func (r *ReconcileIstioControlPlane) updateCondition(icp *v1alpha2.IstioControlPlane, conditionType, conditionStatus,
+	reason, message string) error {
+	if icp.Status == nil {
+		icp.Status = &v1alpha2.InstallStatus{}
+	}
+	changed := setCondition(icp.Status, conditionType, conditionStatus, reason, message)
+	if !changed && icp.Status.Message == message {
+		return nil
+	}
+	icp.Status.Message = message
+	return r.client.Status().Update(context.TODO(), icp)
+}
@@ -0,0 +1,243 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istiocontrolplane
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/inventory"
+	"istio.io/operator/pkg/util"
+	"istio.io/pkg/log"
+)
+
+const (
+	// conflictReason is the reason of the Conflict condition.
+	conflictReason = "ConflictingIstioControlPlane"
+	// conflictResolvedReason is the reason of the Conflict condition once the conflict is resolved.
+	conflictResolvedReason = "NoConflict"
+	// conflictRequeueInterval is how often an IstioControlPlane which is not reconciled because of a conflict is
+	// checked again, so that it is reconciled once the conflict is resolved.
+	conflictRequeueInterval = time.Minute
+	// maxConflictsReported is the number of conflicting resources named in the Conflict condition.
+	maxConflictsReported = 3
+)
+
+// claims is the resources that only one IstioControlPlane can manage: cluster scoped objects, such as CRDs, webhook
+// configurations and ClusterRoles, and namespaces.
+type claims struct {
+	// objects is the cluster scoped objects other than namespaces, as kind.group/name, so that the same object is
+	// claimed whatever the version of its kind.
+	objects map[string]bool
+	// namespaces is the namespaces of namespaced objects, and of Namespace objects.
+	namespaces map[string]bool
+}
+
+// newClaims returns the resources claimed by the objects in inv.
+func newClaims(inv inventory.Inventory) *claims {
+	c := &claims{objects: make(map[string]bool), namespaces: make(map[string]bool)}
+	for _, refs := range inv {
+		for _, r := range refs {
+			switch {
+			case r.Namespace != "":
+				c.namespaces[r.Namespace] = true
+			case r.GroupVersionKind.Group == "" && r.GroupVersionKind.Kind == "Namespace":
+				c.namespaces[r.Name] = true
+			default:
+				c.objects[r.GroupVersionKind.GroupKind().String()+"/"+r.Name] = true
+			}
+		}
+	}
+	return c
+}
+
+// overlap returns the resources claimed by both c and other, sorted.
+func (c *claims) overlap(other *claims) []string {
+	var out []string
+	for ns := range c.namespaces {
+		if other.namespaces[ns] {
+			out = append(out, "namespace "+ns)
+		}
+	}
+	for o := range c.objects {
+		if other.objects[o] {
+			out = append(out, o)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// findConflict returns a description of the conflict between icp and the first IstioControlPlane created before it
+// which manages any of the resources icp would manage, or "" if there is no such IstioControlPlane. The resources an
+// IstioControlPlane manages are taken from its recorded inventory or, if it has none, rendered from its spec.
+// IstioControlPlanes which are themselves not reconciled because of a conflict are ignored.
+func (r *ReconcileIstioControlPlane) findConflict(icp *v1alpha2.IstioControlPlane) (string, error) {
+	other, resources, err := r.findClaimant(icp, func(other *unstructured.Unstructured) bool {
+		return createdBefore(other, icp)
+	})
+	if err != nil || other == nil {
+		return "", err
+	}
+	return fmt.Sprintf("not reconciled because IstioControlPlane %s/%s, created before this one, manages the same "+
+		"resources: %s", other.GetNamespace(), other.GetName(), resources), nil
+}
+
+// findClaimant returns the first IstioControlPlane, in the order they were created, which is selected by include and
+// manages any of the resources icp would manage, along with a description of those resources. It returns nil if there
+// is no such IstioControlPlane. The resources an IstioControlPlane manages are taken from its recorded inventory or,
+// if it has none, rendered from its spec. IstioControlPlanes which are themselves not reconciled because of a
+// conflict are ignored.
+func (r *ReconcileIstioControlPlane) findClaimant(icp *v1alpha2.IstioControlPlane,
+	include func(*unstructured.Unstructured) bool) (*unstructured.Unstructured, string, error) {
+	// The IstioControlPlanes are listed as unstructured for the same reason their spec is read as unstructured.
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(util.IstioOperatorGVK.GroupVersion().WithKind(util.IstioOperatorGVK.Kind + "List"))
+	if err := r.client.List(context.TODO(), list); err != nil {
+		return nil, "", fmt.Errorf("could not list IstioControlPlanes: %s", err)
+	}
+	var candidates []*unstructured.Unstructured
+	for i := range list.Items {
+		other := &list.Items[i]
+		if include(other) && !inConflict(other) {
+			candidates = append(candidates, other)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, "", nil
+	}
+	sort.Slice(candidates, func(i, j int) bool { return createdBefore(candidates[i], candidates[j]) })
+
+	reconciler, err := r.factory.New(icp, r.client)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create reconciler: %s", err)
+	}
+	rendered, err := reconciler.RenderedInventory()
+	if err != nil {
+		return nil, "", fmt.Errorf("could not render IstioControlPlane to check for conflicts: %s", err)
+	}
+	own := newClaims(rendered)
+
+	for _, other := range candidates {
+		inv, err := r.managedInventory(other)
+		if err != nil {
+			log.Warnf("could not check IstioControlPlane %s/%s for conflicts: %s", other.GetNamespace(), other.GetName(), err)
+			continue
+		}
+		overlap := own.overlap(newClaims(inv))
+		if len(overlap) == 0 {
+			continue
+		}
+		resources := strings.Join(overlap, ", ")
+		if len(overlap) > maxConflictsReported {
+			resources = fmt.Sprintf("%s and %d other resources", strings.Join(overlap[:maxConflictsReported], ", "),
+				len(overlap)-maxConflictsReported)
+		}
+		return other, resources, nil
+	}
+	return nil, "", nil
+}
+
+// managedInventory returns the inventory recorded for the IstioControlPlane u or, if it has none, the inventory of
+// the objects rendered from its spec.
+func (r *ReconcileIstioControlPlane) managedInventory(u *unstructured.Unstructured) (inventory.Inventory, error) {
+	icp, err := icpFromUnstructured(u)
+	if err != nil {
+		return nil, err
+	}
+	reconciler, err := r.factory.New(icp, r.client)
+	if err != nil {
+		return nil, err
+	}
+	inv, err := reconciler.RecordedInventory()
+	if err != nil || inv != nil {
+		return inv, err
+	}
+	return reconciler.RenderedInventory()
+}
+
+// inConflict reports whether the IstioControlPlane u is not reconciled because of a conflict.
+func inConflict(u *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		if m, ok := c.(map[string]interface{}); ok && m["type"] == ConditionConflict {
+			return m["status"] == ConditionTrue
+		}
+	}
+	return false
+}
+
+// reportConflict updates the status of icp to report that it is not reconciled because of the given conflict.
+func (r *ReconcileIstioControlPlane) reportConflict(icp *v1alpha2.IstioControlPlane, conflict string) error {
+	return r.updateCondition(icp, ConditionConflict, ConditionTrue, conflictReason, conflict)
+}
+
+// resolveConflict sets the Conflict condition of icp to False, if it is True, once it no longer conflicts with an
+// IstioControlPlane created before it.
+func (r *ReconcileIstioControlPlane) resolveConflict(icp *v1alpha2.IstioControlPlane) error {
+	if !clearCondition(icp.GetStatus(), ConditionConflict, conflictResolvedReason,
+		"no IstioControlPlane created before this one manages the same resources") {
+		return nil
+	}
+	return r.client.Status().Update(context.TODO(), icp)
+}
+
+// createdBefore reports whether a was created before b. IstioControlPlanes created at the same time are ordered by
+// namespace and name.
+func createdBefore(a, b interface {
+	GetCreationTimestamp() metav1.Time
+	GetNamespace() string
+	GetName() string
+}) bool {
+	ta, tb := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if !ta.Equal(&tb) {
+		return ta.Before(&tb)
+	}
+	if a.GetNamespace() != b.GetNamespace() {
+		return a.GetNamespace() < b.GetNamespace()
+	}
+	return a.GetName() < b.GetName()
+}
+
+// icpFromUnstructured returns the IstioControlPlane in u.
+func icpFromUnstructured(u *unstructured.Unstructured) (*v1alpha2.IstioControlPlane, error) {
+	icp := &v1alpha2.IstioControlPlane{Spec: &v1alpha2.IstioControlPlaneSpec{}}
+	icp.SetGroupVersionKind(util.IstioOperatorGVK)
+	icp.ObjectMeta = metav1.ObjectMeta{
+		Name:              u.GetName(),
+		Namespace:         u.GetNamespace(),
+		UID:               u.GetUID(),
+		Generation:        u.GetGeneration(),
+		CreationTimestamp: u.GetCreationTimestamp(),
+		Annotations:       u.GetAnnotations(),
+		Labels:            u.GetLabels(),
+	}
+	spec, err := yaml.Marshal(u.Object["spec"])
+	if err != nil {
+		return nil, err
+	}
+	if err := util.UnmarshalWithJSONPB(string(spec), icp.Spec); err != nil {
+		return nil, err
+	}
+	return icp, nil
+}
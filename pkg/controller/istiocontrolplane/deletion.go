@@ -0,0 +1,112 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istiocontrolplane
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/helmreconciler"
+	"istio.io/pkg/log"
+)
+
+const (
+	// deletedReason is the reason of the event recorded when the resources of a deleted IstioControlPlane have been
+	// deleted or retained.
+	deletedReason = "Deleted"
+	// deleteFailedReason is the reason of the event recorded when the resources of a deleted IstioControlPlane could
+	// not all be deleted or retained.
+	deleteFailedReason = "DeleteFailed"
+	// deleteSkippedReason is the reason of the event recorded when the resources of a deleted IstioControlPlane are
+	// not deleted, because they may be managed by another IstioControlPlane.
+	deleteSkippedReason = "DeleteSkipped"
+)
+
+// deletionConflict returns why the resources of the deleted IstioControlPlane u, read as icp, must not be deleted, or
+// "" if they can be. They must not be deleted if icp was not reconciled because of a conflict, or if it has no
+// recorded inventory and another IstioControlPlane manages any of the resources it would manage, since the resources
+// would then be found by their owner labels and might belong to the other IstioControlPlane.
+func (r *ReconcileIstioControlPlane) deletionConflict(u *unstructured.Unstructured, icp *v1alpha2.IstioControlPlane,
+	reconciler *helmreconciler.HelmReconciler) (string, error) {
+	if inConflict(u) {
+		return "it was not reconciled because of a conflict", nil
+	}
+	inv, err := reconciler.RecordedInventory()
+	if err != nil || inv != nil {
+		return "", err
+	}
+	other, resources, err := r.findClaimant(icp, func(other *unstructured.Unstructured) bool {
+		return other.GetNamespace() != icp.GetNamespace() || other.GetName() != icp.GetName()
+	})
+	if err != nil || other == nil {
+		return "", err
+	}
+	return fmt.Sprintf("it has no inventory and IstioControlPlane %s/%s manages the same resources: %s",
+		other.GetNamespace(), other.GetName(), resources), nil
+}
+
+// reportSkippedDeletion records an event on u, the deleted IstioControlPlane icp, and updates its status to report
+// that its resources were not deleted for the given reason.
+func (r *ReconcileIstioControlPlane) reportSkippedDeletion(u *unstructured.Unstructured, icp *v1alpha2.IstioControlPlane,
+	reason string) {
+	msg := "resources not deleted because " + reason
+	if r.recorder != nil {
+		r.recorder.Event(u, corev1.EventTypeWarning, deleteSkippedReason, msg)
+	}
+	r.updateDeletionStatus(icp, msg, nil)
+}
+
+// reportDeletion records an event on u, the deleted IstioControlPlane icp, with the outcome of deleting its resources,
+// and records the retained resources in its status.
+func (r *ReconcileIstioControlPlane) reportDeletion(u *unstructured.Unstructured, icp *v1alpha2.IstioControlPlane,
+	retained []string, deleteErr error) {
+	msg := deletionMessage(icp.GetSpec().GetDeletionPolicy(), retained)
+	if r.recorder != nil {
+		if deleteErr != nil {
+			r.recorder.Eventf(u, corev1.EventTypeWarning, deleteFailedReason, "%s, with errors: %s", msg, deleteErr)
+		} else {
+			r.recorder.Event(u, corev1.EventTypeNormal, deletedReason, msg)
+		}
+	}
+	r.updateDeletionStatus(icp, msg, retained)
+}
+
+// updateDeletionStatus sets the message and the retained resources in the status of the deleted IstioControlPlane
+// icp. Failures are logged, since they must not keep the finalizer from being removed.
+func (r *ReconcileIstioControlPlane) updateDeletionStatus(icp *v1alpha2.IstioControlPlane, msg string, retained []string) {
+	if icp.Status == nil {
+		icp.Status = &v1alpha2.InstallStatus{}
+	}
+	icp.Status.Retained = retained
+	icp.Status.Message = msg
+	if err := r.client.Status().Update(context.TODO(), icp); err != nil {
+		log.Warnf("could not update the status of deleted IstioControlPlane %s/%s: %s", icp.Namespace, icp.Name, err)
+	}
+}
+
+// deletionMessage describes the outcome of deleting the resources of an IstioControlPlane with the given deletion
+// policy, which retained the resources described by retained.
+func deletionMessage(policy v1alpha2.DeletionPolicy, retained []string) string {
+	if len(retained) == 0 {
+		return fmt.Sprintf("resources deleted with deletion policy %s", policy)
+	}
+	return fmt.Sprintf("resources deleted with deletion policy %s, %d retained: %s", policy, len(retained),
+		strings.Join(retained, ", "))
+}
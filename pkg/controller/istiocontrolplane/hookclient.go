@@ -0,0 +1,93 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istiocontrolplane
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/operator/pkg/manifest"
+)
+
+// hookClient is a manifest.ExecClient which runs the upgrade hooks with the client of the controller, rather than the
+// kube config the CLI uses.
+type hookClient struct {
+	client client.Client
+}
+
+var _ manifest.ExecClient = &hookClient{}
+
+// GetIstioVersions implements the ExecClient interface.
+func (c *hookClient) GetIstioVersions(namespace string) ([]manifest.ComponentVersion, error) {
+	pods, err := c.GetPods(namespace, map[string]string{
+		"labelSelector": "istio",
+		"fieldSelector": "status.phase=Running",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve Istio pods, error: %v", err)
+	}
+	return manifest.IstioVersions(namespace, pods)
+}
+
+// GetPods implements the ExecClient interface. The labelSelector and fieldSelector params are supported.
+func (c *hookClient) GetPods(namespace string, params map[string]string) (*corev1.PodList, error) {
+	opts := &client.ListOptions{Namespace: namespace}
+	for k, v := range params {
+		var err error
+		switch k {
+		case "labelSelector":
+			opts.LabelSelector, err = labels.Parse(v)
+		case "fieldSelector":
+			opts.FieldSelector, err = fields.ParseSelector(v)
+		default:
+			err = fmt.Errorf("unsupported parameter %s", k)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve Pods: %v", err)
+		}
+	}
+	list := &corev1.PodList{}
+	if err := c.client.List(context.TODO(), list, opts); err != nil {
+		return nil, fmt.Errorf("unable to retrieve Pods: %v", err)
+	}
+	return list, nil
+}
+
+// PodsForSelector implements the ExecClient interface.
+func (c *hookClient) PodsForSelector(namespace, labelSelector string) (*corev1.PodList, error) {
+	pods, err := c.GetPods(namespace, map[string]string{"labelSelector": labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve pods, error: %v", err)
+	}
+	return pods, nil
+}
+
+// ConfigMapForSelector implements the ExecClient interface.
+func (c *hookClient) ConfigMapForSelector(namespace, labelSelector string) (*corev1.ConfigMapList, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving configmap: %v", err)
+	}
+	list := &corev1.ConfigMapList{}
+	if err := c.client.List(context.TODO(), list, &client.ListOptions{Namespace: namespace, LabelSelector: selector}); err != nil {
+		return nil, fmt.Errorf("failed retrieving configmap: %v", err)
+	}
+	return list, nil
+}
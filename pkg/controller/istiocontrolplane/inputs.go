@@ -16,46 +16,17 @@ package istiocontrolplane
 
 import (
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/dependency"
 	"istio.io/operator/pkg/helmreconciler"
 	"istio.io/operator/pkg/name"
 )
 
-var (
-	componentDependencies = helmreconciler.ComponentNameToListMap{
-		name.IstioBaseComponentName: {
-			name.PilotComponentName,
-			name.PolicyComponentName,
-			name.TelemetryComponentName,
-			name.GalleyComponentName,
-			name.CitadelComponentName,
-			name.NodeAgentComponentName,
-			name.CertManagerComponentName,
-			name.SidecarInjectorComponentName,
-			name.IngressComponentName,
-			name.EgressComponentName,
-			name.PrometheusComponentName,
-			name.PrometheusOperatorComponentName,
-			name.GrafanaComponentName,
-			name.KialiComponentName,
-			name.CNIComponentName,
-			name.TracingComponentName,
-		},
-	}
-
-	installTree      = make(helmreconciler.ComponentTree)
-	dependencyWaitCh = make(helmreconciler.DependencyWaitCh)
+const (
+	// ForceReconcileKey is the annotation which makes the controller apply every component of an IstioControlPlane
+	// when set to "true", including components which are unchanged since they were last applied.
+	ForceReconcileKey = MetadataNamespace + "/force-reconcile"
 )
 
-func init() {
-	buildInstallTree()
-	for _, parent := range componentDependencies {
-		for _, child := range parent {
-			dependencyWaitCh[child] = make(chan struct{}, 1)
-		}
-	}
-
-}
-
 // IstioRenderingInput is a RenderingInput specific to an v1alpha2 IstioControlPlane instance.
 type IstioRenderingInput struct {
 	instance *v1alpha2.IstioControlPlane
@@ -80,25 +51,17 @@ func (i *IstioRenderingInput) GetTargetNamespace() string {
 	return i.instance.Spec.DefaultNamespace
 }
 
-// GetProcessingOrder returns the order in which the rendered charts should be processed.
-func (i *IstioRenderingInput) GetProcessingOrder(m helmreconciler.ChartManifestsMap) (helmreconciler.ComponentNameToListMap, helmreconciler.DependencyWaitCh) {
-	componentNameList := make([]name.ComponentName, 0)
-	dependencyWaitCh := make(helmreconciler.DependencyWaitCh)
-	for c := range m {
-		cn := name.ComponentName(c)
-		if cn == name.IstioBaseComponentName {
-			continue
-		}
-		componentNameList = append(componentNameList, cn)
-		dependencyWaitCh[cn] = make(chan struct{}, 1)
-	}
-	componentDependencies := helmreconciler.ComponentNameToListMap{
-		name.IstioBaseComponentName: componentNameList,
-	}
-	return componentDependencies, dependencyWaitCh
+// GetForceReconcile returns true if the ForceReconcileKey annotation of the IstioControlPlane is "true".
+func (i *IstioRenderingInput) GetForceReconcile() bool {
+	return i.instance.GetAnnotations()[ForceReconcileKey] == "true"
 }
 
-func buildInstallTree() {
-	// Starting with root, recursively insert each first level child into each node.
-	helmreconciler.InsertChildrenRecursive(name.IstioBaseComponentName, installTree, componentDependencies)
+// GetProcessingOrder returns the dependency graph of the rendered charts, using the componentDependencies of the
+// IstioControlPlane to override the default parents.
+func (i *IstioRenderingInput) GetProcessingOrder(m helmreconciler.ChartManifestsMap) (*dependency.Graph, error) {
+	components := make([]name.ComponentName, 0, len(m))
+	for c := range m {
+		components = append(components, name.ComponentName(c))
+	}
+	return dependency.New(components, i.instance.Spec.GetComponentDependencies())
 }
@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -38,6 +39,8 @@ const (
 	finalizer = "istio-finalizer.install.istio.io"
 	// finalizerMaxRetries defines the maximum number of attempts to add finalizers.
 	finalizerMaxRetries = 10
+	// controllerName is the name of the controller, which is also the source of the events it records.
+	controllerName = "istiocontrolplane-controller"
 )
 
 /**
@@ -53,15 +56,24 @@ func Add(mgr manager.Manager) error {
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
-	factory := &helmreconciler.Factory{CustomizerFactory: &IstioRenderingCustomizerFactory{}}
-	return &ReconcileIstioControlPlane{client: mgr.GetClient(), scheme: mgr.GetScheme(), factory: factory}
+	factory := &helmreconciler.Factory{
+		CustomizerFactory: &IstioRenderingCustomizerFactory{},
+		RESTMapper:        mgr.GetRESTMapper(),
+		ReadinessTimeout:  controllerOptions.ReadinessTimeout,
+	}
+	return &ReconcileIstioControlPlane{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		factory:  factory,
+		recorder: mgr.GetEventRecorderFor(controllerName),
+	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	log.Info("Adding controller for IstioControlPlane")
 	// Create a new controller
-	c, err := controller.New("istiocontrolplane-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
 	if err != nil {
 		return err
 	}
@@ -85,6 +97,8 @@ type ReconcileIstioControlPlane struct {
 	client  client.Client
 	scheme  *runtime.Scheme
 	factory *helmreconciler.Factory
+	// recorder records the events of the IstioControlPlanes, such as the resources retained when one is deleted.
+	recorder record.EventRecorder
 }
 
 // Reconcile reads that state of the cluster for a IstioControlPlane object and makes changes based on the state read
@@ -131,6 +145,13 @@ func (r *ReconcileIstioControlPlane) Reconcile(request reconcile.Request) (recon
 	}
 	log.Infof("Got IstioControlPlaneSpec: \n\n%s\n", string(os))
 
+	if isPaused(u) {
+		// Nothing is rendered, applied or deleted while paused, including the resources of a deleted
+		// IstioControlPlane, which are removed once it is resumed.
+		log.Infof("Reconciliation of IstioControlPlane %s is paused", request.NamespacedName)
+		return reconcile.Result{}, r.reportPaused(icp)
+	}
+
 	if deleted {
 		if finalizerIndex < 0 {
 			log.Info("IstioControlPlane deleted")
@@ -138,11 +159,28 @@ func (r *ReconcileIstioControlPlane) Reconcile(request reconcile.Request) (recon
 		}
 		log.Info("Deleting IstioControlPlane")
 
+		// The outcome is reported before the finalizer is removed, while the IstioControlPlane still exists.
 		reconciler, err := r.factory.New(icp, r.client)
-		if err == nil {
-			err = reconciler.Delete()
-		} else {
+		if err != nil {
 			log.Errorf("failed to create reconciler: %s", err)
+			r.reportDeletion(u, icp, nil, err)
+		} else if reason, checkErr := r.deletionConflict(u, icp, reconciler); checkErr != nil {
+			// The finalizer is kept, so that the deletion is tried again.
+			return reconcile.Result{}, checkErr
+		} else if reason != "" {
+			log.Warnf("Not deleting the resources of IstioControlPlane %s because %s", request.NamespacedName, reason)
+			r.reportSkippedDeletion(u, icp, reason)
+		} else {
+			var retained []string
+			retained, err = reconciler.Delete()
+			r.reportDeletion(u, icp, retained, err)
+		}
+		// u is read again, since reporting updated its status.
+		if getErr := r.client.Get(context.TODO(), request.NamespacedName, u); getErr == nil {
+			finalizers = u.GetFinalizers()
+			if finalizerIndex = indexOf(finalizers, finalizer); finalizerIndex < 0 {
+				return reconcile.Result{}, err
+			}
 		}
 		// TODO: for now, nuke the resources, regardless of errors
 		finalizers = append(finalizers[:finalizerIndex], finalizers[finalizerIndex+1:]...)
@@ -176,15 +214,60 @@ func (r *ReconcileIstioControlPlane) Reconcile(request reconcile.Request) (recon
 		}
 	}
 
+	// An IstioControlPlane which would manage the same resources as an older one is not reconciled, so that they do
+	// not fight over the resources, or prune each other's.
+	conflict, err := r.findConflict(icp)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if conflict != "" {
+		log.Warnf("IstioControlPlane %s is %s", request.NamespacedName, conflict)
+		return reconcile.Result{RequeueAfter: conflictRequeueInterval}, r.reportConflict(icp, conflict)
+	}
+	if err := r.resolveConflict(icp); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !isApproved(u) {
+		log.Infof("Generation %d of IstioControlPlane %s is waiting for approval", u.GetGeneration(), request.NamespacedName)
+		return reconcile.Result{}, r.requestApproval(icp)
+	}
+
 	log.Info("Updating IstioControlPlane")
 	reconciler, err := r.factory.New(icp, r.client)
-	if err == nil {
-		err = reconciler.Reconcile()
-		if err != nil {
-			log.Errorf("reconciling err: %s", err)
-		}
-	} else {
+	if err != nil {
 		log.Errorf("failed to create reconciler: %s", err)
+		return reconcile.Result{}, err
+	}
+
+	// A change of version or install package is an upgrade, which is checked against the versions map and runs the
+	// upgrade hooks around applying the components.
+	up, err := detectUpgrade(icp, reconciler, isForceUpgrade(u))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if up != nil {
+		blocked, err := r.beginUpgrade(icp, up)
+		if err != nil || blocked == unsupportedUpgradeReason {
+			return reconcile.Result{}, err
+		}
+		if blocked != "" {
+			return reconcile.Result{RequeueAfter: upgradeRequeueInterval}, nil
+		}
+	}
+
+	err = reconciler.Reconcile()
+	if err != nil {
+		log.Errorf("reconciling err: %s", err)
+	}
+	if endErr := r.endReconcile(icp, reconciler, up, err); endErr != nil {
+		log.Errorf("failed to record the result of reconciling: %s", endErr)
+		if err == nil {
+			err = endErr
+		}
+	}
+	if err == nil && u.GetAnnotations()[ApprovalModeKey] == ApprovalModeManual {
+		err = r.deletePendingChanges(icp)
 	}
 
 	return reconcile.Result{}, err
@@ -17,19 +17,28 @@ package istiocontrolplane
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
 	"istio.io/operator/pkg/helmreconciler"
+	"istio.io/operator/pkg/util"
 )
 
 var (
@@ -207,7 +216,7 @@ func testSwitchProfile(t *testing.T, c testCase) {
 
 	s := scheme.Scheme
 	s.AddKnownTypes(v1alpha2.SchemeGroupVersion, icp)
-	cl := fake.NewFakeClientWithScheme(s, objs...)
+	cl := newFakeClient(s, objs...)
 	factory := &helmreconciler.Factory{CustomizerFactory: &IstioRenderingCustomizerFactory{}}
 	r := &ReconcileIstioControlPlane{client: cl, scheme: s, factory: factory}
 
@@ -298,3 +307,858 @@ func checkICPStatus(cl client.Client, key client.ObjectKey, profile string) (boo
 	}
 	return true, nil
 }
+
+func TestICPController_PauseAndApproval(t *testing.T) {
+	name := "example-istiocontrolplane"
+	namespace := "istio-system"
+	icp := &v1alpha2.IstioControlPlane{
+		Kind:       "IstioControlPlane",
+		ApiVersion: "install.istio.io/v1alpha2",
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  namespace,
+			Generation: 1,
+			Annotations: map[string]string{
+				PausedKey:       "true",
+				ApprovalModeKey: ApprovalModeManual,
+			},
+		},
+		Spec: &v1alpha2.IstioControlPlaneSpec{
+			Profile: "minimal",
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1alpha2.SchemeGroupVersion, icp)
+	cl := newFakeClient(s, icp)
+	factory := &helmreconciler.Factory{CustomizerFactory: &IstioRenderingCustomizerFactory{}}
+	r := &ReconcileIstioControlPlane{client: cl, scheme: s, factory: factory}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+
+	reconcileAndGet := func(desc string) *v1alpha2.IstioControlPlane {
+		t.Helper()
+		if _, err := r.Reconcile(req); err != nil {
+			t.Fatalf("%s: reconcile: (%v)", desc, err)
+		}
+		out := &v1alpha2.IstioControlPlane{}
+		if err := cl.Get(context.TODO(), req.NamespacedName, out); err != nil {
+			t.Fatalf("%s: get: (%v)", desc, err)
+		}
+		return out
+	}
+	setAnnotation := func(k, v string) {
+		t.Helper()
+		instance := &v1alpha2.IstioControlPlane{}
+		if err := cl.Get(context.TODO(), req.NamespacedName, instance); err != nil {
+			t.Fatal(err)
+		}
+		a := instance.GetAnnotations()
+		if v == "" {
+			delete(a, k)
+		} else {
+			a[k] = v
+		}
+		instance.SetAnnotations(a)
+		if err := cl.Update(context.TODO(), instance); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pendingChanges := func() (*corev1.ConfigMap, error) {
+		cm := &corev1.ConfigMap{}
+		err := cl.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name + pendingChangesSuffix}, cm)
+		return cm, err
+	}
+
+	// Paused: nothing is rendered, the status reports the pause.
+	got := reconcileAndGet("paused")
+	if !strings.Contains(got.GetStatus().GetMessage(), "paused") || len(got.GetStatus().GetStatus()) != 0 {
+		t.Fatalf("paused: got status %v, want paused message and no component status", got.GetStatus())
+	}
+	if _, err := pendingChanges(); !errors.IsNotFound(err) {
+		t.Fatalf("paused: got pending changes err %v, want not found", err)
+	}
+
+	// Resumed in manual approval mode: the changes are rendered and wait for approval.
+	setAnnotation(PausedKey, "")
+	got = reconcileAndGet("waiting for approval")
+	if got.GetStatus().GetPendingGeneration() != 1 || len(got.GetStatus().GetStatus()) != 0 {
+		t.Fatalf("waiting for approval: got status %v, want pending generation 1 and no component status", got.GetStatus())
+	}
+	cm, err := pendingChanges()
+	if err != nil {
+		t.Fatalf("waiting for approval: get pending changes: %v", err)
+	}
+	if cm.Data[pendingGenerationKey] != "1" || !strings.Contains(cm.Data[pendingChangesKey], "create Deployment istio-system/istio-pilot") {
+		t.Fatalf("waiting for approval: got pending changes %v, want generation 1 creating istio-pilot", cm.Data)
+	}
+
+	// Approved: the generation is applied and the pending changes are removed.
+	setAnnotation(ApprovedGenerationKey, "1")
+	got = reconcileAndGet("approved")
+	if got.GetStatus().GetMessage() != "" || got.GetStatus().GetPendingGeneration() != 0 {
+		t.Errorf("approved: got status %v, want no message or pending generation", got.GetStatus())
+	}
+	if succeed, err := checkICPStatus(cl, req.NamespacedName, "minimal"); !succeed || err != nil {
+		t.Errorf("approved: failed to get expected IstioControlPlane status: (%v)", err)
+	}
+	for _, rt := range []string{"apps/v1/Deployment", "v1/ServiceAccount"} {
+		found := false
+		for _, got := range got.GetStatus().GetResourceTypes() {
+			found = found || got == rt
+		}
+		if !found {
+			t.Errorf("approved: got recorded resource types %v, want %s", got.GetStatus().GetResourceTypes(), rt)
+		}
+	}
+	if _, err := pendingChanges(); !errors.IsNotFound(err) {
+		t.Errorf("approved: got pending changes err %v, want not found", err)
+	}
+}
+
+func TestICPController_Inventory(t *testing.T) {
+	name := "example-istiocontrolplane"
+	namespace := "istio-system"
+	icp := &v1alpha2.IstioControlPlane{
+		Kind:       "IstioControlPlane",
+		ApiVersion: "install.istio.io/v1alpha2",
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  namespace,
+			Generation: 1,
+		},
+		Spec: &v1alpha2.IstioControlPlaneSpec{
+			Profile: "minimal",
+		},
+	}
+	// stale was applied for the instance before, but its labels have since been removed.
+	stale := &corev1.ServiceAccount{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+		ObjectMeta: metav1.ObjectMeta{Name: "stale", Namespace: namespace},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1alpha2.SchemeGroupVersion, icp)
+	cl := newFakeClient(s, icp, stale)
+	factory := &helmreconciler.Factory{CustomizerFactory: &IstioRenderingCustomizerFactory{}}
+	r := &ReconcileIstioControlPlane{client: cl, scheme: s, factory: factory}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+	inventoryKey := client.ObjectKey{Namespace: namespace, Name: name + inventorySuffix}
+
+	if _, err := r.Reconcile(req); err != nil {
+		t.Fatalf("reconcile: (%v)", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := cl.Get(context.TODO(), inventoryKey, cm); err != nil {
+		t.Fatalf("get inventory: %v", err)
+	}
+	if !strings.Contains(cm.Data["Pilot"], "apps/v1/Deployment istio-system/istio-pilot\n") {
+		t.Fatalf("got Pilot inventory\n%s\nwant istio-pilot Deployment", cm.Data["Pilot"])
+	}
+
+	// The stale object is pruned because it is in the inventory, even though it has no owner labels.
+	cm.Data["Pilot"] += "v1/ServiceAccount istio-system/stale\n"
+	if err := cl.Update(context.TODO(), cm); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(req); err != nil {
+		t.Fatalf("reconcile: (%v)", err)
+	}
+	if err := cl.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: "stale"}, &corev1.ServiceAccount{}); !errors.IsNotFound(err) {
+		t.Errorf("got stale ServiceAccount err %v, want not found", err)
+	}
+	if err := cl.Get(context.TODO(), inventoryKey, cm); err != nil {
+		t.Fatalf("get inventory: %v", err)
+	}
+	if strings.Contains(cm.Data["Pilot"], "stale") {
+		t.Errorf("got Pilot inventory\n%s\nwant stale ServiceAccount removed", cm.Data["Pilot"])
+	}
+}
+
+func TestICPController_SkipUnchanged(t *testing.T) {
+	name := "example-istiocontrolplane"
+	namespace := "istio-system"
+	icp := &v1alpha2.IstioControlPlane{
+		Kind:       "IstioControlPlane",
+		ApiVersion: "install.istio.io/v1alpha2",
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  namespace,
+			Generation: 1,
+		},
+		Spec: &v1alpha2.IstioControlPlaneSpec{
+			Profile: "minimal",
+		},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1alpha2.SchemeGroupVersion, icp)
+	cl := newFakeClient(s, icp)
+	factory := &helmreconciler.Factory{CustomizerFactory: &IstioRenderingCustomizerFactory{}}
+	r := &ReconcileIstioControlPlane{client: cl, scheme: s, factory: factory}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+	pilotKey := client.ObjectKey{Namespace: namespace, Name: "istio-pilot"}
+
+	if _, err := r.Reconcile(req); err != nil {
+		t.Fatalf("reconcile: (%v)", err)
+	}
+	if err := cl.Get(context.TODO(), req.NamespacedName, icp); err != nil {
+		t.Fatal(err)
+	}
+	if s := icp.GetStatus().GetStatus()["Pilot"]; s.GetManifestHash() == "" || s.GetObjectsHash() == "" {
+		t.Fatalf("got Pilot status %v, want manifest and objects hashes", s)
+	}
+
+	// A new generation with the same spec renders the same manifests, so the Pilot objects are not applied again
+	// and keep the generation they were last applied at.
+	tests := []struct {
+		desc           string
+		mutate         func(icp *v1alpha2.IstioControlPlane)
+		wantGeneration string
+	}{
+		{
+			desc:           "unchanged",
+			mutate:         func(icp *v1alpha2.IstioControlPlane) {},
+			wantGeneration: "1",
+		},
+		{
+			desc: "object deleted",
+			mutate: func(icp *v1alpha2.IstioControlPlane) {
+				svc := &corev1.Service{}
+				if err := cl.Get(context.TODO(), pilotKey, svc); err != nil {
+					t.Fatal(err)
+				}
+				if err := cl.Delete(context.TODO(), svc); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantGeneration: "3",
+		},
+		{
+			desc: "forced",
+			mutate: func(icp *v1alpha2.IstioControlPlane) {
+				icp.Annotations = map[string]string{ForceReconcileKey: "true"}
+			},
+			wantGeneration: "4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := cl.Get(context.TODO(), req.NamespacedName, icp); err != nil {
+				t.Fatal(err)
+			}
+			icp.Generation++
+			tt.mutate(icp)
+			if err := cl.Update(context.TODO(), icp); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := r.Reconcile(req); err != nil {
+				t.Fatalf("reconcile: (%v)", err)
+			}
+			deployment := &appsv1.Deployment{}
+			if err := cl.Get(context.TODO(), pilotKey, deployment); err != nil {
+				t.Fatal(err)
+			}
+			if got := deployment.Annotations[OwnerGenerationKey]; got != tt.wantGeneration {
+				t.Errorf("%s: got Pilot Deployment generation %s, want %s", tt.desc, got, tt.wantGeneration)
+			}
+		})
+	}
+}
+
+func TestICPController_Dependencies(t *testing.T) {
+	name := "example-istiocontrolplane"
+	namespace := "istio-system"
+	tests := []struct {
+		desc         string
+		dependencies map[string]string
+		// readinessTimeout of 0 does not wait for readiness, otherwise the objects of the fake client are never
+		// ready, so the components with children fail.
+		readinessTimeout time.Duration
+		wantStatus       map[string]v1alpha2.InstallStatus_Status
+		wantErr          string
+	}{
+		{
+			desc: "not waiting",
+			wantStatus: map[string]v1alpha2.InstallStatus_Status{
+				"Base":  v1alpha2.InstallStatus_HEALTHY,
+				"Pilot": v1alpha2.InstallStatus_HEALTHY,
+			},
+		},
+		{
+			desc:             "parent not ready",
+			readinessTimeout: time.Millisecond,
+			wantStatus: map[string]v1alpha2.InstallStatus_Status{
+				"Base":  v1alpha2.InstallStatus_ERROR,
+				"Pilot": v1alpha2.InstallStatus_BLOCKED,
+			},
+		},
+		{
+			desc:         "disabled parent",
+			dependencies: map[string]string{"Pilot": "Galley"},
+			wantStatus: map[string]v1alpha2.InstallStatus_Status{
+				"Base":  v1alpha2.InstallStatus_HEALTHY,
+				"Pilot": v1alpha2.InstallStatus_HEALTHY,
+			},
+		},
+		{
+			desc:         "cycle",
+			dependencies: map[string]string{"Pilot": "Galley", "Galley": "Pilot"},
+			wantErr:      "depends on itself",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			icp := &v1alpha2.IstioControlPlane{
+				Kind:       "IstioControlPlane",
+				ApiVersion: "install.istio.io/v1alpha2",
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: &v1alpha2.IstioControlPlaneSpec{
+					Profile:               "minimal",
+					ComponentDependencies: tt.dependencies,
+				},
+			}
+			s := scheme.Scheme
+			s.AddKnownTypes(v1alpha2.SchemeGroupVersion, icp)
+			cl := newFakeClient(s, icp)
+			factory := &helmreconciler.Factory{
+				CustomizerFactory: &IstioRenderingCustomizerFactory{},
+				ReadinessTimeout:  tt.readinessTimeout,
+			}
+			r := &ReconcileIstioControlPlane{client: cl, scheme: s, factory: factory}
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+
+			_, err := r.Reconcile(req)
+			if gotErr := fmt.Sprint(err); tt.wantErr != "" {
+				if !strings.Contains(gotErr, tt.wantErr) {
+					t.Fatalf("%s: got error %v, want error containing %q", tt.desc, err, tt.wantErr)
+				}
+				return
+			}
+			if err := cl.Get(context.TODO(), req.NamespacedName, icp); err != nil {
+				t.Fatal(err)
+			}
+			got := make(map[string]v1alpha2.InstallStatus_Status)
+			for c, s := range icp.GetStatus().GetStatus() {
+				got[c] = s.GetStatus()
+			}
+			if !reflect.DeepEqual(got, tt.wantStatus) {
+				t.Errorf("%s: got status %v, want %v", tt.desc, got, tt.wantStatus)
+			}
+			if pilot := icp.GetStatus().GetStatus()["Pilot"]; pilot.GetStatus() == v1alpha2.InstallStatus_BLOCKED &&
+				!strings.Contains(pilot.GetError(), "Base") {
+				t.Errorf("%s: got blocked Pilot error %q, want it to name Base", tt.desc, pilot.GetError())
+			}
+		})
+	}
+}
+
+func TestICPController_Conflict(t *testing.T) {
+	namespace := "istio-system"
+	created := time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)
+	newICP := func(name string) *v1alpha2.IstioControlPlane {
+		return &v1alpha2.IstioControlPlane{
+			Kind:       "IstioControlPlane",
+			ApiVersion: "install.istio.io/v1alpha2",
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Namespace:  namespace,
+				Generation: 1,
+			},
+			Spec: &v1alpha2.IstioControlPlaneSpec{
+				Profile: "minimal",
+			},
+		}
+	}
+	older, newer := newICP("older"), newICP("newer")
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1alpha2.SchemeGroupVersion, older)
+	cl := newFakeClient(s, older, newer)
+	// The creation timestamps are set as unstructured, since they are lost when the fake client copies the typed
+	// objects.
+	for name, ts := range map[string]time.Time{"older": created, "newer": created.Add(time.Hour)} {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(util.IstioOperatorGVK)
+		if err := cl.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, u); err != nil {
+			t.Fatal(err)
+		}
+		u.SetCreationTimestamp(metav1.NewTime(ts))
+		if err := cl.Update(context.TODO(), u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	factory := &helmreconciler.Factory{CustomizerFactory: &IstioRenderingCustomizerFactory{}}
+	r := &ReconcileIstioControlPlane{client: cl, scheme: s, factory: factory}
+	newerKey := types.NamespacedName{Name: "newer", Namespace: namespace}
+
+	// The newer IstioControlPlane is refused, even though the older one has not been reconciled yet.
+	res, err := r.Reconcile(reconcile.Request{NamespacedName: newerKey})
+	if err != nil {
+		t.Fatalf("reconcile: (%v)", err)
+	}
+	if res.RequeueAfter != conflictRequeueInterval {
+		t.Errorf("got RequeueAfter %s, want %s", res.RequeueAfter, conflictRequeueInterval)
+	}
+	got := &v1alpha2.IstioControlPlane{}
+	if err := cl.Get(context.TODO(), newerKey, got); err != nil {
+		t.Fatal(err)
+	}
+	c := findCondition(got.Status, ConditionConflict)
+	if c.GetStatus() != ConditionTrue || c.GetReason() != conflictReason || !strings.Contains(c.GetMessage(), "istio-system/older") {
+		t.Fatalf("got Conflict condition %v, want True naming istio-system/older", c)
+	}
+	if len(got.Status.GetStatus()) != 0 {
+		t.Errorf("got component status %v, want none", got.Status.GetStatus())
+	}
+	if err := cl.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: "istio-pilot"}, &appsv1.Deployment{}); !errors.IsNotFound(err) {
+		t.Errorf("got istio-pilot Deployment err %v, want not found", err)
+	}
+
+	// The older IstioControlPlane is reconciled.
+	res, err = r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: "older", Namespace: namespace}})
+	if err != nil {
+		t.Fatalf("reconcile: (%v)", err)
+	}
+	if res.RequeueAfter != 0 {
+		t.Errorf("got RequeueAfter %s, want 0", res.RequeueAfter)
+	}
+	if succeed, err := checkICPStatus(cl, client.ObjectKey{Namespace: namespace, Name: "older"}, "minimal"); !succeed || err != nil {
+		t.Fatalf("failed to get expected IstioControlPlane status: (%v)", err)
+	}
+
+	// Once the older IstioControlPlane is gone, the newer one is reconciled and its Conflict condition is cleared.
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(util.IstioOperatorGVK)
+	u.SetNamespace(namespace)
+	u.SetName("older")
+	if err := cl.Delete(context.TODO(), u); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Reconcile(reconcile.Request{NamespacedName: newerKey}); err != nil {
+		t.Fatalf("reconcile: (%v)", err)
+	}
+	got = &v1alpha2.IstioControlPlane{}
+	if err := cl.Get(context.TODO(), newerKey, got); err != nil {
+		t.Fatal(err)
+	}
+	c = findCondition(got.Status, ConditionConflict)
+	if c.GetStatus() != ConditionFalse || c.GetReason() != conflictResolvedReason || c.GetLastTransitionTime() == "" {
+		t.Errorf("got Conflict condition %v, want False with reason %s", c, conflictResolvedReason)
+	}
+	if len(got.Status.GetStatus()) == 0 {
+		t.Error("got no component status, want the newer IstioControlPlane reconciled")
+	}
+}
+
+func TestICPController_DeletionPolicy(t *testing.T) {
+	name := "example-istiocontrolplane"
+	namespace := "istio-system"
+	pilotKey := client.ObjectKey{Namespace: namespace, Name: "istio-pilot"}
+	tests := []struct {
+		desc           string
+		deletionPolicy v1alpha2.DeletionPolicy
+		retainKinds    []string
+		// wantRetained are the kinds of the istio-pilot resources which are retained.
+		wantRetained map[string]bool
+		// wantNamespace is whether the istio-system Namespace is retained.
+		wantNamespace bool
+	}{
+		{
+			desc:         "Delete",
+			wantRetained: map[string]bool{},
+		},
+		{
+			desc:           "RetainKinds",
+			deletionPolicy: v1alpha2.DeletionPolicy_RetainCRDs,
+			retainKinds:    []string{"Service"},
+			wantRetained:   map[string]bool{"Service": true},
+			// The Namespace is retained along with the Service in it.
+			wantNamespace: true,
+		},
+		{
+			desc:           "Retain",
+			deletionPolicy: v1alpha2.DeletionPolicy_Retain,
+			wantRetained:   map[string]bool{"Service": true, "Deployment": true},
+			wantNamespace:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			icp := &v1alpha2.IstioControlPlane{
+				Kind:       "IstioControlPlane",
+				ApiVersion: "install.istio.io/v1alpha2",
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       name,
+					Namespace:  namespace,
+					Generation: 1,
+				},
+				Spec: &v1alpha2.IstioControlPlaneSpec{
+					Profile:        "minimal",
+					DeletionPolicy: tt.deletionPolicy,
+					RetainKinds:    tt.retainKinds,
+				},
+			}
+			s := scheme.Scheme
+			s.AddKnownTypes(v1alpha2.SchemeGroupVersion, icp)
+			cl := newFakeClient(s, icp)
+			factory := &helmreconciler.Factory{CustomizerFactory: &IstioRenderingCustomizerFactory{}}
+			recorder := record.NewFakeRecorder(10)
+			r := &ReconcileIstioControlPlane{client: cl, scheme: s, factory: factory, recorder: recorder}
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+
+			if _, err := r.Reconcile(req); err != nil {
+				t.Fatalf("reconcile: (%v)", err)
+			}
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(util.IstioOperatorGVK)
+			if err := cl.Get(context.TODO(), req.NamespacedName, u); err != nil {
+				t.Fatal(err)
+			}
+			// The finalizer is set again, since the fake client drops it when the status is updated.
+			now := metav1.Now()
+			u.SetDeletionTimestamp(&now)
+			u.SetFinalizers([]string{finalizer})
+			if err := cl.Update(context.TODO(), u); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := r.Reconcile(req); err != nil {
+				t.Fatalf("delete: (%v)", err)
+			}
+
+			for kind, obj := range map[string]runtime.Object{"Service": &corev1.Service{}, "Deployment": &appsv1.Deployment{}} {
+				err := cl.Get(context.TODO(), pilotKey, obj)
+				if !tt.wantRetained[kind] {
+					if !errors.IsNotFound(err) {
+						t.Errorf("got istio-pilot %s err %v, want not found", kind, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Errorf("got istio-pilot %s err %v, want retained", kind, err)
+					continue
+				}
+				labels := obj.(metav1.Object).GetLabels()
+				if _, ok := labels[OwnerNameKey]; ok {
+					t.Errorf("got istio-pilot %s labels %v, want owner labels removed", kind, labels)
+				}
+			}
+
+			err := cl.Get(context.TODO(), client.ObjectKey{Name: namespace}, &corev1.Namespace{})
+			if tt.wantNamespace && err != nil {
+				t.Errorf("got %s Namespace err %v, want retained", namespace, err)
+			} else if !tt.wantNamespace && !errors.IsNotFound(err) {
+				t.Errorf("got %s Namespace err %v, want not found", namespace, err)
+			}
+
+			got := &v1alpha2.IstioControlPlane{}
+			if err := cl.Get(context.TODO(), req.NamespacedName, got); err != nil {
+				t.Fatal(err)
+			}
+			retained := strings.Join(got.GetStatus().GetRetained(), "\n")
+			for kind := range tt.wantRetained {
+				if !strings.Contains(retained, kind+" "+pilotKey.String()) {
+					t.Errorf("got retained %v, want istio-pilot %s", got.GetStatus().GetRetained(), kind)
+				}
+			}
+			if len(tt.wantRetained) == 0 && retained != "" {
+				t.Errorf("got retained %v, want none", got.GetStatus().GetRetained())
+			}
+			select {
+			case event := <-recorder.Events:
+				if !strings.HasPrefix(event, corev1.EventTypeNormal+" "+deletedReason) {
+					t.Errorf("got event %q, want %s %s", event, corev1.EventTypeNormal, deletedReason)
+				}
+			default:
+				t.Errorf("got no event, want %s", deletedReason)
+			}
+		})
+	}
+}
+
+func TestICPController_DeleteConflict(t *testing.T) {
+	name := "example-istiocontrolplane"
+	pilotKey := client.ObjectKey{Namespace: "istio-system", Name: "istio-pilot"}
+	tests := []struct {
+		desc string
+		// reconcile is whether the newer IstioControlPlane is reconciled, and so refused, before it is deleted.
+		reconcile bool
+	}{
+		{
+			desc:      "refused",
+			reconcile: true,
+		},
+		{
+			desc: "not reconciled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// The IstioControlPlanes have the same name, in different namespaces. The one in istio-system is older,
+			// since IstioControlPlanes created at the same time are ordered by namespace.
+			newICP := func(namespace string) *v1alpha2.IstioControlPlane {
+				return &v1alpha2.IstioControlPlane{
+					Kind:       "IstioControlPlane",
+					ApiVersion: "install.istio.io/v1alpha2",
+					ObjectMeta: metav1.ObjectMeta{
+						Name:       name,
+						Namespace:  namespace,
+						Generation: 1,
+					},
+					Spec: &v1alpha2.IstioControlPlaneSpec{
+						Profile: "minimal",
+					},
+				}
+			}
+			older, newer := newICP("istio-system"), newICP("other")
+			s := scheme.Scheme
+			s.AddKnownTypes(v1alpha2.SchemeGroupVersion, older)
+			cl := newFakeClient(s, older, newer)
+			factory := &helmreconciler.Factory{CustomizerFactory: &IstioRenderingCustomizerFactory{}}
+			recorder := record.NewFakeRecorder(10)
+			r := &ReconcileIstioControlPlane{client: cl, scheme: s, factory: factory, recorder: recorder}
+			newerReq := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "other"}}
+
+			if _, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "istio-system"}}); err != nil {
+				t.Fatalf("reconcile older: (%v)", err)
+			}
+			if tt.reconcile {
+				if _, err := r.Reconcile(newerReq); err != nil {
+					t.Fatalf("reconcile newer: (%v)", err)
+				}
+			}
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(util.IstioOperatorGVK)
+			if err := cl.Get(context.TODO(), newerReq.NamespacedName, u); err != nil {
+				t.Fatal(err)
+			}
+			now := metav1.Now()
+			u.SetDeletionTimestamp(&now)
+			u.SetFinalizers([]string{finalizer})
+			if err := cl.Update(context.TODO(), u); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := r.Reconcile(newerReq); err != nil {
+				t.Fatalf("delete newer: (%v)", err)
+			}
+
+			// The owner labels of the resources include the namespace of the IstioControlPlane, so they are not
+			// selected by the labels of the newer one either.
+			deployment := &appsv1.Deployment{}
+			if err := cl.Get(context.TODO(), pilotKey, deployment); err != nil {
+				t.Errorf("got istio-pilot Deployment err %v, want it kept for the older IstioControlPlane", err)
+			} else if got := deployment.Labels[OwnerNamespaceKey]; got != "istio-system" {
+				t.Errorf("got istio-pilot Deployment %s label %q, want istio-system", OwnerNamespaceKey, got)
+			}
+			select {
+			case event := <-recorder.Events:
+				if !strings.HasPrefix(event, corev1.EventTypeWarning+" "+deleteSkippedReason) {
+					t.Errorf("got event %q, want %s %s", event, corev1.EventTypeWarning, deleteSkippedReason)
+				}
+			default:
+				t.Errorf("got no event, want %s", deleteSkippedReason)
+			}
+		})
+	}
+}
+
+func TestICPController_Upgrade(t *testing.T) {
+	name := "example-istiocontrolplane"
+	namespace := "istio-system"
+	icp := &v1alpha2.IstioControlPlane{
+		Kind:       "IstioControlPlane",
+		ApiVersion: "install.istio.io/v1alpha2",
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  namespace,
+			Generation: 1,
+		},
+		Spec: &v1alpha2.IstioControlPlaneSpec{
+			Profile: "minimal",
+		},
+	}
+	// initCRDPod makes the pre-upgrade hook checking for istio-init-crd jobs fail.
+	initCRDPod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "istio-init-crd-10-abcde", Namespace: namespace},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1alpha2.SchemeGroupVersion, icp)
+	cl := newFakeClient(s, icp)
+	factory := &helmreconciler.Factory{CustomizerFactory: &IstioRenderingCustomizerFactory{}}
+	r := &ReconcileIstioControlPlane{client: cl, scheme: s, factory: factory}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: namespace}}
+
+	// The first reconcile records the version of the profile, which is not an upgrade.
+	if _, err := r.Reconcile(req); err != nil {
+		t.Fatalf("reconcile: (%v)", err)
+	}
+	got := &v1alpha2.IstioControlPlane{}
+	if err := cl.Get(context.TODO(), req.NamespacedName, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.GetVersion() != "latest" || got.Status.GetUpgrade() != nil {
+		t.Fatalf("got version %q and upgrade %v, want latest and no upgrade", got.Status.GetVersion(), got.Status.GetUpgrade())
+	}
+
+	tests := []struct {
+		desc string
+		// from is the version the IstioControlPlane was last applied with.
+		from        string
+		to          string
+		force       bool
+		initCRDPod  bool
+		wantRequeue time.Duration
+		wantPhase   string
+		wantReason  string
+		wantMessage string
+		wantVersion string
+	}{
+		{
+			desc:        "unknown version",
+			from:        "latest",
+			to:          "1.4.0",
+			wantPhase:   UpgradePhaseBlocked,
+			wantReason:  unsupportedUpgradeReason,
+			wantMessage: "failed to parse the current version: latest",
+			wantVersion: "latest",
+		},
+		{
+			desc:        "unsupported",
+			from:        "1.2.0",
+			to:          "1.4.0",
+			wantPhase:   UpgradePhaseBlocked,
+			wantReason:  unsupportedUpgradeReason,
+			wantMessage: "upgrade is currently not supported: 1.2.0 -> 1.4.0",
+			wantVersion: "1.2.0",
+		},
+		{
+			desc:        "pre-upgrade hook fails",
+			from:        "1.3.5",
+			to:          "1.4.0",
+			initCRDPod:  true,
+			wantRequeue: upgradeRequeueInterval,
+			wantPhase:   UpgradePhaseBlocked,
+			wantReason:  preUpgradeHookFailedReason,
+			wantMessage: "istio-init-crd pods exist: istio-init-crd-10-abcde",
+			wantVersion: "1.3.5",
+		},
+		{
+			desc:        "supported",
+			from:        "1.3.5",
+			to:          "1.4.0",
+			wantPhase:   UpgradePhaseSucceeded,
+			wantVersion: "1.4.0",
+		},
+		{
+			desc:        "forced",
+			from:        "1.2.0",
+			to:          "1.4.0",
+			force:       true,
+			wantPhase:   UpgradePhaseSucceeded,
+			wantVersion: "1.4.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if err := cl.Get(context.TODO(), req.NamespacedName, got); err != nil {
+				t.Fatal(err)
+			}
+			got.Spec.Tag = tt.to
+			if tt.force {
+				got.Annotations = map[string]string{ForceUpgradeKey: "true"}
+			}
+			if err := cl.Update(context.TODO(), got); err != nil {
+				t.Fatal(err)
+			}
+			got.Status = &v1alpha2.InstallStatus{Version: tt.from}
+			if err := cl.Status().Update(context.TODO(), got); err != nil {
+				t.Fatal(err)
+			}
+			if tt.initCRDPod {
+				if err := cl.Create(context.TODO(), initCRDPod.DeepCopy()); err != nil {
+					t.Fatal(err)
+				}
+				defer func() {
+					if err := cl.Delete(context.TODO(), initCRDPod.DeepCopy()); err != nil {
+						t.Fatal(err)
+					}
+				}()
+			}
+
+			res, err := r.Reconcile(req)
+			if err != nil {
+				t.Fatalf("reconcile: (%v)", err)
+			}
+			if res.RequeueAfter != tt.wantRequeue {
+				t.Errorf("got RequeueAfter %s, want %s", res.RequeueAfter, tt.wantRequeue)
+			}
+			if err := cl.Get(context.TODO(), req.NamespacedName, got); err != nil {
+				t.Fatal(err)
+			}
+			if v := got.Status.GetVersion(); v != tt.wantVersion {
+				t.Errorf("got version %q, want %q", v, tt.wantVersion)
+			}
+			up := got.Status.GetUpgrade()
+			if up.GetFromVersion() != tt.from || up.GetToVersion() != tt.to || up.GetPhase() != tt.wantPhase {
+				t.Errorf("got upgrade %v, want %s -> %s %s", up, tt.from, tt.to, tt.wantPhase)
+			}
+			if !strings.Contains(up.GetMessage(), tt.wantMessage) {
+				t.Errorf("got upgrade message %q, want it to contain %q", up.GetMessage(), tt.wantMessage)
+			}
+			c := findCondition(got.Status, ConditionUpgradeBlocked)
+			if tt.wantReason == "" {
+				if c.GetStatus() == ConditionTrue {
+					t.Errorf("got UpgradeBlocked condition %v, want it not to apply", c)
+				}
+				if up.GetCompletionTime() == "" {
+					t.Error("got no upgrade completion time")
+				}
+				return
+			}
+			if c.GetStatus() != ConditionTrue || c.GetReason() != tt.wantReason || !strings.Contains(c.GetMessage(), tt.wantMessage) {
+				t.Errorf("got UpgradeBlocked condition %v, want reason %s containing %q", c, tt.wantReason, tt.wantMessage)
+			}
+		})
+	}
+}
+
+// icpListClient is a fake client which can list IstioControlPlanes. The fake client cannot list them once they have
+// been stored both typed and unstructured, so the IstioControlPlanes it was created with are read one by one instead.
+type icpListClient struct {
+	client.Client
+	keys []client.ObjectKey
+}
+
+func newFakeClient(s *runtime.Scheme, objs ...runtime.Object) client.Client {
+	c := &icpListClient{Client: fake.NewFakeClientWithScheme(s, objs...)}
+	for _, o := range objs {
+		if icp, ok := o.(*v1alpha2.IstioControlPlane); ok {
+			c.keys = append(c.keys, client.ObjectKey{Namespace: icp.Namespace, Name: icp.Name})
+		}
+	}
+	return c
+}
+
+func (c *icpListClient) List(ctx context.Context, obj runtime.Object, opts ...client.ListOption) error {
+	list, ok := obj.(*unstructured.UnstructuredList)
+	if !ok || list.GetKind() != "IstioControlPlaneList" {
+		return c.Client.List(ctx, obj, opts...)
+	}
+	list.Items = nil
+	for _, key := range c.keys {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(util.IstioOperatorGVK)
+		if err := c.Get(ctx, key, u); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		list.Items = append(list.Items, *u)
+	}
+	return nil
+}
@@ -17,31 +17,25 @@ package istiocontrolplane
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"istio.io/pkg/log"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
 	"istio.io/operator/pkg/helmreconciler"
+	"istio.io/operator/pkg/inventory"
 )
 
 const (
 	// ChartOwnerKey is the annotation key used to store the name of the chart that created the resource
 	ChartOwnerKey = MetadataNamespace + "/chart-owner"
-
-	finalizerRemovalBackoffSteps    = 10
-	finalizerRemovalBackoffDuration = 6 * time.Second
-	finalizerRemovalBackoffFactor   = 1.1
 )
 
 // IstioRenderingListener is a RenderingListener specific to IstioControlPlane resources
@@ -79,7 +73,13 @@ func NewIstioStatusUpdater(instance *v1alpha2.IstioControlPlane) helmreconciler.
 }
 
 // EndReconcile updates the status field on the IstioControlPlane instance based on the resulting err parameter.
+// The version, upgrade progress and conditions, which are recorded by the controller rather than the reconciler, are
+// kept.
 func (u *IstioStatusUpdater) EndReconcile(_ runtime.Object, status *v1alpha2.InstallStatus) error {
+	status.Version = u.instance.Status.GetVersion()
+	status.InstallPackagePath = u.instance.Status.GetInstallPackagePath()
+	status.Upgrade = u.instance.Status.GetUpgrade()
+	status.Conditions = u.instance.Status.GetConditions()
 	u.instance.Status = status
 	return u.reconciler.GetClient().Status().Update(context.TODO(), u.instance)
 }
@@ -142,7 +142,8 @@ func NewIstioDefaultChartCustomizer(chartName, chartAnnotationKey string) *Istio
 	}
 }
 
-// EndChart waits for any deployments or stateful sets that were created to become ready
+// EndChart waits for the resources that were created or updated for the chart to become ready, using the checks in
+// the readiness registry.
 func (c *IstioDefaultChartCustomizer) EndChart(chartName string) error {
 	// ignore any errors.  things should settle out
 	c.waitForResources()
@@ -150,102 +151,23 @@ func (c *IstioDefaultChartCustomizer) EndChart(chartName string) error {
 }
 
 func (c *IstioDefaultChartCustomizer) waitForResources() {
-	if statefulSets, ok := c.NewResourcesByKind["StatefulSet"]; ok {
-		for _, statefulSet := range statefulSets {
-			c.waitForDeployment(statefulSet)
-		}
-	}
-	if deployments, ok := c.NewResourcesByKind["Deployment"]; ok {
-		for _, deployment := range deployments {
-			c.waitForDeployment(deployment)
-		}
-	}
-	if daemonSets, ok := c.NewResourcesByKind["DaemonSet"]; ok {
-		for _, daemonSet := range daemonSets {
-			c.waitForDeployment(daemonSet)
-		}
-	}
-	if services, ok := c.NewResourcesByKind["Service"]; ok {
-		for _, service := range services {
-			c.waitForService(service)
-		}
-	}
-}
-
-func (c *IstioDefaultChartCustomizer) serviceReady(svc *corev1.Service) bool {
-	// ExternalName Services are external to cluster so they should not be checked
-	if svc.Spec.Type == corev1.ServiceTypeExternalName {
-		return true
-	}
-	// Check if services except the headless services have the IP set
-	if svc.Spec.ClusterIP != corev1.ClusterIPNone && svc.Spec.ClusterIP == "" {
-		log.Info(fmt.Sprintf("Service is not ready: %s/%s", svc.GetNamespace(), svc.GetName()))
-		return false
-	}
-	// Check if the service has a LoadBalancer with an Ingress ready
-	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && svc.Status.LoadBalancer.Ingress == nil {
-		log.Info(fmt.Sprintf("Service is not ready: %s/%s", svc.GetNamespace(), svc.GetName()))
-		return false
-	}
-	return true
-}
-
-func (c *IstioDefaultChartCustomizer) waitForService(object runtime.Object) {
-	gvk := object.GetObjectKind().GroupVersionKind()
-	objectAccessor, err := meta.Accessor(object)
-	if err != nil {
-		log.Error(fmt.Sprintf("could not get object accessor for %s", gvk.Kind))
-		return
-	}
-	name := objectAccessor.GetName()
-	service, ok := object.(*corev1.Service)
-	if ok {
-		log.Infof("waiting for service to become ready; %s", name)
-		err = wait.ExponentialBackoff(wait.Backoff{
-			Duration: finalizerRemovalBackoffDuration,
-			Steps:    finalizerRemovalBackoffSteps,
-			Factor:   finalizerRemovalBackoffFactor,
-		}, func() (bool, error) {
-			return c.serviceReady(service), nil
-		})
-		if err != nil {
-			log.Errorf("service failed to become ready in a timely manner: %s", name)
+	var refs []inventory.Ref
+	for kind, objects := range c.NewResourcesByKind {
+		for _, object := range objects {
+			objectAccessor, err := meta.Accessor(object)
+			if err != nil {
+				log.Error(fmt.Sprintf("could not get object accessor for %s", kind))
+				continue
+			}
+			refs = append(refs, inventory.Ref{
+				GroupVersionKind: object.GetObjectKind().GroupVersionKind(),
+				Namespace:        objectAccessor.GetNamespace(),
+				Name:             objectAccessor.GetName(),
+			})
 		}
 	}
-}
-
-// XXX: configure wait period
-func (c *IstioDefaultChartCustomizer) waitForDeployment(object runtime.Object) {
-	gvk := object.GetObjectKind().GroupVersionKind()
-	objectAccessor, err := meta.Accessor(object)
-	if err != nil {
-		log.Error(fmt.Sprintf("could not get object accessor for %s", gvk.Kind))
-		return
-	}
-	name := objectAccessor.GetName()
-	namespace := objectAccessor.GetNamespace()
-	deployment := &unstructured.Unstructured{}
-	deployment.SetGroupVersionKind(gvk)
-	// wait for deployment replicas >= 1
-	log.Infof("waiting for deployment to become ready: %s, %s", gvk.Kind, name)
-	err = wait.ExponentialBackoff(wait.Backoff{
-		Duration: finalizerRemovalBackoffDuration,
-		Steps:    finalizerRemovalBackoffSteps,
-		Factor:   finalizerRemovalBackoffFactor,
-	}, func() (bool, error) {
-		err := c.Reconciler.GetClient().Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, deployment)
-		if err == nil {
-			val, _, _ := unstructured.NestedInt64(deployment.UnstructuredContent(), "status", "readyReplicas")
-			return val > 0, nil
-		} else if errors.IsNotFound(err) {
-			log.Errorf("attempting to wait on unknown deployment: %s, %s", gvk.Kind, name)
-			return true, nil
-		}
-		log.Errorf("unexpected error occurred waiting for deployment to become ready: %s, %s: %s", gvk.Kind, name, err)
-		return false, err
-	})
-	if err != nil {
-		log.Errorf("deployment failed to become ready in a timely manner: %s, %s", gvk.Kind, name)
+	if err := c.Reconciler.WaitForReady(refs); err != nil {
+		log.Errorf("resources of chart %s failed to become ready in a timely manner: %s", c.ChartName, err)
 	}
 }
 
@@ -18,10 +18,12 @@ import (
 	"strconv"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
 	"istio.io/operator/pkg/helmreconciler"
 	"istio.io/operator/pkg/util"
+	"istio.io/pkg/log"
 )
 
 const (
@@ -30,6 +32,8 @@ const (
 
 	// OwnerNameKey represents the name of the owner to which the resource relates
 	OwnerNameKey = MetadataNamespace + "/owner-name"
+	// OwnerNamespaceKey represents the namespace of the owner to which the resource relates
+	OwnerNamespaceKey = MetadataNamespace + "/owner-namespace"
 	// OwnerKindKey represents the kind of the owner to which the resource relates
 	OwnerKindKey = MetadataNamespace + "/owner-kind"
 	// OwnerGroupKey represents the group of the owner to which the resource relates
@@ -37,86 +41,76 @@ const (
 
 	// OwnerGenerationKey represents the generation to which the resource was last reconciled
 	OwnerGenerationKey = MetadataNamespace + "/owner-generation"
-)
 
-var (
-	// ordered by which types should be deleted, first to last
-	namespacedResources = []schema.GroupVersionKind{
-		{Group: "autoscaling", Version: "v2beta1", Kind: "HorizontalPodAutoscaler"},
-		{Group: "policy", Version: "v1beta1", Kind: "PodDisruptionBudget"},
-		{Group: "apps", Version: "v1beta1", Kind: "Deployment"},
-		{Group: "apps", Version: "v1beta1", Kind: "StatefulSet"},
-		{Group: "apps", Version: "v1", Kind: "Deployment"},
-		{Group: "apps", Version: "v1", Kind: "DaemonSet"},
-		{Group: "extensions", Version: "v1beta1", Kind: "DaemonSet"},
-		{Group: "extensions", Version: "v1beta1", Kind: "Deployment"},
-		{Group: "extensions", Version: "v1beta1", Kind: "Ingress"},
-		{Group: "", Version: "v1", Kind: "Service"},
-		{Group: "", Version: "v1", Kind: "Endpoints"},
-		{Group: "", Version: "v1", Kind: "ConfigMap"},
-		{Group: "", Version: "v1", Kind: "PersistentVolumeClaim"},
-		{Group: "", Version: "v1", Kind: "Pod"},
-		{Group: "", Version: "v1", Kind: "Secret"},
-		{Group: "", Version: "v1", Kind: "ServiceAccount"},
-		{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "RoleBinding"},
-		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding"},
-		{Group: "rbac.authorization.k8s.io", Version: "v1beta1", Kind: "Role"},
-		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "Role"},
-		{Group: "authentication.istio.io", Version: "v1alpha1", Kind: "Policy"},
-		{Group: "certmanager.k8s.io", Version: "v1beta1", Kind: "Certificate"},
-		{Group: "certmanager.k8s.io", Version: "v1beta1", Kind: "Challenge"},
-		{Group: "certmanager.k8s.io", Version: "v1beta1", Kind: "Issuer"},
-		{Group: "certmanager.k8s.io", Version: "v1beta1", Kind: "Order"},
-		{Group: "config.istio.io", Version: "v1alpha2", Kind: "adapter"},
-		{Group: "config.istio.io", Version: "v1alpha2", Kind: "attributemanifest"},
-		{Group: "config.istio.io", Version: "v1alpha2", Kind: "handler"},
-		{Group: "config.istio.io", Version: "v1alpha2", Kind: "instance"},
-		{Group: "config.istio.io", Version: "v1alpha2", Kind: "HTTPAPISpec"},
-		{Group: "config.istio.io", Version: "v1alpha2", Kind: "HTTPAPISpecBinding"},
-		{Group: "config.istio.io", Version: "v1alpha2", Kind: "QuotaSpec"},
-		{Group: "config.istio.io", Version: "v1alpha2", Kind: "QuotaSpecBinding"},
-		{Group: "config.istio.io", Version: "v1alpha2", Kind: "rule"},
-		{Group: "config.istio.io", Version: "v1alpha2", Kind: "template"},
-		{Group: "networking.istio.io", Version: "v1alpha3", Kind: "DestinationRule"},
-		{Group: "networking.istio.io", Version: "v1alpha3", Kind: "EnvoyFilter"},
-		{Group: "networking.istio.io", Version: "v1alpha3", Kind: "Gateway"},
-		{Group: "networking.istio.io", Version: "v1alpha3", Kind: "ServiceEntry"},
-		{Group: "networking.istio.io", Version: "v1alpha3", Kind: "Sidecar"},
-		{Group: "networking.istio.io", Version: "v1alpha3", Kind: "VirtualService"},
-		{Group: "rbac.istio.io", Version: "v1alpha1", Kind: "ClusterRbacConfig"},
-		{Group: "rbac.istio.io", Version: "v1alpha1", Kind: "RbacConfig"},
-		{Group: "rbac.istio.io", Version: "v1alpha1", Kind: "ServiceRole"},
-		{Group: "rbac.istio.io", Version: "v1alpha1", Kind: "ServiceRoleBinding"},
-		{Group: "security.istio.io", Version: "v1beta1", Kind: "AuthorizationPolicy"},
-		{Group: "security.istio.io", Version: "v1beta1", Kind: "RequestAuthentication"},
-	}
+	// PruneDryRunKey is the annotation which stops the controller from pruning resources which are no longer rendered
+	// for an IstioControlPlane when set to "true". The resources which would be pruned are listed in the status.
+	PruneDryRunKey = MetadataNamespace + "/prune-dry-run"
+	// PruneBackupKey is the annotation which makes the controller back up the resources of an IstioControlPlane to a
+	// Secret in its namespace before pruning them, when set to "true". The name of the Secret is recorded in the
+	// status, and the resources can be restored from it with mesh manifest restore.
+	PruneBackupKey = MetadataNamespace + "/prune-backup"
 
-	// ordered by which types should be deleted, first to last
-	nonNamespacedResources = []schema.GroupVersionKind{
-		{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "MutatingWebhookConfiguration"},
-		{Group: "admissionregistration.k8s.io", Version: "v1beta1", Kind: "ValidatingWebhookConfiguration"},
-		{Group: "certmanager.k8s.io", Version: "v1beta1", Kind: "ClusterIssuer"},
-		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
-		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding"},
-		{Group: "authentication.istio.io", Version: "v1alpha1", Kind: "MeshPolicy"},
-		{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition"},
-	}
+	// pruneBackupSuffix is appended to the IstioControlPlane name to give the prefix of the backup Secret names.
+	pruneBackupSuffix = "-prune-backup"
+	// inventorySuffix is appended to the IstioControlPlane name to give the name of the ConfigMap which records the
+	// resources applied for it.
+	inventorySuffix = "-inventory"
 )
 
+// crdGroupKind is the kind of the CustomResourceDefinitions retained by the RetainCRDs deletion policy.
+var crdGroupKind = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
 // NewPruningDetails creates a new PruningDetails object specific to the instance.
 func NewIstioPruningDetails(instance *v1alpha2.IstioControlPlane) helmreconciler.PruningDetails {
 	name := instance.GetName()
 	generation := strconv.FormatInt(instance.GetGeneration(), 10)
 	return &helmreconciler.SimplePruningDetails{
 		OwnerLabels: map[string]string{
-			OwnerNameKey:  name,
-			OwnerGroupKey: util.IstioOperatorGVK.Group,
-			OwnerKindKey:  util.IstioOperatorGVK.Kind,
+			OwnerNameKey:      name,
+			OwnerNamespaceKey: instance.GetNamespace(),
+			OwnerGroupKey:     util.IstioOperatorGVK.Group,
+			OwnerKindKey:      util.IstioOperatorGVK.Kind,
 		},
 		OwnerAnnotations: map[string]string{
 			OwnerGenerationKey: generation,
 		},
-		NamespacedResources:    namespacedResources,
-		NonNamespacedResources: nonNamespacedResources,
+		ResourceTypes: recordedResourceTypes(instance),
+		PruneOptions:  pruneOptions(instance),
+		Inventory:     client.ObjectKey{Namespace: instance.GetNamespace(), Name: instance.GetName() + inventorySuffix},
+	}
+}
+
+// pruneOptions returns the prune options selected by the annotations of instance, and the resources retained by its
+// deletion policy.
+func pruneOptions(instance *v1alpha2.IstioControlPlane) helmreconciler.PruneOptions {
+	annotations := instance.GetAnnotations()
+	opts := helmreconciler.PruneOptions{DryRun: annotations[PruneDryRunKey] == "true"}
+	if annotations[PruneBackupKey] == "true" {
+		opts.BackupNamespace = instance.GetNamespace()
+		opts.BackupPrefix = instance.GetName() + pruneBackupSuffix
+	}
+	switch instance.GetSpec().GetDeletionPolicy() {
+	case v1alpha2.DeletionPolicy_Retain:
+		opts.RetainAll = true
+	case v1alpha2.DeletionPolicy_RetainCRDs:
+		opts.RetainKinds = append(opts.RetainKinds, crdGroupKind)
+	}
+	for _, kind := range instance.GetSpec().GetRetainKinds() {
+		opts.RetainKinds = append(opts.RetainKinds, schema.ParseGroupKind(kind))
+	}
+	return opts
+}
+
+// recordedResourceTypes returns the resource types recorded in the status of instance by previous reconciles.
+func recordedResourceTypes(instance *v1alpha2.IstioControlPlane) []schema.GroupVersionKind {
+	var out []schema.GroupVersionKind
+	for _, rt := range instance.GetStatus().GetResourceTypes() {
+		gvk, err := helmreconciler.ParseResourceType(rt)
+		if err != nil {
+			log.Warnf("ignoring recorded resource type of IstioControlPlane %s/%s: %s", instance.Namespace, instance.Name, err)
+			continue
+		}
+		out = append(out, gvk)
 	}
+	return out
 }
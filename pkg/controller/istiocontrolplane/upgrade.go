@@ -0,0 +1,289 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package istiocontrolplane
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	goversion "github.com/hashicorp/go-version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/helmreconciler"
+	"istio.io/operator/pkg/hooks"
+	"istio.io/operator/pkg/httprequest"
+	"istio.io/operator/pkg/util"
+	"istio.io/operator/pkg/version"
+	"istio.io/operator/pkg/vfs"
+	"istio.io/pkg/log"
+)
+
+const (
+	// ForceUpgradeKey is the annotation which makes the controller upgrade an IstioControlPlane when set to "true",
+	// even if the upgrade is not supported according to the versions map or the pre-upgrade hooks fail, like the
+	// --force flag of the upgrade command.
+	ForceUpgradeKey = MetadataNamespace + "/force-upgrade"
+
+	// ConditionUpgradeBlocked is the type of the condition set on an IstioControlPlane whose upgrade is not applied,
+	// because it is not supported or a pre-upgrade hook failed.
+	ConditionUpgradeBlocked = "UpgradeBlocked"
+
+	// UpgradePhasePreUpgrade is the phase of an upgrade while the pre-upgrade hooks run.
+	UpgradePhasePreUpgrade = "PreUpgrade"
+	// UpgradePhaseApplying is the phase of an upgrade while the components are applied.
+	UpgradePhaseApplying = "Applying"
+	// UpgradePhasePostUpgrade is the phase of an upgrade while the post-upgrade hooks run.
+	UpgradePhasePostUpgrade = "PostUpgrade"
+	// UpgradePhaseSucceeded is the phase of an upgrade which has been applied and whose post-upgrade hooks succeeded.
+	UpgradePhaseSucceeded = "Succeeded"
+	// UpgradePhaseFailed is the phase of an upgrade which could not be applied, or whose post-upgrade hooks failed.
+	UpgradePhaseFailed = "Failed"
+	// UpgradePhaseBlocked is the phase of an upgrade which is not applied, as reported by ConditionUpgradeBlocked.
+	UpgradePhaseBlocked = "Blocked"
+
+	// unsupportedUpgradeReason is the reason of the UpgradeBlocked condition for upgrades which are not supported.
+	unsupportedUpgradeReason = "UnsupportedUpgrade"
+	// preUpgradeHookFailedReason is the reason of the UpgradeBlocked condition for upgrades whose pre-upgrade hooks
+	// failed.
+	preUpgradeHookFailedReason = "PreUpgradeHookFailed"
+	// upgradeUnblockedReason is the reason of the UpgradeBlocked condition once an upgrade is applied.
+	upgradeUnblockedReason = "UpgradeApplied"
+	// upgradeRequeueInterval is how often an upgrade blocked by a failing pre-upgrade hook is tried again, since the
+	// state the hooks check may change without the IstioControlPlane changing.
+	upgradeRequeueInterval = time.Minute
+)
+
+// upgrade is a transition of an IstioControlPlane from the version and install package it was last applied with to
+// the ones in its spec.
+type upgrade struct {
+	from, to               string
+	fromPackage, toPackage string
+	// force is set if the upgrade is applied even if it is not supported or the pre-upgrade hooks fail.
+	force bool
+	// spec is the spec of the IstioControlPlane merged with its profile, passed to the hooks.
+	spec *v1alpha2.IstioControlPlaneSpec
+}
+
+// String implements the Stringer interface.
+func (u *upgrade) String() string {
+	if u.from == u.to {
+		return fmt.Sprintf("%s (install package %q -> %q)", u.to, u.fromPackage, u.toPackage)
+	}
+	return fmt.Sprintf("%s -> %s", u.from, u.to)
+}
+
+// hookParams returns the parameters of the upgrade hooks, or nil if the hooks cannot run because a version is not a
+// semantic version, e.g. latest.
+func (u *upgrade) hookParams() *hooks.HookCommonParams {
+	for _, v := range []string{u.from, u.to} {
+		if _, err := goversion.NewVersion(v); err != nil {
+			log.Warnf("Not running upgrade hooks for %s, since %s is not a semantic version", u, v)
+			return nil
+		}
+	}
+	return &hooks.HookCommonParams{
+		SourceVer:    u.from,
+		TargetVer:    u.to,
+		SourceValues: u.spec,
+		TargetValues: u.spec,
+	}
+}
+
+// detectUpgrade returns the upgrade the spec of icp makes, or nil if its version and install package are those it
+// was last applied with, or it has never been applied.
+func detectUpgrade(icp *v1alpha2.IstioControlPlane, reconciler *helmreconciler.HelmReconciler, force bool) (*upgrade, error) {
+	spec, err := reconciler.MergedSpec()
+	if err != nil {
+		return nil, err
+	}
+	status := icp.GetStatus()
+	u := &upgrade{
+		from:        status.GetVersion(),
+		to:          spec.GetTag(),
+		fromPackage: status.GetInstallPackagePath(),
+		toPackage:   spec.GetInstallPackagePath(),
+		force:       force,
+		spec:        spec,
+	}
+	if u.from == "" || (u.from == u.to && u.fromPackage == u.toPackage) {
+		return nil, nil
+	}
+	return u, nil
+}
+
+// isForceUpgrade reports whether obj is upgraded even if the upgrade is not supported or its pre-upgrade hooks fail.
+func isForceUpgrade(obj metav1.Object) bool {
+	return obj.GetAnnotations()[ForceUpgradeKey] == "true"
+}
+
+// beginUpgrade checks that up is supported according to the versions map and runs its pre-upgrade hooks, recording
+// its progress in the status of icp. It returns a non-empty reason if the upgrade is blocked, in which case the
+// UpgradeBlocked condition is set.
+func (r *ReconcileIstioControlPlane) beginUpgrade(icp *v1alpha2.IstioControlPlane, up *upgrade) (string, error) {
+	log.Infof("Upgrading IstioControlPlane %s/%s: %s", icp.Namespace, icp.Name, up)
+	if up.from != up.to {
+		if err := version.CheckUpgrade(loadVersionsMap(), up.from, up.to); err != nil {
+			if !up.force {
+				return unsupportedUpgradeReason, r.blockUpgrade(icp, up, unsupportedUpgradeReason, err)
+			}
+			log.Warnf("Forcing upgrade %s: %s", up, err)
+		}
+	}
+
+	if err := r.recordUpgrade(icp, up, UpgradePhasePreUpgrade, ""); err != nil {
+		return "", err
+	}
+	if hc := up.hookParams(); hc != nil {
+		if errs := hooks.RunPreUpgradeHooks(&hookClient{client: r.client}, hc, false); len(errs) != 0 {
+			if !up.force {
+				return preUpgradeHookFailedReason, r.blockUpgrade(icp, up, preUpgradeHookFailedReason, errs.ToError())
+			}
+			log.Warnf("Forcing upgrade %s: pre-upgrade hooks failed: %s", up, errs.ToError())
+		}
+	}
+	clearCondition(icp.Status, ConditionUpgradeBlocked, upgradeUnblockedReason, fmt.Sprintf("upgrade %s is applied", up))
+	return "", r.recordUpgrade(icp, up, UpgradePhaseApplying, "")
+}
+
+// endReconcile records the version and install package of icp in its status if it was applied successfully, as
+// given by err and the status of its components. If icp was upgraded, the post-upgrade hooks are run first, and the
+// result of the upgrade is recorded.
+func (r *ReconcileIstioControlPlane) endReconcile(icp *v1alpha2.IstioControlPlane, reconciler *helmreconciler.HelmReconciler,
+	up *upgrade, err error) error {
+	if err == nil {
+		err = componentErrors(icp.GetStatus())
+	}
+	if up == nil {
+		if err != nil {
+			return nil
+		}
+		spec, specErr := reconciler.MergedSpec()
+		if specErr != nil {
+			return specErr
+		}
+		return r.recordVersion(icp, spec.GetTag(), spec.GetInstallPackagePath())
+	}
+
+	if err != nil {
+		return r.recordUpgrade(icp, up, UpgradePhaseFailed, err.Error())
+	}
+	if hc := up.hookParams(); hc != nil {
+		if err := r.recordUpgrade(icp, up, UpgradePhasePostUpgrade, ""); err != nil {
+			return err
+		}
+		if errs := hooks.RunPostUpgradeHooks(&hookClient{client: r.client}, hc, false); len(errs) != 0 {
+			msg := fmt.Sprintf("post-upgrade hooks failed: %s", errs.ToError())
+			return util.AppendErr(util.NewErrs(r.recordUpgrade(icp, up, UpgradePhaseFailed, msg)), errs.ToError()).ToError()
+		}
+	}
+	log.Infof("Upgraded IstioControlPlane %s/%s: %s", icp.Namespace, icp.Name, up)
+	icp.Status.Version, icp.Status.InstallPackagePath = up.to, up.toPackage
+	return r.recordUpgrade(icp, up, UpgradePhaseSucceeded, "")
+}
+
+// componentErrors returns an error naming the components in status which failed or were blocked, or nil if there
+// are none.
+func componentErrors(status *v1alpha2.InstallStatus) error {
+	var errs util.Errors
+	for c, s := range status.GetStatus() {
+		if s.GetStatus() == v1alpha2.InstallStatus_ERROR || s.GetStatus() == v1alpha2.InstallStatus_BLOCKED {
+			errs = util.AppendErr(errs, fmt.Errorf("component %s is %s: %s", c, s.GetStatus(), s.GetError()))
+		}
+	}
+	return errs.ToError()
+}
+
+// blockUpgrade records that up is blocked for the given reason in the status of icp.
+func (r *ReconcileIstioControlPlane) blockUpgrade(icp *v1alpha2.IstioControlPlane, up *upgrade, reason string, err error) error {
+	msg := fmt.Sprintf("upgrade %s is blocked: %s, set the %s annotation to true to apply it anyway", up, err, ForceUpgradeKey)
+	log.Warnf("IstioControlPlane %s/%s %s", icp.Namespace, icp.Name, msg)
+	if icp.Status == nil {
+		icp.Status = &v1alpha2.InstallStatus{}
+	}
+	setUpgrade(icp.Status, up, UpgradePhaseBlocked, err.Error())
+	setCondition(icp.Status, ConditionUpgradeBlocked, ConditionTrue, reason, msg)
+	icp.Status.Message = msg
+	return r.client.Status().Update(context.TODO(), icp)
+}
+
+// recordUpgrade records that up is in the given phase in the status of icp.
+func (r *ReconcileIstioControlPlane) recordUpgrade(icp *v1alpha2.IstioControlPlane, up *upgrade, phase, message string) error {
+	if icp.Status == nil {
+		icp.Status = &v1alpha2.InstallStatus{}
+	}
+	setUpgrade(icp.Status, up, phase, message)
+	return r.client.Status().Update(context.TODO(), icp)
+}
+
+// recordVersion records the version and install package icp was applied with in its status, if they have changed.
+func (r *ReconcileIstioControlPlane) recordVersion(icp *v1alpha2.IstioControlPlane, version, installPackagePath string) error {
+	if icp.Status == nil {
+		icp.Status = &v1alpha2.InstallStatus{}
+	}
+	if icp.Status.Version == version && icp.Status.InstallPackagePath == installPackagePath {
+		return nil
+	}
+	icp.Status.Version, icp.Status.InstallPackagePath = version, installPackagePath
+	return r.client.Status().Update(context.TODO(), icp)
+}
+
+// setUpgrade sets the upgrade in status to up in the given phase. The start time is kept while the same upgrade is in
+// progress, and the completion time is set once it succeeds or fails.
+func setUpgrade(status *v1alpha2.InstallStatus, up *upgrade, phase, message string) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	prev := status.GetUpgrade()
+	u := &v1alpha2.InstallStatus_Upgrade{
+		FromVersion: up.from,
+		ToVersion:   up.to,
+		Phase:       phase,
+		Message:     message,
+		StartTime:   now,
+	}
+	if prev.GetFromVersion() == u.FromVersion && prev.GetToVersion() == u.ToVersion && prev.GetCompletionTime() == "" &&
+		prev.GetStartTime() != "" {
+		u.StartTime = prev.GetStartTime()
+	}
+	if phase == UpgradePhaseSucceeded || phase == UpgradePhaseFailed {
+		u.CompletionTime = now
+	}
+	status.Upgrade = u
+}
+
+// loadVersionsMap returns the versions map given by the --versions-uri flag or, if it is not set or cannot be read,
+// the versions map built into the operator.
+func loadVersionsMap() []byte {
+	uri := controllerOptions.VersionsURI
+	if uri != "" {
+		var b []byte
+		var err error
+		if util.IsHTTPURL(uri) {
+			b, err = httprequest.Get(uri)
+		} else {
+			b, err = ioutil.ReadFile(uri)
+		}
+		if err == nil {
+			return b
+		}
+		log.Warnf("Failed to retrieve the version map from %s: %s. Falling back to the internal version map.", uri, err)
+	}
+	b, err := vfs.ReadFile("versions.yaml")
+	if err != nil {
+		log.Errorf("Failed to read the internal version map: %s", err)
+	}
+	return b
+}
@@ -0,0 +1,193 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package dependency defines the order in which the components of an installation are applied, for both the CLI and the
+controller. Each component except the base component has a parent, which must be applied and ready before the
+component is applied. If a parent fails, the components which depend on it are blocked rather than applied.
+
+The default parents are in DefaultParents. They can be overridden in the componentDependencies field of the
+IstioControlPlaneSpec, which maps a component name to the name of its parent, e.g.
+
+	componentDependencies:
+	  IngressGateway: Pilot
+*/
+package dependency
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"istio.io/operator/pkg/name"
+)
+
+var (
+	// DefaultParents is the parent of each component, unless overridden in the spec. Components which are not listed,
+	// such as named gateways, depend on the base component.
+	DefaultParents = map[name.ComponentName]name.ComponentName{
+		name.PilotComponentName:              name.IstioBaseComponentName,
+		name.PolicyComponentName:             name.IstioBaseComponentName,
+		name.TelemetryComponentName:          name.IstioBaseComponentName,
+		name.GalleyComponentName:             name.IstioBaseComponentName,
+		name.CitadelComponentName:            name.IstioBaseComponentName,
+		name.NodeAgentComponentName:          name.IstioBaseComponentName,
+		name.CertManagerComponentName:        name.IstioBaseComponentName,
+		name.SidecarInjectorComponentName:    name.IstioBaseComponentName,
+		name.IngressComponentName:            name.IstioBaseComponentName,
+		name.EgressComponentName:             name.IstioBaseComponentName,
+		name.CNIComponentName:                name.IstioBaseComponentName,
+		name.CoreDNSComponentName:            name.IstioBaseComponentName,
+		name.PrometheusOperatorComponentName: name.IstioBaseComponentName,
+		name.PrometheusComponentName:         name.IstioBaseComponentName,
+		name.GrafanaComponentName:            name.IstioBaseComponentName,
+		name.KialiComponentName:              name.IstioBaseComponentName,
+		name.TracingComponentName:            name.IstioBaseComponentName,
+	}
+)
+
+// Graph is the parent of each of a set of components being applied.
+type Graph struct {
+	// components is sorted by name.
+	components []name.ComponentName
+	parents    map[name.ComponentName]name.ComponentName
+	children   map[name.ComponentName][]name.ComponentName
+}
+
+// New returns the graph of components, where the parents in overrides, which are keyed by component name, replace
+// the default parents. If the parent of a component is not in components, e.g. because it is disabled, the component
+// depends on the parent's parent instead.
+func New(components []name.ComponentName, overrides map[string]string) (*Graph, error) {
+	parents, err := parentsWithOverrides(overrides)
+	if err != nil {
+		return nil, err
+	}
+	present := make(map[name.ComponentName]bool)
+	for _, c := range components {
+		present[c] = true
+	}
+	g := &Graph{
+		parents:  make(map[name.ComponentName]name.ComponentName),
+		children: make(map[name.ComponentName][]name.ComponentName),
+	}
+	for c := range present {
+		g.components = append(g.components, c)
+	}
+	sort.Slice(g.components, func(i, j int) bool { return g.components[i] < g.components[j] })
+	for _, c := range g.components {
+		p, ok := parentOf(c, parents)
+		for ok && !present[p] {
+			p, ok = parentOf(p, parents)
+		}
+		if !ok {
+			continue
+		}
+		g.parents[c] = p
+		g.children[p] = append(g.children[p], c)
+	}
+	return g, nil
+}
+
+// Validate returns an error if overrides refer to unknown components, give the base component a parent or contain a
+// cycle.
+func Validate(overrides map[string]string) error {
+	_, err := parentsWithOverrides(overrides)
+	return err
+}
+
+// Parent returns the parent of c, and false if c has none.
+func (g *Graph) Parent(c name.ComponentName) (name.ComponentName, bool) {
+	p, ok := g.parents[c]
+	return p, ok
+}
+
+// Children returns the components whose parent is c, sorted by name.
+func (g *Graph) Children(c name.ComponentName) []name.ComponentName {
+	return g.children[c]
+}
+
+// Roots returns the components with no parent, sorted by name.
+func (g *Graph) Roots() []name.ComponentName {
+	var out []name.ComponentName
+	for _, c := range g.components {
+		if _, ok := g.parents[c]; !ok {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// String returns the graph as an indented tree.
+func (g *Graph) String() string {
+	var sb strings.Builder
+	var write func(c name.ComponentName, prefix string)
+	write = func(c name.ComponentName, prefix string) {
+		sb.WriteString(prefix + string(c) + "\n")
+		for _, child := range g.children[c] {
+			write(child, prefix+"  ")
+		}
+	}
+	for _, r := range g.Roots() {
+		write(r, "")
+	}
+	return sb.String()
+}
+
+// parentsWithOverrides returns DefaultParents with the parents in overrides replacing the defaults.
+func parentsWithOverrides(overrides map[string]string) (map[name.ComponentName]name.ComponentName, error) {
+	out := make(map[name.ComponentName]name.ComponentName)
+	for c, p := range DefaultParents {
+		out[c] = p
+	}
+	for c, p := range overrides {
+		cn, pn := name.ComponentName(c), name.ComponentName(p)
+		for _, n := range []name.ComponentName{cn, pn} {
+			if !isKnown(n) {
+				return nil, fmt.Errorf("unknown component %s", n)
+			}
+		}
+		if cn == name.IstioBaseComponentName {
+			return nil, fmt.Errorf("component %s cannot depend on another component", cn)
+		}
+		out[cn] = pn
+	}
+	for c := range out {
+		seen := map[name.ComponentName]bool{c: true}
+		for p, ok := parentOf(c, out); ok; p, ok = parentOf(p, out) {
+			if seen[p] {
+				return nil, fmt.Errorf("component %s depends on itself", c)
+			}
+			seen[p] = true
+		}
+	}
+	return out, nil
+}
+
+// parentOf returns the parent of c in parents. Components other than the base component which are not in parents
+// depend on the base component.
+func parentOf(c name.ComponentName, parents map[name.ComponentName]name.ComponentName) (name.ComponentName, bool) {
+	if c == name.IstioBaseComponentName {
+		return "", false
+	}
+	if p, ok := parents[c]; ok {
+		return p, true
+	}
+	return name.IstioBaseComponentName, true
+}
+
+// isKnown reports whether c is the name of a component.
+func isKnown(c name.ComponentName) bool {
+	_, ok := DefaultParents[c]
+	return ok || c == name.IstioBaseComponentName || name.IsGatewayComponentName(c)
+}
@@ -0,0 +1,119 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependency
+
+import (
+	"fmt"
+	"testing"
+
+	"istio.io/operator/pkg/name"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		desc       string
+		components []name.ComponentName
+		overrides  map[string]string
+		want       string
+		wantErr    string
+	}{
+		{
+			desc:       "defaults",
+			components: []name.ComponentName{"Pilot", "Base", "Galley"},
+			want:       "Base\n  Galley\n  Pilot\n",
+		},
+		{
+			desc:       "override",
+			components: []name.ComponentName{"Pilot", "Base", "Galley", "IngressGateway"},
+			overrides:  map[string]string{"IngressGateway": "Pilot", "Pilot": "Galley"},
+			want:       "Base\n  Galley\n    Pilot\n      IngressGateway\n",
+		},
+		{
+			desc:       "missing parent",
+			components: []name.ComponentName{"Base", "IngressGateway"},
+			overrides:  map[string]string{"IngressGateway": "Pilot", "Pilot": "Galley"},
+			want:       "Base\n  IngressGateway\n",
+		},
+		{
+			desc:       "named gateway",
+			components: []name.ComponentName{"Base", "Pilot", "IngressGateway-internal"},
+			overrides:  map[string]string{"IngressGateway-internal": "Pilot"},
+			want:       "Base\n  Pilot\n    IngressGateway-internal\n",
+		},
+		{
+			desc:       "unknown component",
+			components: []name.ComponentName{"Base"},
+			overrides:  map[string]string{"Pilot": "Pliot"},
+			wantErr:    "unknown component Pliot",
+		},
+		{
+			desc:       "base override",
+			components: []name.ComponentName{"Base"},
+			overrides:  map[string]string{"Base": "Pilot"},
+			wantErr:    "component Base cannot depend on another component",
+		},
+		{
+			desc:       "cycle",
+			components: []name.ComponentName{"Base"},
+			overrides:  map[string]string{"Pilot": "Pilot"},
+			wantErr:    "component Pilot depends on itself",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			g, err := New(tt.components, tt.overrides)
+			if gotErr, wantErr := errToString(err), tt.wantErr; gotErr != wantErr {
+				t.Fatalf("%s: got error %s, want error %s", tt.desc, gotErr, wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := g.String(); got != tt.want {
+				t.Errorf("%s: got graph\n%s\nwant\n%s", tt.desc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParent(t *testing.T) {
+	g, err := New([]name.ComponentName{"Base", "Pilot", "Galley"}, map[string]string{"Galley": "Pilot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		component name.ComponentName
+		want      string
+	}{
+		{component: "Base", want: " false"},
+		{component: "Pilot", want: "Base true"},
+		{component: "Galley", want: "Pilot true"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.component), func(t *testing.T) {
+			p, ok := g.Parent(tt.component)
+			if got := fmt.Sprintf("%s %v", p, ok); got != tt.want {
+				t.Errorf("got parent of %s %q, want %q", tt.component, got, tt.want)
+			}
+		})
+	}
+}
+
+// errToString returns the string representation of err and the empty string if err is nil.
+func errToString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
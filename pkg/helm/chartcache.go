@@ -0,0 +1,196 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+
+	"istio.io/operator/pkg/util/fswatch"
+	"istio.io/pkg/log"
+)
+
+var (
+	// charts is the process wide cache of loaded charts.
+	charts = newChartCache()
+)
+
+// chartCache caches loaded charts, keyed by chart path and a hash of the chart files, so that a chart is loaded and
+// parsed once rather than for every component on every render. Charts on the local filesystem are removed from the
+// cache when their files change.
+type chartCache struct {
+	mu     sync.Mutex
+	charts map[string]*cachedChart
+	// watched is the set of directories with a watcher which removes the charts under them when they change.
+	watched map[string]bool
+}
+
+// cachedChart is a chart loaded from files with the given hash.
+type cachedChart struct {
+	hash  string
+	chart *chart.Chart
+}
+
+// newChartCache creates an empty chartCache.
+func newChartCache() *chartCache {
+	return &chartCache{
+		charts:  make(map[string]*cachedChart),
+		watched: make(map[string]bool),
+	}
+}
+
+// load returns the chart at path with files of the given hash, calling loadFn to load it if it is not cached.
+func (c *chartCache) load(path, hash string, loadFn func() (*chart.Chart, error)) (*chart.Chart, error) {
+	c.mu.Lock()
+	cc := c.charts[path]
+	c.mu.Unlock()
+	if cc != nil && cc.hash == hash {
+		log.Debugf("Using cached chart %s", path)
+		return cc.chart, nil
+	}
+
+	chrt, err := loadFn()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.charts[path] = &cachedChart{hash: hash, chart: chrt}
+	c.mu.Unlock()
+	return chrt, nil
+}
+
+// loadCompiledIn returns the compiled in chart at path, calling readFiles to read its files if it is not cached.
+// Compiled in charts cannot change, so the files of a cached chart are not read and hashed again.
+func (c *chartCache) loadCompiledIn(path string, readFiles func() ([]*chartutil.BufferedFile, error)) (*chart.Chart, error) {
+	c.mu.Lock()
+	cc := c.charts[path]
+	c.mu.Unlock()
+	if cc != nil {
+		log.Debugf("Using cached chart %s", path)
+		return cc.chart, nil
+	}
+	files, err := readFiles()
+	if err != nil {
+		return nil, err
+	}
+	return c.loadFiles(path, files)
+}
+
+// loadFiles returns the chart at path made up of files.
+func (c *chartCache) loadFiles(path string, files []*chartutil.BufferedFile) (*chart.Chart, error) {
+	sorted := append([]*chartutil.BufferedFile{}, files...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	h := sha256.New()
+	for _, f := range sorted {
+		fmt.Fprintf(h, "%s\n%d\n", f.Name, len(f.Data))
+		h.Write(f.Data)
+	}
+	return c.load(path, fmt.Sprintf("%x", h.Sum(nil)), func() (*chart.Chart, error) {
+		return chartutil.LoadFiles(files)
+	})
+}
+
+// loadDir returns the chart in the local directory dir and the hash of its files.
+func (c *chartCache) loadDir(dir string) (*chart.Chart, string, error) {
+	dir = filepath.Clean(dir)
+	hash, err := dirHash(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	chrt, err := c.load(dir, hash, func() (*chart.Chart, error) {
+		return chartutil.Load(dir)
+	})
+	return chrt, hash, err
+}
+
+// cached reports whether the chart at path is cached with the given hash.
+func (c *chartCache) cached(path, hash string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cc := c.charts[filepath.Clean(path)]
+	return cc != nil && cc.hash == hash
+}
+
+// invalidate removes the charts in dir and its subdirectories from the cache.
+func (c *chartCache) invalidate(dir string) {
+	dir = filepath.Clean(dir)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for path := range c.charts {
+		if path == dir || strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			log.Debugf("Removing chart %s from the cache", path)
+			delete(c.charts, path)
+		}
+	}
+}
+
+// watch removes the charts in dir from the cache whenever any file under dir changes. Each directory is only watched
+// once, however many renderers use it.
+func (c *chartCache) watch(dir string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watched[dir] {
+		return nil
+	}
+	changed, err := fswatch.WatchDirRecursively(dir)
+	if err != nil {
+		return err
+	}
+	c.watched[dir] = true
+	go func() {
+		for range changed {
+			log.Infof("Charts in %s changed", dir)
+			c.invalidate(dir)
+		}
+	}()
+	return nil
+}
+
+// dirHash returns a hash of the names and contents of the files under dir.
+func dirHash(dir string) (string, error) {
+	dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	err = filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "%s\n%d\n", path, len(b))
+		h.Write(b)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helm
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var (
+	// benchmarkCharts are the compiled in charts loaded by the benchmarks.
+	benchmarkCharts = []string{
+		"istio-control/istio-discovery",
+		"istio-control/istio-config",
+		"istio-control/istio-autoinject",
+		"gateways/istio-ingress",
+		"istio-telemetry/mixer-telemetry",
+		"security/citadel",
+	}
+)
+
+func writeChart(t *testing.T, dir, configMapName string) {
+	files := map[string]string{
+		"Chart.yaml": "apiVersion: v1\nname: test\nversion: 1.0.0\n",
+		"templates/configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + configMapName +
+			"\n  namespace: {{ .Release.Namespace }}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestChartCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "chart-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	chartDir := filepath.Join(dir, "test")
+	writeChart(t, chartDir, "first")
+
+	c := newChartCache()
+	first, _, err := c.loadDir(chartDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc     string
+		change   func()
+		wantSame bool
+	}{
+		{
+			desc:     "unchanged",
+			change:   func() {},
+			wantSame: true,
+		},
+		{
+			desc:   "invalidated",
+			change: func() { c.invalidate(dir) },
+		},
+		{
+			desc:   "content changed",
+			change: func() { writeChart(t, chartDir, "second") },
+		},
+	}
+	prev := first
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tt.change()
+			got, hash, err := c.loadDir(chartDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if same := got == prev; same != tt.wantSame {
+				t.Errorf("%s: got same chart %v, want %v", tt.desc, same, tt.wantSame)
+			}
+			if !c.cached(chartDir, hash) {
+				t.Errorf("%s: got chart not cached after loading", tt.desc)
+			}
+			prev = got
+		})
+	}
+
+	manifest, err := renderChart("istio-system", "", prev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "name: second"; !strings.Contains(manifest, want) {
+		t.Errorf("got manifest\n%s\nwant %q", manifest, want)
+	}
+}
+
+// BenchmarkVFSRenderer starts renderers for compiled in charts, which loads the charts, as is done for each component
+// on every render, with and without the chart cache.
+func BenchmarkVFSRenderer(b *testing.B) {
+	for _, bm := range []struct {
+		desc   string
+		cached bool
+	}{
+		{desc: "uncached"},
+		{desc: "cached", cached: true},
+	} {
+		b.Run(bm.desc, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				for _, c := range benchmarkCharts {
+					if !bm.cached {
+						charts.invalidate(chartsRoot)
+					}
+					r := NewVFSRenderer(c, c, "istio-system")
+					if err := r.Run(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -17,10 +17,8 @@ package helm
 import (
 	"fmt"
 
-	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 
-	"istio.io/operator/pkg/util/fswatch"
 	"istio.io/pkg/log"
 )
 
@@ -30,7 +28,9 @@ type FileTemplateRenderer struct {
 	componentName    string
 	helmChartDirPath string
 	chart            *chart.Chart
-	started          bool
+	// chartHash is the hash of the chart files when chart was loaded.
+	chartHash string
+	started   bool
 }
 
 // NewFileTemplateRenderer creates a TemplateRenderer with the given parameters and returns a pointer to it.
@@ -46,25 +46,13 @@ func NewFileTemplateRenderer(helmChartDirPath, componentName, namespace string)
 
 // Run implements the TemplateRenderer interface.
 func (h *FileTemplateRenderer) Run() error {
-	var err error
 	log.Infof("Run FileTemplateRenderer with helmChart=%s, componentName=%s", h.helmChartDirPath, h.componentName)
 	if err := h.loadChart(); err != nil {
 		return err
 	}
-
-	chartChanged, err := fswatch.WatchDirRecursively(h.helmChartDirPath)
-	if err != nil {
+	if err := charts.watch(h.helmChartDirPath); err != nil {
 		return err
 	}
-
-	go func() {
-		for range chartChanged {
-			if err := h.loadChart(); err != nil {
-				log.Errorf("Failed to load chart: %v", err.Error())
-			}
-		}
-	}()
-
 	h.started = true
 	return nil
 }
@@ -74,13 +62,19 @@ func (h *FileTemplateRenderer) RenderManifest(values string) (string, error) {
 	if !h.started {
 		return "", fmt.Errorf("fileTemplateRenderer for %s not started in renderChart", h.componentName)
 	}
+	// The chart is removed from the cache when its files change, in which case it is loaded again.
+	if !charts.cached(h.helmChartDirPath, h.chartHash) {
+		if err := h.loadChart(); err != nil {
+			return "", err
+		}
+	}
 	return renderChart(h.namespace, values, h.chart)
 }
 
 // loadChart implements the TemplateRenderer interface.
 func (h *FileTemplateRenderer) loadChart() error {
 	var err error
-	if h.chart, err = chartutil.Load(h.helmChartDirPath); err != nil {
+	if h.chart, h.chartHash, err = charts.loadDir(h.helmChartDirPath); err != nil {
 		return err
 	}
 	return nil
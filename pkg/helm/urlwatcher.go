@@ -67,6 +67,7 @@ func (p *URLPoller) poll(notify chan<- struct{}) {
 			log.Errorf("Error polling charts: %v", err)
 		}
 		if updated {
+			charts.invalidate(p.urlFetcher.destDir)
 			notify <- struct{}{}
 		}
 	}
@@ -109,25 +109,27 @@ func isBuiltinProfileName(name string) bool {
 // loadChart implements the TemplateRenderer interface.
 func (h *VFSRenderer) loadChart() error {
 	prefix := filepath.Join(chartsRoot, h.helmChartDirPath)
-	fnames, err := vfs.GetFilesRecursive(prefix)
-	if err != nil {
-		return err
-	}
-	var bfs []*chartutil.BufferedFile
-	for _, fname := range fnames {
-		b, err := vfs.ReadFile(fname)
+	var err error
+	h.chart, err = charts.loadCompiledIn(prefix, func() ([]*chartutil.BufferedFile, error) {
+		fnames, err := vfs.GetFilesRecursive(prefix)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		bf := &chartutil.BufferedFile{
-			Name: stripPrefix(fname, prefix),
-			Data: b,
+		var bfs []*chartutil.BufferedFile
+		for _, fname := range fnames {
+			b, err := vfs.ReadFile(fname)
+			if err != nil {
+				return nil, err
+			}
+			bf := &chartutil.BufferedFile{
+				Name: stripPrefix(fname, prefix),
+				Data: b,
+			}
+			bfs = append(bfs, bf)
+			log.Debugf("Chart loaded: %s", bf.Name)
 		}
-		bfs = append(bfs, bf)
-		log.Debugf("Chart loaded: %s", bf.Name)
-	}
-
-	h.chart, err = chartutil.LoadFiles(bfs)
+		return bfs, nil
+	})
 	return err
 }
 
@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/helm/pkg/manifest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"istio.io/operator/pkg/util"
 )
@@ -62,16 +63,18 @@ func (c *SimpleRenderingCustomizer) RegisterReconciler(reconciler *HelmReconcile
 }
 
 // SimplePruningDetails is a helper to implement PruningDetails from a known set of labels,
-// annotations, and resource types.
+// annotations, resource types and prune options.
 type SimplePruningDetails struct {
 	// OwnerLabels to be added to all rendered resources.
 	OwnerLabels map[string]string
 	// OwnerAnnotations to be added to all rendered resources.
 	OwnerAnnotations map[string]string
-	// NamespacedResources rendered by these charts
-	NamespacedResources []schema.GroupVersionKind
-	// NonNamespacedResources rendered by these charts
-	NonNamespacedResources []schema.GroupVersionKind
+	// ResourceTypes recorded as applied by previous reconciles.
+	ResourceTypes []schema.GroupVersionKind
+	// PruneOptions controlling how resources are pruned.
+	PruneOptions PruneOptions
+	// Inventory is the ConfigMap which records the applied resources.
+	Inventory client.ObjectKey
 }
 
 var _ PruningDetails = &SimplePruningDetails{}
@@ -92,9 +95,19 @@ func (m *SimplePruningDetails) GetOwnerAnnotations() map[string]string {
 	return m.OwnerAnnotations
 }
 
-// GetResourceTypes returns this.NamespacedResources and this.NonNamespacedResources
-func (m *SimplePruningDetails) GetResourceTypes() (namespaced []schema.GroupVersionKind, nonNamespaced []schema.GroupVersionKind) {
-	return m.NamespacedResources, m.NonNamespacedResources
+// GetResourceTypes returns this.ResourceTypes
+func (m *SimplePruningDetails) GetResourceTypes() []schema.GroupVersionKind {
+	return m.ResourceTypes
+}
+
+// GetPruneOptions returns this.PruneOptions
+func (m *SimplePruningDetails) GetPruneOptions() PruneOptions {
+	return m.PruneOptions
+}
+
+// GetInventory returns this.Inventory
+func (m *SimplePruningDetails) GetInventory() client.ObjectKey {
+	return m.Inventory
 }
 
 // DefaultChartCustomizerFactory is a factory for creating DefaultChartCustomizer objects
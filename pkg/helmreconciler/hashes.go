@@ -0,0 +1,102 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/helm/pkg/manifest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/inventory"
+	"istio.io/pkg/log"
+)
+
+// manifestHash returns a hash of the content of ms.
+func manifestHash(ms []manifest.Manifest) string {
+	h := sha256.New()
+	for _, m := range ms {
+		fmt.Fprintf(h, "%s\n%s\n", m.Name, m.Content)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// objectsHash returns a hash of the generations of the objects referred to in refs, or of the resource versions of
+// objects which have no generation, so that the hash changes if any of the objects is changed. It returns false if
+// any of the objects does not exist.
+func (h *HelmReconciler) objectsHash(refs []inventory.Ref) (string, bool, error) {
+	hash := sha256.New()
+	for _, r := range refs {
+		rt, ok := h.resolveType(r.GroupVersionKind)
+		if !ok {
+			return "", false, nil
+		}
+		r.GroupVersionKind = rt.gvk
+		o := r.Unstructured()
+		if err := h.client.Get(context.TODO(), client.ObjectKey{Namespace: r.Namespace, Name: r.Name}, o); err != nil {
+			if apierrors.IsNotFound(err) {
+				return "", false, nil
+			}
+			return "", false, fmt.Errorf("could not get %s: %s", r, err)
+		}
+		version := o.GetResourceVersion()
+		if o.GetGeneration() != 0 {
+			version = strconv.FormatInt(o.GetGeneration(), 10)
+		}
+		fmt.Fprintf(hash, "%s %s %s\n", r, o.GetUID(), version)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), true, nil
+}
+
+// statusGetter is implemented by instances with an InstallStatus.
+type statusGetter interface {
+	GetStatus() *v1alpha2.InstallStatus
+}
+
+// previousStatus returns the status of each chart recorded for the instance when it was last reconciled.
+func (h *HelmReconciler) previousStatus() map[string]*v1alpha2.InstallStatus_VersionStatus {
+	if s, ok := h.instance.(statusGetter); ok {
+		return s.GetStatus().GetStatus()
+	}
+	return nil
+}
+
+// unchanged reports whether chart can be skipped because it was applied successfully from the same manifests when
+// the instance was last reconciled, the inventory recorded then, recorded, has the objects of the rendered
+// inventory, and none of the objects have been changed or deleted since. It returns the hash of the objects.
+func (h *HelmReconciler) unchanged(chart string, ms []manifest.Manifest, prev *v1alpha2.InstallStatus_VersionStatus,
+	rendered, recorded inventory.Inventory) (string, bool) {
+	if h.customizer.Input().GetForceReconcile() || recorded == nil {
+		return "", false
+	}
+	if prev.GetStatus() != v1alpha2.InstallStatus_HEALTHY || prev.GetManifestHash() != manifestHash(ms) {
+		return "", false
+	}
+	refs := rendered[chart]
+	if len(inventory.Subtract(refs, recorded[chart])) != 0 || len(inventory.Subtract(recorded[chart], refs)) != 0 {
+		return "", false
+	}
+	hash, ok, err := h.objectsHash(refs)
+	if err != nil {
+		log.Warnf("could not check whether the objects of %s have changed: %s", chart, err)
+		return "", false
+	}
+	return hash, ok && hash == prev.GetObjectsHash()
+}
@@ -23,8 +23,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
-
-	"istio.io/operator/pkg/name"
+	"istio.io/operator/pkg/dependency"
 )
 
 // RenderingCustomizer encompasses all the customization details for a specific rendering invocation.
@@ -42,8 +41,8 @@ type RenderingCustomizerFactory interface {
 	NewCustomizer(obj runtime.Object) (RenderingCustomizer, error)
 }
 
-// PruningDetails define the labels and annotations used to mark resources managed by the operator, as well as the
-// resource types managed by the operator.
+// PruningDetails define the labels and annotations used to mark resources managed by the operator, the resource types
+// managed by the operator and how resources are pruned.
 type PruningDetails interface {
 	// GetOwnerLabels returns the labels applied to all resources managed by the operator.
 	// These are used as label selectors when selecting resources managed by the operator (e.g. as part of pruning
@@ -63,9 +62,48 @@ type PruningDetails interface {
 	// pruned.  To avoid pruning derived resources (which typically inherit the parent's labels), the prune logic
 	// verifies that the annotation keys exist.
 	GetOwnerAnnotations() map[string]string
-	// GetResourceTypes returns the types of resources managed by the operator.  These types are used when selecting
-	// resources to be pruned.
-	GetResourceTypes() (namespaced []schema.GroupVersionKind, nonNamespaced []schema.GroupVersionKind)
+	// GetResourceTypes returns the types of resources recorded as applied by the operator.  These types are used,
+	// along with the types of the rendered resources, when selecting resources to be pruned.  The types may include
+	// versions which are no longer served, which are resolved using discovery.
+	GetResourceTypes() []schema.GroupVersionKind
+	// GetPruneOptions returns the options controlling how resources which are no longer rendered are pruned.
+	GetPruneOptions() PruneOptions
+	// GetInventory returns the namespace and name of the ConfigMap which records the resources applied for each chart.
+	// The resources in the inventory which are no longer rendered are pruned, without listing the resource types. If
+	// the name is empty, or no inventory has been recorded yet, the resources to prune are found by listing the
+	// resource types with the owner labels.
+	GetInventory() client.ObjectKey
+}
+
+// PruneOptions control how resources which are no longer rendered are pruned. Resources protected by the
+// prune.DoNotPruneKey annotation are never pruned.
+type PruneOptions struct {
+	// DryRun reports the resources which would be pruned instead of deleting them. It does not apply when the
+	// resources are deleted because the custom resource is deleted.
+	DryRun bool
+	// BackupNamespace is the namespace of the Secrets that resources are backed up to before they are pruned.
+	BackupNamespace string
+	// BackupPrefix is the prefix of the names of the backup Secrets, which are followed by the time of the backup.
+	// Resources are not backed up if it is empty.
+	BackupPrefix string
+	// RetainAll keeps all the resources when the custom resource is deleted, rather than deleting them.
+	RetainAll bool
+	// RetainKinds are the kinds of the resources which are kept when the custom resource is deleted. A kind with an
+	// empty group matches the kind in any group.
+	RetainKinds []schema.GroupKind
+}
+
+// retains reports whether the resources of kind gk are kept when the custom resource is deleted.
+func (o PruneOptions) retains(gk schema.GroupKind) bool {
+	if o.RetainAll {
+		return true
+	}
+	for _, rk := range o.RetainKinds {
+		if rk.Kind == gk.Kind && (rk.Group == "" || rk.Group == gk.Group) {
+			return true
+		}
+	}
+	return false
 }
 
 // ChartManifestsMap is a typedef representing a map of chart-name: []manifest, i.e. the manifests
@@ -81,10 +119,12 @@ type RenderingInput interface {
 	// GetTargetNamespace returns the target namespace which should be applied to namespaced resources
 	// (i.e. used to set Release.Namespace)
 	GetTargetNamespace() string
-	// GetProcessingOrder returns a dependency tree for the given manifests. ComponentNameToListMap is a map of
-	// each component to its dependencies. DependencyWaitCh is a map of channels, indexed by name. The component with
-	// the given name must wait on the channel before starting its processing.
-	GetProcessingOrder(manifests ChartManifestsMap) (ComponentNameToListMap, DependencyWaitCh)
+	// GetProcessingOrder returns the dependency graph of the charts in the given manifests. A chart is processed once
+	// its parent in the graph has been processed and is ready.
+	GetProcessingOrder(manifests ChartManifestsMap) (*dependency.Graph, error)
+	// GetForceReconcile returns true if every chart should be applied, including charts which are unchanged since
+	// they were last applied.
+	GetForceReconcile() bool
 }
 
 // RenderingListener is the main hook into the rendering process.  The methods represent each stage in the
@@ -201,13 +241,3 @@ type ClientProvider interface {
 	// GetClient returns a kubernetes client.
 	GetClient() client.Client
 }
-
-// ComponentNameToListMap is a map of ComponentName to a list of ComponentNames.
-type ComponentNameToListMap map[name.ComponentName][]name.ComponentName
-
-// ComponentTree defines a dependency tree.
-type ComponentTree map[name.ComponentName]interface{}
-
-// DependencyWaitCh defines a map of component name to a channel which signals when its parent dependency has
-// completed.
-type DependencyWaitCh map[name.ComponentName]chan struct{}
@@ -0,0 +1,201 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/operator/pkg/inventory"
+	"istio.io/operator/pkg/object"
+	"istio.io/operator/pkg/util"
+)
+
+const (
+	// unknownChart is the inventory key of the objects which were found by listing the resource types, before an
+	// inventory was recorded, and could not be pruned.
+	unknownChart = "unknown"
+)
+
+// renderedInventory returns the inventory of the objects in manifests. Namespaced objects without a namespace are in
+// the target namespace.
+func (h *HelmReconciler) renderedInventory(manifests ChartManifestsMap) (inventory.Inventory, error) {
+	targetNamespace := h.customizer.Input().GetTargetNamespace()
+	out := make(inventory.Inventory)
+	for chart, ms := range manifests {
+		var refs []inventory.Ref
+		for _, m := range ms {
+			objects, err := object.ParseK8sObjectsFromYAMLManifest(m.Content)
+			if err != nil {
+				return nil, err
+			}
+			for _, o := range objects {
+				r := inventory.RefForObject(o)
+				if r.Namespace == "" && !object.IsClusterScoped(o.Kind) {
+					r.Namespace = targetNamespace
+				}
+				refs = append(refs, r)
+			}
+		}
+		out.Set(chart, refs)
+	}
+	return out, nil
+}
+
+// RenderedInventory returns the inventory of the objects rendered for the current spec of the instance, which it
+// would manage if it were reconciled.
+func (h *HelmReconciler) RenderedInventory() (inventory.Inventory, error) {
+	manifests, err := h.renderCharts(h.customizer.Input())
+	if err != nil {
+		return nil, err
+	}
+	return h.renderedInventory(manifests)
+}
+
+// RecordedInventory returns the inventory of the objects applied when the instance was last reconciled, or nil if
+// none is recorded.
+func (h *HelmReconciler) RecordedInventory() (inventory.Inventory, error) {
+	return h.loadInventory()
+}
+
+// loadInventory returns the inventory recorded for the instance, or nil if none is recorded or inventories are not
+// configured.
+func (h *HelmReconciler) loadInventory() (inventory.Inventory, error) {
+	key := h.customizer.PruningDetails().GetInventory()
+	if key.Name == "" {
+		return nil, nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := h.client.Get(context.TODO(), key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read inventory %s: %s", key, err)
+	}
+	inv, err := inventory.FromConfigMap(cm)
+	if err != nil {
+		return nil, fmt.Errorf("could not read inventory %s: %s", key, err)
+	}
+	return inv, nil
+}
+
+// saveInventory records inv as the inventory of the instance, if inventories are configured. The inventory is owned
+// by the instance, so it is deleted along with it.
+func (h *HelmReconciler) saveInventory(inv inventory.Inventory) error {
+	key := h.customizer.PruningDetails().GetInventory()
+	if key.Name == "" {
+		return nil
+	}
+	owner, err := meta.Accessor(h.instance)
+	if err != nil {
+		return err
+	}
+	cm := &corev1.ConfigMap{}
+	err = h.client.Get(context.TODO(), key, cm)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("could not read inventory %s: %s", key, err)
+	}
+	create := apierrors.IsNotFound(err)
+	cm.Name, cm.Namespace = key.Name, key.Namespace
+	cm.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(owner, util.IstioOperatorGVK)}
+	inv.ToConfigMap(cm)
+	if create {
+		err = h.client.Create(context.TODO(), cm)
+	} else {
+		err = h.client.Update(context.TODO(), cm)
+	}
+	if err != nil {
+		return fmt.Errorf("could not record inventory %s: %s", key, err)
+	}
+	return nil
+}
+
+// inventoryObjects returns the objects referred to in refs which exist, in the order they should be deleted.
+func (h *HelmReconciler) inventoryObjects(refs []inventory.Ref) ([]unstructured.Unstructured, error) {
+	var out []unstructured.Unstructured
+	for _, r := range refs {
+		rt, ok := h.resolveType(r.GroupVersionKind)
+		if !ok {
+			// The type is no longer served, so there are no objects of the type.
+			continue
+		}
+		r.GroupVersionKind = rt.gvk
+		o := r.Unstructured()
+		if err := h.client.Get(context.TODO(), client.ObjectKey{Namespace: r.Namespace, Name: r.Name}, o); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("could not get %s: %s", r, err)
+		}
+		out = append(out, *o)
+	}
+	sortForDeletion(out)
+	return out, nil
+}
+
+// nextInventory returns the inventory to record after pruning: the rendered objects and the objects in the previous
+// inventory, prev, which were kept. Kept objects which were not in prev are recorded under unknownChart.
+func nextInventory(rendered, prev inventory.Inventory, kept []inventory.Ref) inventory.Inventory {
+	keep := make(map[string]bool)
+	for _, r := range kept {
+		keep[r.Key()] = true
+	}
+	refs := make(map[string][]inventory.Ref)
+	for chart, rs := range rendered {
+		refs[chart] = append(refs[chart], rs...)
+	}
+	for chart, rs := range prev {
+		for _, r := range rs {
+			if keep[r.Key()] {
+				refs[chart] = append(refs[chart], r)
+				delete(keep, r.Key())
+			}
+		}
+	}
+	for _, r := range kept {
+		if keep[r.Key()] {
+			refs[unknownChart] = append(refs[unknownChart], r)
+		}
+	}
+	out := make(inventory.Inventory)
+	for chart, rs := range refs {
+		out.Set(chart, rs)
+	}
+	return out
+}
+
+// sortForDeletion sorts objs so that objects are deleted before the objects they depend on.
+func sortForDeletion(objs []unstructured.Unstructured) {
+	types := make(map[schema.GroupKind]resourceType)
+	for _, o := range objs {
+		types[o.GroupVersionKind().GroupKind()] = resourceType{gvk: o.GroupVersionKind()}
+	}
+	order := make(map[schema.GroupKind]int)
+	for i, rt := range deleteOrder(types) {
+		order[rt.gvk.GroupKind()] = i
+	}
+	sort.SliceStable(objs, func(i, j int) bool {
+		return order[objs[i].GroupVersionKind().GroupKind()] < order[objs[j].GroupVersionKind().GroupKind()]
+	})
+}
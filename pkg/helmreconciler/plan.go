@@ -0,0 +1,181 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/operator/pkg/compare"
+	"istio.io/operator/pkg/inventory"
+	"istio.io/operator/pkg/object"
+	"istio.io/operator/pkg/prune"
+)
+
+// Plan renders the charts for the custom resource instance and returns a description of the changes that Reconcile
+// would make to the cluster, without making them. Rendered objects which do not exist are listed as created, objects
+// which exist are listed with a diff of the rendered fields, and objects owned by the instance which are no longer
+// rendered are listed as pruned, unless they are protected from pruning. Fields which are set by the cluster or by the operator when applying are not compared.
+func (h *HelmReconciler) Plan() (string, error) {
+	manifestMap, err := h.renderCharts(h.customizer.Input())
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	for _, c := range sortedCharts(manifestMap) {
+		for _, m := range manifestMap[c] {
+			objects, err := object.ParseK8sObjectsFromYAMLManifest(m.Content)
+			if err != nil {
+				return "", err
+			}
+			for _, o := range objects {
+				change, err := h.planObject(o.UnstructuredObject())
+				if err != nil {
+					return "", err
+				}
+				if change != "" {
+					sb.WriteString(fmt.Sprintf("%s: %s\n", c, change))
+				}
+			}
+		}
+	}
+
+	types, err := ResourceTypes(manifestMap)
+	if err != nil {
+		return "", err
+	}
+	rendered, err := h.renderedInventory(manifestMap)
+	if err != nil {
+		return "", err
+	}
+	pruned, err := h.planPrune(rendered, types)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range pruned {
+		sb.WriteString(p + "\n")
+	}
+	return sb.String(), nil
+}
+
+// planObject returns a description of the change that applying obj would make, or an empty string if there is none.
+func (h *HelmReconciler) planObject(obj *unstructured.Unstructured) (string, error) {
+	id := fmt.Sprintf("%s %s", obj.GetKind(), objectName(obj.GetNamespace(), obj.GetName()))
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	key := client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	if err := h.client.Get(context.TODO(), key, existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "create " + id, nil
+		}
+		return "", fmt.Errorf("could not get %s: %s", id, err)
+	}
+
+	want, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", err
+	}
+	got, err := yaml.Marshal(projectTree(existing.Object, obj.Object))
+	if err != nil {
+		return "", err
+	}
+	if diff := compare.YAMLCmp(string(got), string(want)); diff != "" {
+		return fmt.Sprintf("update %s\n%s", id, diff), nil
+	}
+	return "", nil
+}
+
+// planPrune returns the changes which prune the objects of the instance that are not in rendered, the inventory of the
+// rendered objects. types are the rendered resource types.
+func (h *HelmReconciler) planPrune(rendered inventory.Inventory, types []schema.GroupVersionKind) ([]string, error) {
+	_, candidates, err := h.findPruneCandidates(rendered, types, false)
+	if err != nil {
+		return nil, err
+	}
+	// Without an inventory the candidates are found by the owner generation, which the rendered objects only have once
+	// they are applied, so the rendered objects are excluded here.
+	isRendered := make(map[string]bool)
+	for _, r := range rendered.All() {
+		isRendered[r.Key()] = true
+	}
+	var out []string
+	for _, o := range candidates {
+		if isRendered[inventory.RefForUnstructured(&o).Key()] {
+			continue
+		}
+		if prune.IsProtected(&o) {
+			out = append(out, fmt.Sprintf("keep %s (protected by %s)", prune.ObjectString(&o), prune.DoNotPruneKey))
+		} else {
+			out = append(out, "prune "+prune.ObjectString(&o))
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// projectTree returns the subset of tree which has the same paths as the subset tree, so that fields which are added
+// by the cluster, such as status or defaulted fields, are ignored when comparing tree with subset.
+func projectTree(tree, subset interface{}) interface{} {
+	switch st := subset.(type) {
+	case map[string]interface{}:
+		t, ok := tree.(map[string]interface{})
+		if !ok {
+			return tree
+		}
+		out := make(map[string]interface{})
+		for k, sv := range st {
+			if tv, ok := t[k]; ok {
+				out[k] = projectTree(tv, sv)
+			}
+		}
+		return out
+	case []interface{}:
+		t, ok := tree.([]interface{})
+		if !ok || len(t) != len(st) {
+			return tree
+		}
+		out := make([]interface{}, len(t))
+		for i := range t {
+			out[i] = projectTree(t[i], st[i])
+		}
+		return out
+	}
+	return tree
+}
+
+func objectName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "/" + name
+}
+
+func sortedCharts(m ChartManifestsMap) []string {
+	var out []string
+	for c := range m {
+		out = append(out, c)
+	}
+	sort.Strings(out)
+	return out
+}
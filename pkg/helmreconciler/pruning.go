@@ -16,70 +16,250 @@ package helmreconciler
 
 import (
 	"context"
+	"fmt"
 
 	"istio.io/pkg/log"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/operator/pkg/inventory"
+	"istio.io/operator/pkg/prune"
 )
 
-// Prune removes any resources not specified in manifests generated by HelmReconciler h. If all is set to true, this
-// function prunes all resources.
-func (h *HelmReconciler) Prune(all bool) error {
-	allErrors := []error{}
-	namespacedResources, nonNamespacedResources := h.customizer.PruningDetails().GetResourceTypes()
-	targetNamespace := h.customizer.Input().GetTargetNamespace()
-	err := h.PruneResources(namespacedResources, all, targetNamespace)
+// namespaceGroupKind is the kind of Namespaces, which are retained along with the objects retained in them.
+var namespaceGroupKind = schema.GroupKind{Kind: "Namespace"}
+
+// pruneResult is the outcome of pruning resources.
+type pruneResult struct {
+	// failed are the types of the resources which could not be pruned, so that they are checked again.
+	failed []schema.GroupVersionKind
+	// skipped describes the resources which were not pruned because they are protected or prune dry run is enabled.
+	skipped []string
+	// kept are the resources which were not pruned, which remain in the inventory.
+	kept []inventory.Ref
+	// retained describes the resources which were kept and detached from the custom resource when it was deleted.
+	retained []string
+	// backup is the namespace and name of the Secret the pruned resources were backed up to, if any.
+	backup string
+}
+
+// prune removes the resources which are not in rendered, the inventory of the resources in the manifests generated by
+// HelmReconciler h. If all is set to true, it prunes all resources. The recorded inventory is updated afterwards.
+func (h *HelmReconciler) prune(rendered inventory.Inventory, types []schema.GroupVersionKind, all bool) (*pruneResult, error) {
+	inv, candidates, err := h.findPruneCandidates(rendered, types, all)
 	if err != nil {
-		allErrors = append(allErrors, err)
+		return &pruneResult{}, err
+	}
+	out, err := h.pruneObjects(candidates, all)
+	if !all {
+		if invErr := h.saveInventory(nextInventory(rendered, inv, out.kept)); invErr != nil {
+			err = utilerrors.NewAggregate([]error{err, invErr})
+		}
 	}
-	err = h.PruneResources(nonNamespacedResources, all, "")
+	return out, err
+}
+
+// findPruneCandidates returns the recorded inventory and the resources which are not in rendered, in the order they
+// should be deleted. If all is set to true, it returns all resources. The resources are taken from the inventory, or
+// if none is recorded they are found by listing the resources of types, the rendered resource types, and the types
+// recorded by previous reconciles.
+func (h *HelmReconciler) findPruneCandidates(rendered inventory.Inventory, types []schema.GroupVersionKind,
+	all bool) (inventory.Inventory, []unstructured.Unstructured, error) {
+	inv, err := h.loadInventory()
 	if err != nil {
-		allErrors = append(allErrors, err)
+		return nil, nil, err
+	}
+	if inv == nil {
+		return nil, h.scanCandidates(types, all), nil
+	}
+	refs := inv.All()
+	if !all {
+		refs = inventory.Subtract(refs, rendered.All())
 	}
-	return utilerrors.NewAggregate(allErrors)
+	candidates, err := h.inventoryObjects(refs)
+	return inv, candidates, err
 }
 
-// Prune removes any resources not specified gvks. If all is set to true, it prunes all
-// resources.
-func (h *HelmReconciler) PruneResources(gvks []schema.GroupVersionKind, all bool, namespace string) error {
+// pruneObjects prunes candidates. Resources protected by the prune.DoNotPruneKey annotation are skipped. The remaining
+// resources are only reported if prune dry run is enabled and all is not set. If all is set, the resources retained
+// by the prune options are detached from the custom resource instead. The rest are backed up, if a backup is
+// configured, and deleted. Nothing is deleted if the backup fails.
+func (h *HelmReconciler) pruneObjects(candidates []unstructured.Unstructured, all bool) (*pruneResult, error) {
+	out := &pruneResult{}
+	prunable, protected := prune.Filter(candidates)
+	for _, o := range protected {
+		out.skipped = append(out.skipped, prune.ObjectString(&o)+" (protected)")
+		out.kept = append(out.kept, inventory.RefForUnstructured(&o))
+	}
+	opts := h.customizer.PruningDetails().GetPruneOptions()
 	allErrors := []error{}
-	ownerLabels := h.customizer.PruningDetails().GetOwnerLabels()
-	ownerAnnotations := h.customizer.PruningDetails().GetOwnerAnnotations()
-	for _, gvk := range gvks {
-		objects := &unstructured.UnstructuredList{}
-		objects.SetGroupVersionKind(gvk)
-		err := h.client.List(context.TODO(), objects, client.MatchingLabels(ownerLabels), client.InNamespace(namespace))
+	if all {
+		var retained []unstructured.Unstructured
+		prunable, retained = splitRetained(prunable, opts)
+		for _, o := range retained {
+			if err := h.detach(&o); err != nil {
+				allErrors = append(allErrors, fmt.Errorf("could not detach retained resource %s: %s", prune.ObjectString(&o), err))
+				out.failed = append(out.failed, o.GroupVersionKind())
+				continue
+			}
+			out.retained = append(out.retained, prune.ObjectString(&o))
+		}
+	}
+	if len(prunable) == 0 {
+		return out, utilerrors.NewAggregate(allErrors)
+	}
+	if opts.DryRun && !all {
+		for _, o := range prunable {
+			out.skipped = append(out.skipped, prune.ObjectString(&o)+" (dry run)")
+			out.kept = append(out.kept, inventory.RefForUnstructured(&o))
+		}
+		return out, nil
+	}
+
+	if opts.BackupPrefix != "" {
+		backup, err := h.backup(prunable, opts)
 		if err != nil {
-			// we only want to retrieve resources clusters
-			log.Warnf("retrieving resources to prune type %s: %s not found", gvk.String(), err)
-			continue
+			for _, o := range prunable {
+				out.failed = append(out.failed, o.GroupVersionKind())
+				out.kept = append(out.kept, inventory.RefForUnstructured(&o))
+			}
+			allErrors = append(allErrors, fmt.Errorf("could not back up resources before pruning, nothing was pruned: %s", err))
+			return out, utilerrors.NewAggregate(allErrors)
 		}
-	objectLoop:
-		for _, object := range objects.Items {
-			annotations := object.GetAnnotations()
-			for ownerKey, ownerValue := range ownerAnnotations {
-				// we only want to delete objects that contain the annotations
-				// if we're not pruning all objects, we only want to prune those whose annotation value does not match what is expected
-				if value, ok := annotations[ownerKey]; !ok || (!all && value == ownerValue) {
-					continue objectLoop
-				}
+		out.backup = backup
+	}
+
+	for _, object := range prunable {
+		err := h.client.Delete(context.TODO(), &object, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err == nil {
+			if listenerErr := h.customizer.Listener().ResourceDeleted(&object); listenerErr != nil {
+				log.Errorf("error calling listener: %s", err)
 			}
-			err = h.client.Delete(context.TODO(), &object, client.PropagationPolicy(metav1.DeletePropagationBackground))
-			if err == nil {
-				if listenerErr := h.customizer.Listener().ResourceDeleted(&object); listenerErr != nil {
-					log.Errorf("error calling listener: %s", err)
-				}
-			} else {
-				if listenerErr := h.customizer.Listener().ResourceError(&object, err); listenerErr != nil {
-					log.Errorf("error calling listener: %s", err)
-				}
-				allErrors = append(allErrors, err)
+		} else {
+			if listenerErr := h.customizer.Listener().ResourceError(&object, err); listenerErr != nil {
+				log.Errorf("error calling listener: %s", err)
 			}
+			allErrors = append(allErrors, err)
+			out.failed = append(out.failed, object.GroupVersionKind())
+			out.kept = append(out.kept, inventory.RefForUnstructured(&object))
+		}
+	}
+	return out, utilerrors.NewAggregate(allErrors)
+}
+
+// scanCandidates returns the resources of types, and the types recorded by previous reconciles, which are managed by
+// the operator and are not in the current manifests, in the order they should be deleted. If all is set to true, it
+// returns all the managed resources.
+func (h *HelmReconciler) scanCandidates(types []schema.GroupVersionKind, all bool) []unstructured.Unstructured {
+	var out []unstructured.Unstructured
+	targetNamespace := h.customizer.Input().GetTargetNamespace()
+	for _, rt := range h.pruneTypes(types) {
+		namespace := ""
+		if rt.namespaced {
+			namespace = targetNamespace
 		}
+		out = append(out, h.pruneCandidates(rt.gvk, all, namespace)...)
+	}
+	return out
+}
+
+// pruneCandidates returns the resources of type gvk in namespace which are managed by the operator and are not in the
+// current manifests. If all is set to true, it returns all the managed resources.
+func (h *HelmReconciler) pruneCandidates(gvk schema.GroupVersionKind, all bool, namespace string) []unstructured.Unstructured {
+	ownerLabels := h.customizer.PruningDetails().GetOwnerLabels()
+	ownerAnnotations := h.customizer.PruningDetails().GetOwnerAnnotations()
+	objects := &unstructured.UnstructuredList{}
+	// The cached client only lists types with a List kind.
+	objects.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	err := h.client.List(context.TODO(), objects, client.MatchingLabels(ownerLabels), client.InNamespace(namespace))
+	if err != nil {
+		// Types which are not served were dropped using discovery, if available, so this is unexpected.
+		log.Warnf("retrieving resources to prune type %s: %s", gvk.String(), err)
+		return nil
+	}
+	var out []unstructured.Unstructured
+objectLoop:
+	for _, object := range objects.Items {
+		annotations := object.GetAnnotations()
+		for ownerKey, ownerValue := range ownerAnnotations {
+			// we only want to delete objects that contain the annotations
+			// if we're not pruning all objects, we only want to prune those whose annotation value does not match what is expected
+			if value, ok := annotations[ownerKey]; !ok || (!all && value == ownerValue) {
+				continue objectLoop
+			}
+		}
+		out = append(out, object)
+	}
+	return out
+}
+
+// backup writes objects to a new backup Secret and returns its namespace and name. It fails if the namespace of the
+// backup is one of objects, since the backup would be deleted along with it.
+func (h *HelmReconciler) backup(objects []unstructured.Unstructured, opts PruneOptions) (string, error) {
+	for _, o := range objects {
+		if o.GroupVersionKind().GroupKind() == namespaceGroupKind && o.GetName() == opts.BackupNamespace {
+			return "", fmt.Errorf("the backup namespace %s is being pruned", opts.BackupNamespace)
+		}
+	}
+	secret, err := prune.BackupSecret(opts.BackupNamespace, prune.BackupName(opts.BackupPrefix), objects)
+	if err != nil {
+		return "", err
+	}
+	if err := h.client.Create(context.TODO(), secret); err != nil {
+		return "", err
+	}
+	key := client.ObjectKey{Namespace: secret.Namespace, Name: secret.Name}.String()
+	log.Infof("backed up %d resources to Secret %s before pruning them", len(objects), key)
+	return key, nil
+}
+
+// splitRetained splits objects into the ones which are deleted and the ones which are retained by opts when the custom
+// resource is deleted. The Namespaces of the retained objects are retained too, since deleting them would delete the
+// objects.
+func splitRetained(objects []unstructured.Unstructured, opts PruneOptions) (deleted, retained []unstructured.Unstructured) {
+	namespaces := make(map[string]bool)
+	for _, o := range objects {
+		if opts.retains(o.GroupVersionKind().GroupKind()) && o.GetNamespace() != "" {
+			namespaces[o.GetNamespace()] = true
+		}
+	}
+	for _, o := range objects {
+		gk := o.GroupVersionKind().GroupKind()
+		if opts.retains(gk) || (gk == namespaceGroupKind && namespaces[o.GetName()]) {
+			retained = append(retained, o)
+		} else {
+			deleted = append(deleted, o)
+		}
+	}
+	return deleted, retained
+}
+
+// detach removes the owner references to the custom resource, and the owner labels and annotations, from object, so
+// that it is neither garbage collected nor pruned once the custom resource is deleted.
+func (h *HelmReconciler) detach(object *unstructured.Unstructured) error {
+	if owner, err := meta.Accessor(h.instance); err == nil {
+		var refs []metav1.OwnerReference
+		for _, ref := range object.GetOwnerReferences() {
+			if ref.UID != owner.GetUID() {
+				refs = append(refs, ref)
+			}
+		}
+		object.SetOwnerReferences(refs)
+	}
+	labels := object.GetLabels()
+	for key := range h.customizer.PruningDetails().GetOwnerLabels() {
+		delete(labels, key)
+	}
+	object.SetLabels(labels)
+	annotations := object.GetAnnotations()
+	for key := range h.customizer.PruningDetails().GetOwnerAnnotations() {
+		delete(annotations, key)
 	}
-	return utilerrors.NewAggregate(allErrors)
+	object.SetAnnotations(annotations)
+	return h.client.Update(context.TODO(), object)
 }
@@ -0,0 +1,54 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"istio.io/operator/pkg/inventory"
+	"istio.io/operator/pkg/readiness"
+)
+
+// WaitForReady waits up to the ReadinessTimeout of the Factory for the objects referred to in refs to be ready, using
+// the checks in the readiness registry. It returns immediately if the timeout is 0.
+func (h *HelmReconciler) WaitForReady(refs []inventory.Ref) error {
+	if h.readinessTimeout == 0 || len(refs) == 0 {
+		return nil
+	}
+	return readiness.Wait(refs, h.getObject, readiness.DefaultPollInterval, h.readinessTimeout)
+}
+
+// getObject is a readiness.Getter which gets objects with the client of h, using the version of their kind which is
+// served by the API server.
+func (h *HelmReconciler) getObject(gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	rt, ok := h.resolveType(gvk)
+	if !ok {
+		return nil, nil
+	}
+	o := &unstructured.Unstructured{}
+	o.SetGroupVersionKind(rt.gvk)
+	if err := h.client.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, o); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return o, nil
+}
@@ -15,13 +15,18 @@
 package helmreconciler
 
 import (
+	"fmt"
 	"sync"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/inventory"
 	"istio.io/operator/pkg/name"
 	"istio.io/operator/pkg/util"
 	"istio.io/pkg/log"
@@ -31,14 +36,23 @@ import (
 // or deletes all resources associated with a specific instance of a custom resource.
 type HelmReconciler struct {
 	client     client.Client
+	restMapper meta.RESTMapper
 	customizer RenderingCustomizer
 	instance   runtime.Object
+
+	readinessTimeout time.Duration
 }
 
 // Factory is a factory for creating HelmReconciler objects using the specified CustomizerFactory.
 type Factory struct {
 	// CustomizerFactory is a factory for creating the Customizer object for the HelmReconciler.
 	CustomizerFactory RenderingCustomizerFactory
+	// RESTMapper is used to find the types served by the API server when pruning. If nil, all rendered and recorded
+	// types are assumed to be served.
+	RESTMapper meta.RESTMapper
+	// ReadinessTimeout is how long to wait for the objects of a chart to be ready before the charts which depend on it
+	// are applied. If 0, dependent charts are applied as soon as the chart has been applied, without waiting.
+	ReadinessTimeout time.Duration
 }
 
 // New Returns a new HelmReconciler for the custom resource.
@@ -54,7 +68,8 @@ func (f *Factory) New(instance runtime.Object, client client.Client) (*HelmRecon
 	if err != nil {
 		return nil, err
 	}
-	reconciler := &HelmReconciler{client: client, customizer: wrappedcustomizer, instance: instance}
+	reconciler := &HelmReconciler{client: client, restMapper: f.RESTMapper, customizer: wrappedcustomizer, instance: instance,
+		readinessTimeout: f.ReadinessTimeout}
 	wrappedcustomizer.RegisterReconciler(reconciler)
 	return reconciler, nil
 }
@@ -106,27 +121,69 @@ func (h *HelmReconciler) Reconcile() error {
 	//	}
 	//	manifestMap[chartName] = newManifests
 	//}
-	status := h.processRecursive(manifestMap)
+	rendered, err := ResourceTypes(manifestMap)
+	if err != nil {
+		return err
+	}
+	renderedInventory, err := h.renderedInventory(manifestMap)
+	if err != nil {
+		return err
+	}
+	recordedInventory, err := h.loadInventory()
+	if err != nil {
+		return err
+	}
+	status, err := h.processRecursive(manifestMap, renderedInventory, recordedInventory)
+	if err != nil {
+		return err
+	}
 
 	// Delete any resources not in the manifest but managed by operator.
 	var errs util.Errors
 	errs = util.AppendErr(errs, h.customizer.Listener().BeginPrune(false))
-	errs = util.AppendErr(errs, h.Prune(false))
+	pruned, err := h.prune(renderedInventory, rendered, false)
+	errs = util.AppendErr(errs, err)
 	errs = util.AppendErr(errs, h.customizer.Listener().EndPrune())
+	status.PruneSkipped = pruned.skipped
+	status.PruneBackup = pruned.backup
+
+	// Record the applied types, and any types which could not be pruned, so they are pruned even if they are no
+	// longer rendered.
+	recorded := append(rendered, pruned.failed...)
+	sortGVKs(recorded)
+	for i, gvk := range recorded {
+		if i == 0 || gvk != recorded[i-1] {
+			status.ResourceTypes = append(status.ResourceTypes, FormatResourceType(gvk))
+		}
+	}
 
 	errs = util.AppendErr(errs, h.customizer.Listener().EndReconcile(h.instance, status))
 
 	return errs.ToError()
 }
 
-// processRecursive processes the given manifests in an order of dependencies defined in h. Dependencies are a tree,
-// where a child must wait for the parent to complete before starting.
-func (h *HelmReconciler) processRecursive(manifests ChartManifestsMap) *v1alpha2.InstallStatus {
-	deps, dch := h.customizer.Input().GetProcessingOrder(manifests)
+// processRecursive processes the given manifests in the order of the dependency graph returned by the input. A chart
+// is only processed once its parent has been applied and is ready, and charts whose parent failed are blocked rather
+// than processed. Charts which are unchanged since the instance was last reconciled are skipped, using the inventory
+// of the rendered objects, rendered, and the inventory recorded when the instance was last reconciled, recorded.
+func (h *HelmReconciler) processRecursive(manifests ChartManifestsMap, rendered, recorded inventory.Inventory) (*v1alpha2.InstallStatus, error) {
+	graph, err := h.customizer.Input().GetProcessingOrder(manifests)
+	if err != nil {
+		return nil, fmt.Errorf("invalid componentDependencies: %s", err)
+	}
 	out := &v1alpha2.InstallStatus{Status: make(map[string]*v1alpha2.InstallStatus_VersionStatus)}
+	prev := h.previousStatus()
+
+	// done is closed when the chart with the given name has been processed.
+	done := make(map[name.ComponentName]chan struct{})
+	for c := range manifests {
+		done[name.ComponentName(c)] = make(chan struct{})
+	}
 
-	// mu protects the shared InstallStatus out across goroutines
+	// mu protects the shared InstallStatus out and failed across goroutines
 	var mu sync.Mutex
+	// failed is the set of charts which failed or are blocked.
+	failed := make(map[name.ComponentName]bool)
 	// wg waits for all manifest processing goroutines to finish
 	var wg sync.WaitGroup
 
@@ -136,9 +193,27 @@ func (h *HelmReconciler) processRecursive(manifests ChartManifestsMap) *v1alpha2
 		go func() {
 			defer wg.Done()
 			cn := name.ComponentName(c)
-			if s := dch[cn]; s != nil {
-				log.Infof("%s is waiting on dependency...", c)
-				<-s
+			defer close(done[cn])
+			if p, ok := graph.Parent(cn); ok && done[p] != nil {
+				log.Infof("%s is waiting on dependency %s...", c, p)
+				<-done[p]
+				mu.Lock()
+				parentFailed := failed[p]
+				if parentFailed {
+					failed[cn] = true
+					if len(rendered[c]) != 0 {
+						out.Status[c] = &v1alpha2.InstallStatus_VersionStatus{
+							Status:       v1alpha2.InstallStatus_BLOCKED,
+							StatusString: v1alpha2.InstallStatus_Status_name[int32(v1alpha2.InstallStatus_BLOCKED)],
+							Error:        fmt.Sprintf("not applied because %s, which it depends on, failed", p),
+						}
+					}
+				}
+				mu.Unlock()
+				if parentFailed {
+					log.Infof("%s is blocked because %s failed.", c, p)
+					return
+				}
 				log.Infof("Dependency for %s has completed, proceeding.", c)
 			}
 
@@ -152,9 +227,12 @@ func (h *HelmReconciler) processRecursive(manifests ChartManifestsMap) *v1alpha2
 			mu.Unlock()
 
 			// Process manifests and get the status result
-			errString := ""
+			errString, objectsHash := "", ""
 			if len(m) == 0 {
 				status = v1alpha2.InstallStatus_NONE
+			} else if hash, ok := h.unchanged(c, m, prev[c], rendered, recorded); ok {
+				log.Infof("Skipping %s, which is unchanged since it was last applied.", c)
+				status, objectsHash = v1alpha2.InstallStatus_HEALTHY, hash
 			} else {
 				status = v1alpha2.InstallStatus_HEALTHY
 				if cnt, err := h.ProcessManifest(m[0]); err != nil {
@@ -162,11 +240,23 @@ func (h *HelmReconciler) processRecursive(manifests ChartManifestsMap) *v1alpha2
 					status = v1alpha2.InstallStatus_ERROR
 				} else if cnt == 0 {
 					status = v1alpha2.InstallStatus_NONE
+				} else if hash, ok, err := h.objectsHash(rendered[c]); err == nil && ok {
+					objectsHash = hash
+				}
+			}
+			// The charts which depend on this one are only processed once its objects are ready.
+			if status == v1alpha2.InstallStatus_HEALTHY && len(graph.Children(cn)) != 0 {
+				if err := h.WaitForReady(rendered[c]); err != nil {
+					errString = err.Error()
+					status = v1alpha2.InstallStatus_ERROR
 				}
 			}
 
 			// Update status based on the result
 			mu.Lock()
+			if status == v1alpha2.InstallStatus_ERROR {
+				failed[cn] = true
+			}
 			if status == v1alpha2.InstallStatus_NONE {
 				delete(out.Status, c)
 			} else {
@@ -175,23 +265,22 @@ func (h *HelmReconciler) processRecursive(manifests ChartManifestsMap) *v1alpha2
 				if errString != "" {
 					out.Status[c].Error = errString
 				}
+				if status == v1alpha2.InstallStatus_HEALTHY {
+					out.Status[c].ManifestHash = manifestHash(m)
+					out.Status[c].ObjectsHash = objectsHash
+				}
 			}
 			mu.Unlock()
-
-			// Signal all the components that depend on us.
-			for _, ch := range deps[cn] {
-				log.Infof("Unblocking dependency %s.", ch)
-				dch[ch] <- struct{}{}
-			}
 		}()
 	}
 	wg.Wait()
 
-	return out
+	return out, nil
 }
 
-// Delete resources associated with the custom resource instance
-func (h *HelmReconciler) Delete() error {
+// Delete resources associated with the custom resource instance. The resources retained by the prune options are
+// detached from the instance instead, and their descriptions are returned.
+func (h *HelmReconciler) Delete() ([]string, error) {
 	allErrors := []error{}
 
 	// any processing required before processing the charts
@@ -204,7 +293,15 @@ func (h *HelmReconciler) Delete() error {
 	if err != nil {
 		allErrors = append(allErrors, err)
 	}
-	err = h.Prune(true)
+	// The resources in the inventory are deleted. If there is no inventory, resources of the types rendered for the
+	// current spec are deleted along with the recorded types, in case the spec has changed since it was last reconciled.
+	var rendered []schema.GroupVersionKind
+	if manifestMap, err := h.renderCharts(h.customizer.Input()); err == nil {
+		rendered, _ = ResourceTypes(manifestMap)
+	} else {
+		log.Warnf("could not render charts to find resource types to delete: %s", err)
+	}
+	result, err := h.prune(nil, rendered, true)
 	if err != nil {
 		allErrors = append(allErrors, err)
 	}
@@ -220,7 +317,7 @@ func (h *HelmReconciler) Delete() error {
 	}
 
 	// return any errors
-	return err
+	return result.retained, err
 }
 
 // GetClient returns the kubernetes client associated with this HelmReconciler
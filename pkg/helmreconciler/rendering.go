@@ -51,15 +51,35 @@ func (h *HelmReconciler) renderCharts(in RenderingInput) (ChartManifestsMap, err
 		return nil, err
 	}
 
-	mergedICPS, err := mergeICPSWithProfile(icpSpec)
+	t, err := translate.NewTranslator(binversion.OperatorBinaryVersion.MinorVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	t, err := translate.NewTranslator(binversion.OperatorBinaryVersion.MinorVersion)
+	icpSpecYAML, err := util.MarshalWithJSONPB(icpSpec)
+	if err != nil {
+		return nil, err
+	}
+	deprecated, err := validate.CheckDeprecations(icpSpecYAML, t)
 	if err != nil {
 		return nil, err
 	}
+	for _, d := range deprecated {
+		log.Warnf("%s", d)
+	}
+
+	mergedICPS, err := mergeICPSWithProfile(icpSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := validate.CheckSemantics(mergedICPS, icpSpec)
+	for _, w := range findings.Warnings() {
+		log.Warnf("%s", w)
+	}
+	if errs := findings.Errors(); len(errs) != 0 {
+		return nil, fmt.Errorf("IstioControlPlane %s/%s failed semantic validation:\n%s", icp.Namespace, icp.Name, errs)
+	}
 
 	cp := controlplane.NewIstioControlPlane(mergedICPS, t)
 	if err := cp.Run(); err != nil {
@@ -74,6 +94,16 @@ func (h *HelmReconciler) renderCharts(in RenderingInput) (ChartManifestsMap, err
 	return toChartManifestsMap(manifests), err
 }
 
+// MergedSpec returns the spec of the instance overlaid on top of the defaults for its profile, which gives the
+// effective values of fields such as tag which the instance may leave to the profile.
+func (h *HelmReconciler) MergedSpec() (*v1alpha2.IstioControlPlaneSpec, error) {
+	icp, ok := h.customizer.Input().GetInputConfig().(*v1alpha2.IstioControlPlane)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type %T in MergedSpec", h.customizer.Input().GetInputConfig())
+	}
+	return mergeICPSWithProfile(icp.GetSpec())
+}
+
 // mergeICPSWithProfile overlays the values in icp on top of the defaults for the profile given by icp.profile and
 // returns the merged result.
 func mergeICPSWithProfile(icp *v1alpha2.IstioControlPlaneSpec) (*v1alpha2.IstioControlPlaneSpec, error) {
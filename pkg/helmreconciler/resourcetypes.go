@@ -0,0 +1,240 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"istio.io/operator/pkg/object"
+	"istio.io/pkg/log"
+)
+
+// resourceType is a type of resource that is checked for pruning.
+type resourceType struct {
+	gvk        schema.GroupVersionKind
+	namespaced bool
+}
+
+var (
+	// kindDependencies maps a kind to the kinds that objects of the kind may depend on. Objects are deleted before
+	// the objects they depend on, e.g. webhook configurations before the services that back them, so that the control
+	// plane keeps working while it is being pruned. Workloads depend on CRDs since the control plane components watch
+	// the custom resources.
+	kindDependencies = map[string][]string{
+		"Deployment":                     {"ServiceAccount", "ConfigMap", "Secret", "PersistentVolumeClaim", "CustomResourceDefinition"},
+		"StatefulSet":                    {"ServiceAccount", "ConfigMap", "Secret", "PersistentVolumeClaim", "CustomResourceDefinition"},
+		"DaemonSet":                      {"ServiceAccount", "ConfigMap", "Secret", "CustomResourceDefinition"},
+		"Job":                            {"ServiceAccount", "ConfigMap", "Secret"},
+		"Pod":                            {"ServiceAccount", "ConfigMap", "Secret", "PersistentVolumeClaim"},
+		"HorizontalPodAutoscaler":        {"Deployment", "StatefulSet"},
+		"PodDisruptionBudget":            {"Deployment", "StatefulSet", "DaemonSet"},
+		"Ingress":                        {"Service"},
+		"Endpoints":                      {"Service"},
+		"MutatingWebhookConfiguration":   {"Service"},
+		"ValidatingWebhookConfiguration": {"Service"},
+		"APIService":                     {"Service"},
+		"RoleBinding":                    {"Role", "ClusterRole", "ServiceAccount"},
+		"ClusterRoleBinding":             {"ClusterRole", "ServiceAccount"},
+	}
+
+	// builtinGroups are the API groups served by Kubernetes itself. Resources in other groups are custom resources,
+	// which are deleted before their CustomResourceDefinitions.
+	builtinGroups = map[string]bool{
+		"":                             true,
+		"admissionregistration.k8s.io": true,
+		"apiextensions.k8s.io":         true,
+		"apiregistration.k8s.io":       true,
+		"apps":                         true,
+		"autoscaling":                  true,
+		"batch":                        true,
+		"certificates.k8s.io":          true,
+		"coordination.k8s.io":          true,
+		"extensions":                   true,
+		"networking.k8s.io":            true,
+		"policy":                       true,
+		"rbac.authorization.k8s.io":    true,
+		"scheduling.k8s.io":            true,
+		"storage.k8s.io":               true,
+	}
+)
+
+// ResourceTypes returns the types of the objects in manifests.
+func ResourceTypes(manifests ChartManifestsMap) ([]schema.GroupVersionKind, error) {
+	seen := make(map[schema.GroupVersionKind]bool)
+	var out []schema.GroupVersionKind
+	for _, ms := range manifests {
+		for _, m := range ms {
+			objects, err := object.ParseK8sObjectsFromYAMLManifest(m.Content)
+			if err != nil {
+				return nil, err
+			}
+			for _, o := range objects {
+				if gvk := o.GroupVersionKind(); !seen[gvk] {
+					seen[gvk] = true
+					out = append(out, gvk)
+				}
+			}
+		}
+	}
+	sortGVKs(out)
+	return out, nil
+}
+
+// FormatResourceType returns gvk in apiVersion/kind form, e.g. apps/v1/Deployment or v1/Service.
+func FormatResourceType(gvk schema.GroupVersionKind) string {
+	return gvk.GroupVersion().String() + "/" + gvk.Kind
+}
+
+// ParseResourceType parses a type in the form returned by FormatResourceType.
+func ParseResourceType(s string) (schema.GroupVersionKind, error) {
+	i := strings.LastIndex(s, "/")
+	if i < 0 {
+		return schema.GroupVersionKind{}, fmt.Errorf("resource type %s is not in apiVersion/kind form", s)
+	}
+	gv, err := schema.ParseGroupVersion(s[:i])
+	if err != nil {
+		return schema.GroupVersionKind{}, fmt.Errorf("resource type %s: %s", s, err)
+	}
+	return gv.WithKind(s[i+1:]), nil
+}
+
+// pruneTypes returns the types to check for pruning, in the order their objects should be deleted. These are the
+// rendered types and the types recorded by previous reconciles, which may include versions the API server no longer
+// serves. If h has a RESTMapper, each type is resolved to a served version, types the API server does not know are
+// dropped and the scope of each type is taken from discovery. Otherwise the types are used as they are.
+func (h *HelmReconciler) pruneTypes(rendered []schema.GroupVersionKind) []resourceType {
+	byGK := make(map[schema.GroupKind]resourceType)
+	for _, gvk := range append(append([]schema.GroupVersionKind{}, rendered...), h.customizer.PruningDetails().GetResourceTypes()...) {
+		if _, ok := byGK[gvk.GroupKind()]; ok {
+			continue
+		}
+		rt, ok := h.resolveType(gvk)
+		if !ok {
+			continue
+		}
+		byGK[gvk.GroupKind()] = rt
+	}
+	return deleteOrder(byGK)
+}
+
+// resolveType returns the served version and scope of gvk and whether the API server serves its kind.
+func (h *HelmReconciler) resolveType(gvk schema.GroupVersionKind) (resourceType, bool) {
+	if h.restMapper == nil {
+		return resourceType{gvk: gvk, namespaced: !object.IsClusterScoped(gvk.Kind)}, true
+	}
+	mapping, err := h.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		// The recorded version may no longer be served, use the preferred version instead.
+		mapping, err = h.restMapper.RESTMapping(gvk.GroupKind())
+	}
+	if err != nil {
+		if !meta.IsNoMatchError(err) {
+			log.Warnf("could not get the API server mapping for %s: %s", gvk, err)
+		} else {
+			log.Debugf("skipping type %s which is not served by the API server", gvk)
+		}
+		return resourceType{}, false
+	}
+	return resourceType{
+		gvk:        mapping.GroupVersionKind,
+		namespaced: mapping.Scope.Name() == meta.RESTScopeNameNamespace,
+	}, true
+}
+
+// deleteOrder returns the types in types ordered so that objects of a type are deleted before the objects of the
+// types they depend on. Types with no dependency between them are ordered by group and kind.
+func deleteOrder(types map[schema.GroupKind]resourceType) []resourceType {
+	// dependents counts the remaining types which depend on each type.
+	dependents := make(map[schema.GroupKind]int)
+	for gk := range types {
+		for dep := range types {
+			if dependsOn(gk, dep) {
+				dependents[dep]++
+			}
+		}
+	}
+
+	var out []resourceType
+	remaining := sortedGroupKinds(types)
+	for len(remaining) != 0 {
+		next := -1
+		for i, gk := range remaining {
+			if dependents[gk] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			// Dependency cycle, the remaining types are deleted in name order.
+			next = 0
+		}
+		gk := remaining[next]
+		remaining = append(remaining[:next], remaining[next+1:]...)
+		for dep := range types {
+			if dependsOn(gk, dep) {
+				dependents[dep]--
+			}
+		}
+		out = append(out, types[gk])
+	}
+	return out
+}
+
+// dependsOn reports whether objects of type a may depend on objects of type b.
+func dependsOn(a, b schema.GroupKind) bool {
+	if a == b {
+		return false
+	}
+	switch {
+	case b.Kind == "CustomResourceDefinition" && !builtinGroups[a.Group]:
+		return true
+	case b.Kind == "Namespace" && b.Group == "":
+		return a.Kind != "Namespace" && !object.IsClusterScoped(a.Kind)
+	}
+	if !builtinGroups[a.Group] || !builtinGroups[b.Group] {
+		return false
+	}
+	for _, k := range kindDependencies[a.Kind] {
+		if k == b.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedGroupKinds(types map[schema.GroupKind]resourceType) []schema.GroupKind {
+	var out []schema.GroupKind
+	for gk := range types {
+		out = append(out, gk)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Group != out[j].Group {
+			return out[i].Group < out[j].Group
+		}
+		return out[i].Kind < out[j].Kind
+	})
+	return out
+}
+
+func sortGVKs(gvks []schema.GroupVersionKind) {
+	sort.Slice(gvks, func(i, j int) bool {
+		return FormatResourceType(gvks[i]) < FormatResourceType(gvks[j])
+	})
+}
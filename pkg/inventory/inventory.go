@@ -0,0 +1,186 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package inventory records references to the objects applied for each component of an installation, so that the
+objects which are no longer rendered can be found for pruning, uninstall and drift checks without listing every type
+in every namespace of the cluster, and even if their labels have been changed.
+
+An inventory is stored in a ConfigMap with a key for each component. The value of each key is the sorted list of
+references to the objects of the component, one per line, e.g.
+
+	apps/v1/Deployment istio-system/istio-pilot
+	rbac.authorization.k8s.io/v1/ClusterRole istio-pilot-istio-system
+*/
+package inventory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"istio.io/operator/pkg/object"
+)
+
+// Ref is a reference to an applied object.
+type Ref struct {
+	GroupVersionKind schema.GroupVersionKind
+	// Namespace is empty for cluster scoped objects.
+	Namespace string
+	Name      string
+}
+
+// Inventory is the references to the objects applied for each component, keyed by component name.
+type Inventory map[string][]Ref
+
+// RefForObject returns a reference to o.
+func RefForObject(o *object.K8sObject) Ref {
+	return Ref{GroupVersionKind: o.GroupVersionKind(), Namespace: o.Namespace, Name: o.Name}
+}
+
+// RefForUnstructured returns a reference to u.
+func RefForUnstructured(u *unstructured.Unstructured) Ref {
+	return Ref{GroupVersionKind: u.GroupVersionKind(), Namespace: u.GetNamespace(), Name: u.GetName()}
+}
+
+// String returns r in the form stored in an inventory, e.g. apps/v1/Deployment istio-system/istio-pilot.
+func (r Ref) String() string {
+	gvk := r.GroupVersionKind.GroupVersion().String() + "/" + r.GroupVersionKind.Kind
+	if r.Namespace == "" {
+		return gvk + " " + r.Name
+	}
+	return gvk + " " + r.Namespace + "/" + r.Name
+}
+
+// ParseRef parses a reference in the form returned by Ref.String.
+func ParseRef(s string) (Ref, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Ref{}, fmt.Errorf("object reference %q is not in apiVersion/kind namespace/name form", s)
+	}
+	i := strings.LastIndex(fields[0], "/")
+	if i < 0 {
+		return Ref{}, fmt.Errorf("object reference %q has no kind", s)
+	}
+	gv, err := schema.ParseGroupVersion(fields[0][:i])
+	if err != nil {
+		return Ref{}, fmt.Errorf("object reference %q: %s", s, err)
+	}
+	out := Ref{GroupVersionKind: gv.WithKind(fields[0][i+1:]), Name: fields[1]}
+	if nn := strings.SplitN(fields[1], "/", 2); len(nn) == 2 {
+		out.Namespace, out.Name = nn[0], nn[1]
+	}
+	return out, nil
+}
+
+// Key returns a key which identifies the object r refers to regardless of the API version, since the same object is
+// served at every version of its kind.
+func (r Ref) Key() string {
+	return r.GroupVersionKind.Group + "/" + object.Hash(r.GroupVersionKind.Kind, r.Namespace, r.Name)
+}
+
+// Unstructured returns an object with the type, namespace and name of r, which can be used to get or delete the
+// object.
+func (r Ref) Unstructured() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(r.GroupVersionKind)
+	u.SetNamespace(r.Namespace)
+	u.SetName(r.Name)
+	return u
+}
+
+// FromConfigMap returns the inventory stored in cm.
+func FromConfigMap(cm *corev1.ConfigMap) (Inventory, error) {
+	out := make(Inventory)
+	for component, refs := range cm.Data {
+		var rs []Ref
+		for _, s := range strings.Split(refs, "\n") {
+			if strings.TrimSpace(s) == "" {
+				continue
+			}
+			r, err := ParseRef(s)
+			if err != nil {
+				return nil, fmt.Errorf("component %s: %s", component, err)
+			}
+			rs = append(rs, r)
+		}
+		out.Set(component, rs)
+	}
+	return out, nil
+}
+
+// ToConfigMap stores inv in the data of cm, replacing any inventory already stored.
+func (inv Inventory) ToConfigMap(cm *corev1.ConfigMap) {
+	cm.Data = make(map[string]string)
+	for component, refs := range inv {
+		var sb strings.Builder
+		for _, r := range refs {
+			sb.WriteString(r.String() + "\n")
+		}
+		cm.Data[component] = sb.String()
+	}
+}
+
+// Set records refs as the objects of component, sorted and without duplicates. The component is removed if refs is
+// empty.
+func (inv Inventory) Set(component string, refs []Ref) {
+	refs = unique(refs)
+	if len(refs) == 0 {
+		delete(inv, component)
+		return
+	}
+	inv[component] = refs
+}
+
+// All returns the references to the objects of all components, sorted and without duplicates.
+func (inv Inventory) All() []Ref {
+	var out []Ref
+	for _, refs := range inv {
+		out = append(out, refs...)
+	}
+	return unique(out)
+}
+
+// Subtract returns the references in refs to objects which are not referred to in remove.
+func Subtract(refs, remove []Ref) []Ref {
+	removed := make(map[string]bool)
+	for _, r := range remove {
+		removed[r.Key()] = true
+	}
+	var out []Ref
+	for _, r := range refs {
+		if !removed[r.Key()] {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// unique returns refs sorted and with references to the same object removed.
+func unique(refs []Ref) []Ref {
+	seen := make(map[string]bool)
+	var out []Ref
+	for _, r := range refs {
+		if !seen[r.Key()] {
+			seen[r.Key()] = true
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].String() < out[j].String() })
+	return out
+}
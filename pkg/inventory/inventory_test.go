@@ -0,0 +1,167 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	deployment = Ref{
+		GroupVersionKind: schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		Namespace:        "istio-system",
+		Name:             "istio-pilot",
+	}
+	deploymentV1beta1 = Ref{
+		GroupVersionKind: schema.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "Deployment"},
+		Namespace:        "istio-system",
+		Name:             "istio-pilot",
+	}
+	appsDeploymentV1beta1 = Ref{
+		GroupVersionKind: schema.GroupVersionKind{Group: "apps", Version: "v1beta1", Kind: "Deployment"},
+		Namespace:        "istio-system",
+		Name:             "istio-pilot",
+	}
+	service = Ref{
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Service"},
+		Namespace:        "istio-system",
+		Name:             "istio-pilot",
+	}
+	clusterRole = Ref{
+		GroupVersionKind: schema.GroupVersionKind{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+		Name:             "istio-pilot-istio-system",
+	}
+)
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		desc    string
+		in      string
+		want    Ref
+		wantErr bool
+	}{
+		{
+			desc: "namespaced",
+			in:   "apps/v1/Deployment istio-system/istio-pilot",
+			want: deployment,
+		},
+		{
+			desc: "core group",
+			in:   "v1/Service istio-system/istio-pilot",
+			want: service,
+		},
+		{
+			desc: "cluster scoped",
+			in:   "rbac.authorization.k8s.io/v1/ClusterRole istio-pilot-istio-system",
+			want: clusterRole,
+		},
+		{
+			desc:    "no name",
+			in:      "apps/v1/Deployment",
+			wantErr: true,
+		},
+		{
+			desc:    "no kind",
+			in:      "Deployment istio-system/istio-pilot",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := ParseRef(tt.in)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("%s: got error %v, want error %v", tt.desc, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("%s: got %v, want %v", tt.desc, got, tt.want)
+			}
+			if got.String() != tt.in {
+				t.Errorf("%s: got string %s, want %s", tt.desc, got.String(), tt.in)
+			}
+		})
+	}
+}
+
+func TestConfigMap(t *testing.T) {
+	inv := make(Inventory)
+	inv.Set("Pilot", []Ref{service, deployment, clusterRole, deployment})
+	inv.Set("Base", nil)
+	cm := &corev1.ConfigMap{}
+	inv.ToConfigMap(cm)
+
+	want := map[string]string{
+		"Pilot": "apps/v1/Deployment istio-system/istio-pilot\n" +
+			"rbac.authorization.k8s.io/v1/ClusterRole istio-pilot-istio-system\n" +
+			"v1/Service istio-system/istio-pilot\n",
+	}
+	if !reflect.DeepEqual(cm.Data, want) {
+		t.Errorf("got ConfigMap data %v, want %v", cm.Data, want)
+	}
+	got, err := FromConfigMap(cm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, inv) {
+		t.Errorf("got inventory %v, want %v", got, inv)
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	tests := []struct {
+		desc   string
+		refs   []Ref
+		remove []Ref
+		want   []Ref
+	}{
+		{
+			desc:   "none removed",
+			refs:   []Ref{deployment, service},
+			remove: []Ref{clusterRole},
+			want:   []Ref{deployment, service},
+		},
+		{
+			desc:   "removed",
+			refs:   []Ref{deployment, service, clusterRole},
+			remove: []Ref{service},
+			want:   []Ref{deployment, clusterRole},
+		},
+		{
+			desc:   "same object at another version",
+			refs:   []Ref{deployment, service},
+			remove: []Ref{appsDeploymentV1beta1},
+			want:   []Ref{service},
+		},
+		{
+			desc:   "same kind in another group",
+			refs:   []Ref{deployment},
+			remove: []Ref{deploymentV1beta1},
+			want:   []Ref{deployment},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := Subtract(tt.refs, tt.remove); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s: got %v, want %v", tt.desc, got, tt.want)
+			}
+		})
+	}
+}
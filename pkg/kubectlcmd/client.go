@@ -172,6 +172,30 @@ func (c *Client) GetConfig(kubeconfig, context, name, namespace, output string,
 	return c.kubectl(subcmds, params)
 }
 
+// GetSecret runs the `kubectl get secret` command with parameters:
+// kubeconfig, context - used to identify the cluster
+// name - name of the secret to get
+// namespace - k8s namespace for kubectl command
+// output - output mode for kubectl
+// extraArgs - more args to be added to the kubectl command
+//
+// It returns stdout, stderr from the `kubectl` command as strings, and error for errors external to kubectl.
+func (c *Client) GetSecret(kubeconfig, context, name, namespace, output string,
+	extraArgs ...string) (string, string, error) {
+	subcmds := []string{"get", "secret", name}
+	params := &kubectlParams{
+		dryRun:     false,
+		verbose:    false,
+		kubeconfig: kubeconfig,
+		context:    context,
+		namespace:  namespace,
+		stdin:      "",
+		output:     output,
+		extraArgs:  extraArgs,
+	}
+	return c.kubectl(subcmds, params)
+}
+
 // kubectl runs the `kubectl` command by specifying subcommands in subcmds with kubectlParams
 func (c *Client) kubectl(subcmds []string, params *kubectlParams) (string, string, error) {
 	hasStdin := strings.TrimSpace(params.stdin) != ""
@@ -72,6 +72,12 @@ func (client *Client) GetIstioVersions(namespace string) ([]ComponentVersion, er
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve Istio pods, error: %v", err)
 	}
+	return IstioVersions(namespace, pods)
+}
+
+// IstioVersions returns the version of each Istio component in pods, the running Istio pods in namespace, taken from
+// the tags of their images.
+func IstioVersions(namespace string, pods *v1.PodList) ([]ComponentVersion, error) {
 	if len(pods.Items) == 0 {
 		return nil, fmt.Errorf("istio pod not found in namespace %v", namespace)
 	}
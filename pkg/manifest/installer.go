@@ -16,11 +16,9 @@ package manifest
 
 import (
 	"fmt"
-	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strings"
 	"sync"
 	"time"
 
@@ -35,25 +33,22 @@ import (
 	// For kubeclient GCP auth
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 
-	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
-	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
-	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
-	"k8s.io/apimachinery/pkg/util/wait"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
-	kubectlutil "k8s.io/kubectl/pkg/util/deployment"
-
+	"istio.io/operator/pkg/dependency"
+	"istio.io/operator/pkg/inventory"
 	"istio.io/operator/pkg/kubectlcmd"
 	"istio.io/operator/pkg/name"
+	"istio.io/operator/pkg/readiness"
 	"istio.io/operator/pkg/version"
 	"istio.io/pkg/log"
 )
@@ -87,58 +82,18 @@ type ComponentApplyOutput struct {
 	Err error
 	// Manifest is the manifest applied to the cluster.
 	Manifest string
+	// Blocked is set if the component was not applied because a component it depends on failed.
+	Blocked bool
 }
 
 type CompositeOutput map[name.ComponentName]*ComponentApplyOutput
 
-type componentNameToListMap map[name.ComponentName][]name.ComponentName
-type componentTree map[name.ComponentName]interface{}
-
-// deployment holds associated replicaSets for a deployment
-type deployment struct {
-	replicaSets *appsv1.ReplicaSet
-	deployment  *appsv1.Deployment
-}
-
 var (
-	componentDependencies = componentNameToListMap{
-		name.IstioBaseComponentName: {
-			name.PilotComponentName,
-			name.PolicyComponentName,
-			name.TelemetryComponentName,
-			name.GalleyComponentName,
-			name.CitadelComponentName,
-			name.NodeAgentComponentName,
-			name.CertManagerComponentName,
-			name.SidecarInjectorComponentName,
-			name.IngressComponentName,
-			name.EgressComponentName,
-			name.CNIComponentName,
-			name.PrometheusOperatorComponentName,
-			name.PrometheusComponentName,
-			name.GrafanaComponentName,
-			name.KialiComponentName,
-			name.TracingComponentName,
-		},
-	}
-
-	installTree      = make(componentTree)
-	dependencyWaitCh = make(map[name.ComponentName]chan struct{})
-	kubectl          = kubectlcmd.New()
+	kubectl = kubectlcmd.New()
 
 	k8sRESTConfig *rest.Config
 )
 
-func init() {
-	buildInstallTree()
-	for _, parent := range componentDependencies {
-		for _, child := range parent {
-			dependencyWaitCh[child] = make(chan struct{}, 1)
-		}
-	}
-
-}
-
 // ParseK8SYAMLToIstioControlPlaneSpec parses a IstioControlPlane CustomResource YAML string and unmarshals in into
 // an IstioControlPlaneSpec object. It returns the object and an API group/version with it.
 func ParseK8SYAMLToIstioControlPlaneSpec(yml string) (*v1alpha2.IstioControlPlaneSpec, *schema.GroupVersionKind, error) {
@@ -158,15 +113,21 @@ func ParseK8SYAMLToIstioControlPlaneSpec(yml string) (*v1alpha2.IstioControlPlan
 	return icp, &gvk, nil
 }
 
-// RenderToDir writes manifests to a local filesystem directory tree.
-func RenderToDir(manifests name.ManifestMap, outputDir string, dryRun bool) error {
-	logAndPrint("Component dependencies tree: \n%s", installTreeString())
+// RenderToDir writes manifests to a local filesystem directory tree, in which the directory of each component is in
+// the directory of the component it depends on. dependencies overrides the default component dependencies.
+func RenderToDir(manifests name.ManifestMap, dependencies map[string]string, outputDir string, dryRun bool) error {
+	graph, err := dependencyGraph(manifests, dependencies)
+	if err != nil {
+		return err
+	}
+	logAndPrint("Component dependencies tree: \n%s", graph)
 	logAndPrint("Rendering manifests to output dir %s", outputDir)
-	return renderRecursive(manifests, installTree, outputDir, dryRun)
+	return renderRecursive(manifests, graph, graph.Roots(), outputDir, dryRun)
 }
 
-func renderRecursive(manifests name.ManifestMap, installTree componentTree, outputDir string, dryRun bool) error {
-	for k, v := range installTree {
+func renderRecursive(manifests name.ManifestMap, graph *dependency.Graph, components []name.ComponentName,
+	outputDir string, dryRun bool) error {
+	for _, k := range components {
 		componentName := string(k)
 		ym := manifests[k]
 		if ym == "" {
@@ -188,12 +149,7 @@ func renderRecursive(manifests name.ManifestMap, installTree componentTree, outp
 			}
 		}
 
-		kt, ok := v.(componentTree)
-		if !ok {
-			// Leaf
-			return nil
-		}
-		if err := renderRecursive(manifests, kt, dirName, dryRun); err != nil {
+		if err := renderRecursive(manifests, graph, graph.Children(k), dirName, dryRun); err != nil {
 			return err
 		}
 	}
@@ -214,6 +170,17 @@ type InstallOptions struct {
 	Kubeconfig string
 	// Name of the kubeconfig context to use.
 	Context string
+	// PruneDryRun lists the resources which would be pruned instead of deleting them.
+	PruneDryRun bool
+	// PruneBackupDir is the directory that resources are backed up to before they are pruned. If empty, pruned
+	// resources are not backed up.
+	PruneBackupDir string
+	// InventoryNamespace is the namespace of the ConfigMap which records the objects applied for each component. If
+	// empty, istio-system is used.
+	InventoryNamespace string
+	// ComponentDependencies overrides the default component dependencies, as in the IstioControlPlaneSpec field of
+	// the same name.
+	ComponentDependencies map[string]string
 }
 
 // ApplyAll applies all given manifests using kubectl client.
@@ -223,40 +190,129 @@ func ApplyAll(manifests name.ManifestMap, version version.Version, opts *Install
 		logAndPrint("- %s", c)
 	}
 	logAndPrint("")
-	log.Infof("Component dependencies tree: \n%s", installTreeString())
 	if err := initK8SRestClient(opts.Kubeconfig, opts.Context); err != nil {
 		return nil, err
 	}
-	return applyRecursive(manifests, version, opts)
+	inv, err := loadInventory(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := addRemovedGateways(manifests, inv, opts); err != nil {
+		return nil, err
+	}
+	graph, err := dependencyGraph(manifests, opts.ComponentDependencies)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Component dependencies tree: \n%s", graph)
+	return applyRecursive(manifests, graph, version, inv, opts)
+}
+
+// addRemovedGateways adds an empty manifest to manifests for each named gateway component which has resources in the
+// cluster but is no longer in manifests, so that applying manifests deletes the gateway resources. The gateways are
+// taken from inv, or found by scanning the cluster if inv has no stored inventory.
+func addRemovedGateways(manifests name.ManifestMap, inv *installInventory, opts *InstallOptions) error {
+	if inv.found() {
+		for _, cn := range inv.components() {
+			if _, ok := manifests[cn]; ok || !name.IsGatewayComponentName(cn) {
+				continue
+			}
+			logAndPrint("Gateway component %s is no longer in the spec and will be removed.", cn)
+			manifests[cn] = ""
+		}
+		return nil
+	}
+	stdout, stderr, err := kubectl.GetAll(opts.Kubeconfig, opts.Context, "", "yaml",
+		"--all-namespaces", "--selector", istioComponentLabelStr)
+	if err != nil {
+		return fmt.Errorf("could not list installed components: %s\n%s", err, stderr)
+	}
+	items, err := GetKubectlGetItems(stdout)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		o, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		u := &unstructured.Unstructured{Object: o}
+		cn := name.ComponentName(u.GetLabels()[istioComponentLabelStr])
+		if _, ok := manifests[cn]; ok || !name.IsGatewayComponentName(cn) {
+			continue
+		}
+		logAndPrint("Gateway component %s is no longer in the spec and will be removed.", cn)
+		manifests[cn] = ""
+	}
+	return nil
+}
+
+// dependencyGraph returns the dependencies between the components in manifests, with the default dependencies
+// overridden by overrides.
+func dependencyGraph(manifests name.ManifestMap, overrides map[string]string) (*dependency.Graph, error) {
+	var components []name.ComponentName
+	for c := range manifests {
+		components = append(components, c)
+	}
+	graph, err := dependency.New(components, overrides)
+	if err != nil {
+		return nil, fmt.Errorf("invalid componentDependencies: %s", err)
+	}
+	return graph, nil
 }
 
-func applyRecursive(manifests name.ManifestMap, version version.Version, opts *InstallOptions) (CompositeOutput, error) {
+// applyRecursive applies manifests concurrently, applying each component once the component it depends on in graph
+// has been applied and is ready. Components which depend on a component which failed are blocked and not applied.
+func applyRecursive(manifests name.ManifestMap, graph *dependency.Graph, version version.Version,
+	inv *installInventory, opts *InstallOptions) (CompositeOutput, error) {
+	// done is closed for each component once it has been applied, or has failed.
+	done := make(map[name.ComponentName]chan struct{})
+	for c := range manifests {
+		done[c] = make(chan struct{})
+	}
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 	out := CompositeOutput{}
+	failed := make(map[name.ComponentName]bool)
 	allAppliedObjects := object.K8sObjects{}
 	for c, m := range manifests {
 		c := c
 		m := m
 		wg.Add(1)
 		go func() {
-			if s := dependencyWaitCh[c]; s != nil {
+			defer wg.Done()
+			defer close(done[c])
+			if p, ok := graph.Parent(c); ok {
 				log.Infof("%s is waiting on a prerequisite...", c)
-				<-s
+				<-done[p]
+				mu.Lock()
+				parentFailed := failed[p]
+				if parentFailed {
+					out[c] = &ComponentApplyOutput{
+						Err:     fmt.Errorf("component %s was not applied because %s, which it depends on, failed", c, p),
+						Blocked: true,
+					}
+					failed[c] = true
+				}
+				mu.Unlock()
+				if parentFailed {
+					logAndPrint("Component %s is blocked because %s failed", c, p)
+					return
+				}
 				log.Infof("Prerequisite for %s has completed, proceeding with install.", c)
 			}
-			applyOut, appliedObjects := applyManifest(c, m, version, opts)
+			applyOut, appliedObjects := applyManifest(c, m, version, inv, opts)
+			if applyOut.Err == nil && len(graph.Children(c)) != 0 && len(appliedObjects) != 0 {
+				// The components which depend on c are only applied once it is ready.
+				if err := waitForResources(appliedObjects, opts); err != nil {
+					applyOut.Err = fmt.Errorf("component %s was applied but is not ready: %s", c, err)
+				}
+			}
 			mu.Lock()
 			out[c] = applyOut
+			failed[c] = applyOut.Err != nil
 			allAppliedObjects = append(allAppliedObjects, appliedObjects...)
 			mu.Unlock()
-
-			// Signal all the components that depend on us.
-			for _, ch := range componentDependencies[c] {
-				log.Infof("unblocking child %s.", ch)
-				dependencyWaitCh[ch] <- struct{}{}
-			}
-			wg.Done()
 		}()
 	}
 	wg.Wait()
@@ -266,47 +322,25 @@ func applyRecursive(manifests name.ManifestMap, version version.Version, opts *I
 	return out, nil
 }
 
+// applyManifest applies the objects in manifestStr for componentName, prunes the objects of the component which are no
+// longer rendered, and records the objects of the component in inv.
 func applyManifest(componentName name.ComponentName, manifestStr string, version version.Version,
-	opts *InstallOptions) (*ComponentApplyOutput, object.K8sObjects) {
+	inv *installInventory, opts *InstallOptions) (*ComponentApplyOutput, object.K8sObjects) {
 	stdout, stderr := "", ""
 	appliedObjects := object.K8sObjects{}
 	objects, err := object.ParseK8sObjectsFromYAMLManifest(manifestStr)
 	if err != nil {
 		return buildComponentApplyOutput(stdout, stderr, appliedObjects, err), appliedObjects
 	}
-	componentLabel := fmt.Sprintf("%s=%s", istioComponentLabelStr, componentName)
 
-	// TODO: remove this when `kubectl --prune` supports empty objects
-	//  (https://github.com/kubernetes/kubernetes/issues/40635)
-	// Delete all resources for a disabled component
+	// Delete all resources for a disabled component. This also works around `kubectl --prune` not supporting empty
+	// objects (https://github.com/kubernetes/kubernetes/issues/40635).
 	if len(objects) == 0 {
-		extraArgsGet := []string{"--all-namespaces", "--selector", componentLabel}
-		stdoutGet, stderrGet, err := kubectl.GetAll(opts.Kubeconfig, opts.Context, "", "yaml", extraArgsGet...)
-		if err != nil {
-			stdout += "\n" + stdoutGet
-			stderr += "\n" + stderrGet
-			return buildComponentApplyOutput(stdout, stderr, appliedObjects, err), appliedObjects
+		var kept []inventory.Ref
+		stdout, kept, err = pruneComponent(componentName, "", nil, inv, opts)
+		if recordErr := inv.record(componentName, kept); err == nil {
+			err = recordErr
 		}
-		items, err := GetKubectlGetItems(stdoutGet)
-		if err != nil {
-			return buildComponentApplyOutput(stdout, stderr, appliedObjects, err), appliedObjects
-		}
-		if len(items) == 0 {
-			return buildComponentApplyOutput(stdout, stderr, appliedObjects, err), appliedObjects
-		}
-
-		delObjects, err := object.ParseK8sObjectsFromYAMLManifest(stdoutGet)
-		if err != nil {
-			return buildComponentApplyOutput(stdout, stderr, appliedObjects, err), appliedObjects
-		}
-		extraArgsDel := []string{"--selector", componentLabel}
-		stdoutDel, stderrDel, err := kubectl.Delete(opts.DryRun, opts.Verbose, opts.Kubeconfig, opts.Context, "", stdoutGet, extraArgsDel...)
-		stdout += "\n" + stdoutDel
-		stderr += "\n" + stderrDel
-		if err != nil {
-			return buildComponentApplyOutput(stdout, stderr, appliedObjects, err), appliedObjects
-		}
-		appliedObjects = append(appliedObjects, delObjects...)
 		return buildComponentApplyOutput(stdout, stderr, appliedObjects, err), appliedObjects
 	}
 
@@ -322,11 +356,9 @@ func applyManifest(componentName name.ComponentName, manifestStr string, version
 	}
 	objects.Sort(defaultObjectOrder())
 
+	// Resources are pruned by pruneComponent after applying, rather than by kubectl apply --prune, so that protected
+	// resources are kept and pruned resources can be backed up.
 	extraArgs := []string{"--force"}
-	// Base components include namespaces and CRDs, pruning them will remove user configs, which makes it hard to roll back.
-	if componentName != name.IstioBaseComponentName {
-		extraArgs = append(extraArgs, "--prune", "--selector", componentLabel)
-	}
 
 	logAndPrint("Applying manifest for component %s", componentName)
 
@@ -378,8 +410,29 @@ func applyManifest(componentName name.ComponentName, manifestStr string, version
 	stdoutNonNsCrd, stderrNonNsCrd, err := kubectl.Apply(opts.DryRun, opts.Verbose, opts.Kubeconfig, opts.Context, namespace, m, extraArgs...)
 	stdout += "\n" + stdoutNonNsCrd
 	stderr += "\n" + stderrNonNsCrd
-	logAndPrint("Finished applying manifest for component %s", componentName)
 	appliedObjects = append(appliedObjects, nonNsCrdObjects...)
+	if err != nil {
+		return buildComponentApplyOutput(stdout, stderr, appliedObjects, err), appliedObjects
+	}
+
+	// Base components include namespaces and CRDs, pruning them will remove user configs, which makes it hard to roll back.
+	// Their objects which are no longer rendered are kept in the inventory.
+	applied := appliedRefs(objects, namespace)
+	var kept []inventory.Ref
+	if componentName != name.IstioBaseComponentName {
+		var stdoutPrune string
+		stdoutPrune, kept, err = pruneComponent(componentName, namespace, objects, inv, opts)
+		stdout += "\n" + stdoutPrune
+	} else if refs, ok := inv.refs(componentName); ok {
+		kept = inventory.Subtract(refs, applied)
+	}
+	if recordErr := inv.record(componentName, append(applied, kept...)); err == nil {
+		err = recordErr
+	}
+	if err != nil {
+		return buildComponentApplyOutput(stdout, stderr, appliedObjects, err), appliedObjects
+	}
+	logAndPrint("Finished applying manifest for component %s", componentName)
 	return buildComponentApplyOutput(stdout, stderr, appliedObjects, err), appliedObjects
 }
 
@@ -486,6 +539,7 @@ func objectsNotInLists(objects object.K8sObjects, lists ...object.K8sObjects) ob
 	return ret
 }
 
+// waitForCRDs waits for the CRDs in objects to be established.
 func waitForCRDs(objects object.K8sObjects, dryRun bool) error {
 	if dryRun {
 		log.Info("Not waiting for CRDs in dry run mode.")
@@ -493,259 +547,70 @@ func waitForCRDs(objects object.K8sObjects, dryRun bool) error {
 	}
 
 	log.Info("Waiting for CRDs to be applied.")
-	cs, err := apiextensionsclient.NewForConfig(k8sRESTConfig)
-	if err != nil {
+	if err := initPruneClient(); err != nil {
 		return fmt.Errorf("k8s client error: %s", err)
 	}
-
-	var crdNames []string
+	var refs []inventory.Ref
 	for _, o := range cRDKindObjects(objects) {
-		crdNames = append(crdNames, o.Name)
+		refs = append(refs, inventory.RefForObject(o))
 	}
-
-	errPoll := wait.Poll(cRDPollInterval, cRDPollTimeout, func() (bool, error) {
-	descriptor:
-		for _, crdName := range crdNames {
-			crd, errGet := cs.ApiextensionsV1beta1().CustomResourceDefinitions().Get(crdName, metav1.GetOptions{})
-			if errGet != nil {
-				return false, errGet
-			}
-			for _, cond := range crd.Status.Conditions {
-				switch cond.Type {
-				case apiextensionsv1beta1.Established:
-					if cond.Status == apiextensionsv1beta1.ConditionTrue {
-						log.Infof("established CRD %q", crdName)
-						continue descriptor
-					}
-				case apiextensionsv1beta1.NamesAccepted:
-					if cond.Status == apiextensionsv1beta1.ConditionFalse {
-						log.Warnf("name conflict: %v", cond.Reason)
-					}
-				}
-			}
-			log.Infof("missing status condition for %q", crdName)
-			return false, nil
-		}
-		return true, nil
-	})
-
-	if errPoll != nil {
-		log.Errorf("failed to verify CRD creation; %s", errPoll)
-		return fmt.Errorf("failed to verify CRD creation: %s", errPoll)
+	if err := readiness.Wait(refs, getClusterObject, cRDPollInterval, cRDPollTimeout); err != nil {
+		log.Errorf("failed to verify CRD creation; %s", err)
+		return fmt.Errorf("failed to verify CRD creation: %s", err)
 	}
 
 	log.Info("Finished applying CRDs.")
 	return nil
 }
 
-// waitForResources polls to get the current status of all pods, PVCs, and Services
-// until all are ready or a timeout is reached
-// TODO - plumb through k8s client and remove global `k8sRESTConfig`
+// waitForResources waits up to opts.WaitTimeout for objects to be ready, using the checks in the readiness registry.
 func waitForResources(objects object.K8sObjects, opts *InstallOptions) error {
 	if opts.DryRun {
 		logAndPrint("Not waiting for resources ready in dry run mode.")
 		return nil
 	}
-
-	cs, err := kubernetes.NewForConfig(k8sRESTConfig)
-	if err != nil {
+	if err := initPruneClient(); err != nil {
 		return fmt.Errorf("k8s client error: %s", err)
 	}
 
-	errPoll := wait.Poll(2*time.Second, opts.WaitTimeout, func() (bool, error) {
-		pods := []v1.Pod{}
-		services := []v1.Service{}
-		deployments := []deployment{}
-		namespaces := []v1.Namespace{}
-
-		for _, o := range objects {
-			kind := o.GroupVersionKind().Kind
-			switch kind {
-			case "Namespace":
-				namespace, err := cs.CoreV1().Namespaces().Get(o.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				namespaces = append(namespaces, *namespace)
-			case "Pod":
-				pod, err := cs.CoreV1().Pods(o.Namespace).Get(o.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, *pod)
-			case "ReplicationController":
-				rc, err := cs.CoreV1().ReplicationControllers(o.Namespace).Get(o.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				list, err := getPods(cs, rc.Namespace, rc.Spec.Selector)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case "Deployment":
-				currentDeployment, err := cs.AppsV1().Deployments(o.Namespace).Get(o.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				_, _, newReplicaSet, err := kubectlutil.GetAllReplicaSets(currentDeployment, cs.AppsV1())
-				if err != nil || newReplicaSet == nil {
-					return false, err
-				}
-				newDeployment := deployment{
-					newReplicaSet,
-					currentDeployment,
-				}
-				deployments = append(deployments, newDeployment)
-			case "DaemonSet":
-				ds, err := cs.AppsV1().DaemonSets(o.Namespace).Get(o.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				list, err := getPods(cs, ds.Namespace, ds.Spec.Selector.MatchLabels)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case "StatefulSet":
-				sts, err := cs.AppsV1().StatefulSets(o.Namespace).Get(o.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				list, err := getPods(cs, sts.Namespace, sts.Spec.Selector.MatchLabels)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case "ReplicaSet":
-				rs, err := cs.AppsV1().ReplicaSets(o.Namespace).Get(o.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				list, err := getPods(cs, rs.Namespace, rs.Spec.Selector.MatchLabels)
-				if err != nil {
-					return false, err
-				}
-				pods = append(pods, list...)
-			case "Service":
-				svc, err := cs.CoreV1().Services(o.Namespace).Get(o.Name, metav1.GetOptions{})
-				if err != nil {
-					return false, err
-				}
-				services = append(services, *svc)
-			}
-		}
-		isReady := namespacesReady(namespaces) && podsReady(pods) && deploymentsReady(deployments) && servicesReady(services)
-		if !isReady {
-			logAndPrint("Waiting for resources ready with timeout of %v", opts.WaitTimeout)
-		}
-		return isReady, nil
-	})
-
-	if errPoll != nil {
-		logAndPrint("Failed to wait for resources ready: %v", errPoll)
-		return fmt.Errorf("failed to wait for resources ready: %s", errPoll)
-	}
-	return nil
-}
-
-func getPods(client kubernetes.Interface, namespace string, selector map[string]string) ([]v1.Pod, error) {
-	list, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{
-		FieldSelector: fields.Everything().String(),
-		LabelSelector: labels.Set(selector).AsSelector().String(),
-	})
-	return list.Items, err
-}
-
-func namespacesReady(namespaces []v1.Namespace) bool {
-	for _, namespace := range namespaces {
-		if !isNamespaceReady(&namespace) {
-			logAndPrint("Namespace is not ready: %s/%s", namespace.GetName())
-			return false
-		}
-	}
-	return true
-}
-
-func podsReady(pods []v1.Pod) bool {
-	for _, pod := range pods {
-		if !isPodReady(&pod) {
-			logAndPrint("Pod is not ready: %s/%s", pod.GetNamespace(), pod.GetName())
-			return false
-		}
+	refs := make([]inventory.Ref, 0, len(objects))
+	for _, o := range objects {
+		refs = append(refs, inventory.RefForObject(o))
 	}
-	return true
-}
-
-func isNamespaceReady(namespace *v1.Namespace) bool {
-	return namespace.Status.Phase == v1.NamespaceActive
-}
-
-func isPodReady(pod *v1.Pod) bool {
-	if len(pod.Status.Conditions) > 0 {
-		for _, condition := range pod.Status.Conditions {
-			if condition.Type == v1.PodReady &&
-				condition.Status == v1.ConditionTrue {
-				return true
-			}
-		}
+	logAndPrint("Waiting for resources ready with timeout of %v", opts.WaitTimeout)
+	if err := readiness.Wait(refs, getClusterObject, readiness.DefaultPollInterval, opts.WaitTimeout); err != nil {
+		logAndPrint("Failed to wait for resources ready: %v", err)
+		return fmt.Errorf("failed to wait for resources ready: %s", err)
 	}
-	return false
+	return nil
 }
 
-func deploymentsReady(deployments []deployment) bool {
-	for _, v := range deployments {
-		if v.replicaSets.Status.ReadyReplicas < *v.deployment.Spec.Replicas {
-			logAndPrint("Deployment is not ready: %s/%s", v.deployment.GetNamespace(), v.deployment.GetName())
-			return false
-		}
+// getClusterObject is a readiness.Getter which gets objects from the cluster with the prune client, which must have
+// been initialized.
+func getClusterObject(gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+	mapping, err := pruneMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		// The version may not be served, use the preferred version instead.
+		mapping, err = pruneMapper.RESTMapping(gvk.GroupKind())
 	}
-	return true
-}
-
-func servicesReady(svc []v1.Service) bool {
-	for _, s := range svc {
-		if s.Spec.Type == v1.ServiceTypeExternalName {
-			continue
-		}
-		if s.Spec.ClusterIP != v1.ClusterIPNone && s.Spec.ClusterIP == "" {
-			logAndPrint("Service is not ready: %s/%s", s.GetNamespace(), s.GetName())
-			return false
-		}
-		if s.Spec.Type == v1.ServiceTypeLoadBalancer && s.Status.LoadBalancer.Ingress == nil {
-			logAndPrint("Service is not ready: %s/%s", s.GetNamespace(), s.GetName())
-			return false
-		}
+	if err != nil {
+		return nil, err
 	}
-	return true
-}
-
-func buildInstallTree() {
-	// Starting with root, recursively insert each first level child into each node.
-	insertChildrenRecursive(name.IstioBaseComponentName, installTree, componentDependencies)
-}
-
-func insertChildrenRecursive(componentName name.ComponentName, tree componentTree, children componentNameToListMap) {
-	tree[componentName] = make(componentTree)
-	for _, child := range children[componentName] {
-		insertChildrenRecursive(child, tree[componentName].(componentTree), children)
+	ri := pruneClient.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		o, err := ri.Namespace(namespace).Get(name, metav1.GetOptions{})
+		return existing(o, err)
 	}
+	o, err := ri.Get(name, metav1.GetOptions{})
+	return existing(o, err)
 }
 
-func installTreeString() string {
-	var sb strings.Builder
-	buildInstallTreeString(name.IstioBaseComponentName, "", &sb)
-	return sb.String()
-}
-
-func buildInstallTreeString(componentName name.ComponentName, prefix string, sb io.StringWriter) {
-	_, _ = sb.WriteString(prefix + string(componentName) + "\n")
-	if _, ok := installTree[componentName].(componentTree); !ok {
-		return
-	}
-	for k := range installTree[componentName].(componentTree) {
-		buildInstallTreeString(k, prefix+"  ", sb)
+// existing returns the result of getting an object, with a nil object and no error if it does not exist.
+func existing(o *unstructured.Unstructured, err error) (*unstructured.Unstructured, error) {
+	if errors.IsNotFound(err) {
+		return nil, nil
 	}
+	return o, err
 }
 
 func initK8SRestClient(kubeconfig, context string) error {
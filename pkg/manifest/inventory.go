@@ -0,0 +1,128 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"istio.io/operator/pkg/inventory"
+	"istio.io/operator/pkg/name"
+)
+
+const (
+	// inventoryConfigMapName is the name of the ConfigMap which holds the inventory of the objects applied by the CLI.
+	inventoryConfigMapName = "istio-install-inventory"
+	// defaultInventoryNamespace is the namespace of the inventory ConfigMap if InstallOptions.InventoryNamespace is
+	// not set.
+	defaultInventoryNamespace = "istio-system"
+)
+
+// installInventory is the inventory of the objects applied for each component, which is stored in a ConfigMap so that
+// the objects which are no longer rendered can be pruned without scanning the cluster.
+type installInventory struct {
+	mu        sync.Mutex
+	client    kubernetes.Interface
+	namespace string
+	dryRun    bool
+	inv       inventory.Inventory
+	// stored reports whether the inventory ConfigMap exists.
+	stored bool
+	// scan is set if no inventory was stored when it was loaded, e.g. for installations made before inventories were
+	// recorded, in which case the objects to prune are found by scanning the cluster.
+	scan bool
+}
+
+// loadInventory reads the inventory stored in the cluster.
+func loadInventory(opts *InstallOptions) (*installInventory, error) {
+	cs, err := kubernetes.NewForConfig(k8sRESTConfig)
+	if err != nil {
+		return nil, err
+	}
+	out := &installInventory{client: cs, namespace: opts.InventoryNamespace, dryRun: opts.DryRun, inv: make(inventory.Inventory)}
+	if out.namespace == "" {
+		out.namespace = defaultInventoryNamespace
+	}
+	cm, err := cs.CoreV1().ConfigMaps(out.namespace).Get(inventoryConfigMapName, metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		out.scan = true
+		return out, nil
+	case err != nil:
+		return nil, fmt.Errorf("could not read the inventory of installed objects: %s", err)
+	}
+	if out.inv, err = inventory.FromConfigMap(cm); err != nil {
+		return nil, fmt.Errorf("could not read the inventory of installed objects: %s", err)
+	}
+	out.stored = true
+	return out, nil
+}
+
+// found reports whether an inventory was stored when it was loaded. If not, the objects of each component must be
+// found by scanning the cluster.
+func (i *installInventory) found() bool {
+	return !i.scan
+}
+
+// refs returns the references to the objects applied for componentName, and false if no inventory was found.
+func (i *installInventory) refs(componentName name.ComponentName) ([]inventory.Ref, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.inv[string(componentName)], i.found()
+}
+
+// components returns the names of the components with objects in the inventory.
+func (i *installInventory) components() []name.ComponentName {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	var out []name.ComponentName
+	for c := range i.inv {
+		out = append(out, name.ComponentName(c))
+	}
+	return out
+}
+
+// record sets refs as the objects of componentName and writes the inventory to the cluster, unless in dry run mode.
+func (i *installInventory) record(componentName name.ComponentName, refs []inventory.Ref) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.dryRun {
+		return nil
+	}
+	inv := make(inventory.Inventory)
+	for c, rs := range i.inv {
+		inv[c] = rs
+	}
+	inv.Set(string(componentName), refs)
+
+	cm := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: inventoryConfigMapName, Namespace: i.namespace}}
+	inv.ToConfigMap(cm)
+	var err error
+	if i.stored {
+		_, err = i.client.CoreV1().ConfigMaps(i.namespace).Update(cm)
+	} else {
+		_, err = i.client.CoreV1().ConfigMaps(i.namespace).Create(cm)
+	}
+	if err != nil {
+		return fmt.Errorf("could not record the inventory of installed objects: %s", err)
+	}
+	i.inv, i.stored = inv, true
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+
+	"istio.io/operator/pkg/inventory"
+	"istio.io/operator/pkg/name"
+	"istio.io/operator/pkg/object"
+	"istio.io/operator/pkg/prune"
+	"istio.io/pkg/log"
+)
+
+const (
+	// lastAppliedKey is the annotation kubectl apply sets on the objects it applies. Like kubectl apply --prune, only
+	// objects with this annotation are pruned, so that objects created in other ways are left alone.
+	lastAppliedKey = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+var (
+	// defaultPruneTypes are the types kubectl apply --prune checks by default, and the HorizontalPodAutoscaler and
+	// PodDisruptionBudget types the charts render for components with optional autoscaling or disruption budgets. They
+	// are checked along with the types rendered for a component, so that these objects are also pruned once a component
+	// no longer renders them.
+	defaultPruneTypes = []schema.GroupVersionKind{
+		{Version: "v1", Kind: "ConfigMap"},
+		{Version: "v1", Kind: "Endpoints"},
+		{Version: "v1", Kind: "Namespace"},
+		{Version: "v1", Kind: "PersistentVolumeClaim"},
+		{Version: "v1", Kind: "PersistentVolume"},
+		{Version: "v1", Kind: "Pod"},
+		{Version: "v1", Kind: "ReplicationController"},
+		{Version: "v1", Kind: "Secret"},
+		{Version: "v1", Kind: "Service"},
+		{Group: "batch", Version: "v1", Kind: "Job"},
+		{Group: "batch", Version: "v1beta1", Kind: "CronJob"},
+		{Group: "extensions", Version: "v1beta1", Kind: "Ingress"},
+		{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+		{Group: "apps", Version: "v1", Kind: "Deployment"},
+		{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+		{Group: "apps", Version: "v1", Kind: "StatefulSet"},
+		{Group: "autoscaling", Version: "v2beta1", Kind: "HorizontalPodAutoscaler"},
+		{Group: "policy", Version: "v1beta1", Kind: "PodDisruptionBudget"},
+	}
+
+	pruneClientOnce sync.Once
+	pruneClient     dynamic.Interface
+	pruneMapper     meta.RESTMapper
+	pruneClientErr  error
+)
+
+// pruneComponent deletes the objects of componentName which were applied before but are not in objects, like
+// kubectl apply --prune with a selector for the component. The objects applied before are taken from inv, or found
+// by scanning the cluster for objects with the component label if inv has no stored inventory. Unlike kubectl, objects
+// protected by the prune.DoNotPruneKey annotation are kept, and the objects are backed up to opts.PruneBackupDir, if
+// set, before they are deleted. In dry run mode, or if opts.PruneDryRun is set, the objects are listed but not
+// deleted. namespace is the namespace of the component, if any. It returns a description of what was pruned and the
+// references to the objects which were kept, which remain in the inventory.
+func pruneComponent(componentName name.ComponentName, namespace string, objects object.K8sObjects, inv *installInventory,
+	opts *InstallOptions) (string, []inventory.Ref, error) {
+	candidates, err := pruneCandidates(componentName, namespace, objects, inv)
+	if err != nil {
+		return "", nil, fmt.Errorf("could not find %s resources to prune: %s", componentName, err)
+	}
+	prunable, protected := prune.Filter(candidates)
+
+	var sb strings.Builder
+	var kept []inventory.Ref
+	for _, o := range protected {
+		sb.WriteString(fmt.Sprintf("%s not pruned, it is protected by the %s annotation\n", prune.ObjectString(&o), prune.DoNotPruneKey))
+		kept = append(kept, inventory.RefForUnstructured(&o))
+	}
+	if len(prunable) == 0 {
+		return sb.String(), kept, nil
+	}
+	if opts.DryRun || opts.PruneDryRun {
+		for _, o := range prunable {
+			sb.WriteString(fmt.Sprintf("%s would be pruned (dry run)\n", prune.ObjectString(&o)))
+			kept = append(kept, inventory.RefForUnstructured(&o))
+		}
+		return sb.String(), kept, nil
+	}
+
+	if opts.PruneBackupDir != "" {
+		path, err := prune.WriteBackupFile(opts.PruneBackupDir, string(componentName), prunable)
+		if err != nil {
+			for _, o := range prunable {
+				kept = append(kept, inventory.RefForUnstructured(&o))
+			}
+			return sb.String(), kept, fmt.Errorf("could not back up %s resources before pruning, nothing was pruned: %s", componentName, err)
+		}
+		sb.WriteString(fmt.Sprintf("backed up resources to prune to %s\n", path))
+	}
+
+	var errs []string
+	for _, o := range prunable {
+		mapping, err := pruneMapper.RESTMapping(o.GroupVersionKind().GroupKind(), o.GroupVersionKind().Version)
+		if err == nil {
+			propagation := metav1.DeletePropagationBackground
+			err = pruneClient.Resource(mapping.Resource).Namespace(o.GetNamespace()).Delete(o.GetName(),
+				&metav1.DeleteOptions{PropagationPolicy: &propagation})
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			errs = append(errs, fmt.Sprintf("could not prune %s: %s", prune.ObjectString(&o), err))
+			kept = append(kept, inventory.RefForUnstructured(&o))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s pruned\n", prune.ObjectString(&o)))
+	}
+	if len(errs) != 0 {
+		return sb.String(), kept, fmt.Errorf("%s", strings.Join(errs, "\n"))
+	}
+	return sb.String(), kept, nil
+}
+
+// appliedRefs returns references to objects, which are applied to namespace if they are namespaced and have no
+// namespace.
+func appliedRefs(objects object.K8sObjects, namespace string) []inventory.Ref {
+	var out []inventory.Ref
+	for _, o := range objects {
+		r := inventory.RefForObject(o)
+		if r.Namespace == "" && !object.IsClusterScoped(o.Kind) {
+			r.Namespace = namespace
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// initPruneClient creates the clients used for pruning, once.
+func initPruneClient() error {
+	pruneClientOnce.Do(func() {
+		if pruneClient, pruneClientErr = dynamic.NewForConfig(k8sRESTConfig); pruneClientErr != nil {
+			return
+		}
+		var dc *discovery.DiscoveryClient
+		if dc, pruneClientErr = discovery.NewDiscoveryClientForConfig(k8sRESTConfig); pruneClientErr != nil {
+			return
+		}
+		var grs []*restmapper.APIGroupResources
+		if grs, pruneClientErr = restmapper.GetAPIGroupResources(dc); pruneClientErr != nil {
+			return
+		}
+		pruneMapper = restmapper.NewDiscoveryRESTMapper(grs)
+	})
+	return pruneClientErr
+}
+
+// pruneCandidates returns the objects of componentName that were applied before and are not in objects.
+func pruneCandidates(componentName name.ComponentName, namespace string, objects object.K8sObjects,
+	inv *installInventory) ([]unstructured.Unstructured, error) {
+	if err := initPruneClient(); err != nil {
+		return nil, err
+	}
+	refs, ok := inv.refs(componentName)
+	if !ok {
+		return scanPruneCandidates(componentName, namespace, objects)
+	}
+
+	var out []unstructured.Unstructured
+	for _, r := range inventory.Subtract(refs, appliedRefs(objects, namespace)) {
+		gk := r.GroupVersionKind.GroupKind()
+		mapping, err := pruneMapper.RESTMapping(gk, r.GroupVersionKind.Version)
+		if err != nil {
+			// The recorded version may no longer be served, use the preferred version instead.
+			mapping, err = pruneMapper.RESTMapping(gk)
+		}
+		if err != nil {
+			log.Debugf("skipping prune of %s, its type is not served by the API server: %s", r, err)
+			continue
+		}
+		o, err := pruneClient.Resource(mapping.Resource).Namespace(r.Namespace).Get(r.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *o)
+	}
+	return out, nil
+}
+
+// scanPruneCandidates returns the objects labeled as belonging to componentName that were applied by kubectl and are
+// not in objects. The types in objects and defaultPruneTypes which are served by the API server are checked. If
+// namespace is set, only namespaced objects in that namespace are checked.
+func scanPruneCandidates(componentName name.ComponentName, namespace string, objects object.K8sObjects) ([]unstructured.Unstructured, error) {
+	applied := make(map[string]bool)
+	types := append([]schema.GroupVersionKind{}, defaultPruneTypes...)
+	for _, r := range appliedRefs(objects, namespace) {
+		applied[r.Key()] = true
+		types = append(types, r.GroupVersionKind)
+	}
+	selector := fmt.Sprintf("%s=%s", istioComponentLabelStr, componentName)
+	seen := make(map[schema.GroupKind]bool)
+	var out []unstructured.Unstructured
+	for _, gvk := range types {
+		if seen[gvk.GroupKind()] {
+			continue
+		}
+		seen[gvk.GroupKind()] = true
+		mapping, err := pruneMapper.RESTMapping(gvk.GroupKind())
+		if err != nil {
+			log.Debugf("skipping prune of type %s which is not served by the API server: %s", gvk, err)
+			continue
+		}
+		ri := pruneClient.Resource(mapping.Resource)
+		var list *unstructured.UnstructuredList
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			list, err = ri.Namespace(namespace).List(metav1.ListOptions{LabelSelector: selector})
+		} else {
+			list, err = ri.List(metav1.ListOptions{LabelSelector: selector})
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range list.Items {
+			if _, ok := o.GetAnnotations()[lastAppliedKey]; !ok || applied[inventory.RefForUnstructured(&o).Key()] {
+				continue
+			}
+			out = append(out, o)
+		}
+	}
+	return out, nil
+}
@@ -16,6 +16,7 @@ package name
 
 import (
 	"fmt"
+	"strings"
 
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
 	"istio.io/operator/pkg/tpath"
@@ -104,6 +105,19 @@ var (
 // ManifestMap is a map of ComponentName to its manifest string.
 type ManifestMap map[ComponentName]string
 
+// GatewayComponentName returns the name of the component for the named gateway gatewayName, rendered from the chart
+// of baseComponentName, which is either IngressComponentName or EgressComponentName. For example, the ingress gateway
+// named internal-ingressgateway has the component name IngressGateway-internal-ingressgateway.
+func GatewayComponentName(baseComponentName ComponentName, gatewayName string) ComponentName {
+	return ComponentName(string(baseComponentName) + "-" + gatewayName)
+}
+
+// IsGatewayComponentName reports whether componentName is the name of a named gateway component.
+func IsGatewayComponentName(componentName ComponentName) bool {
+	cn := string(componentName)
+	return strings.HasPrefix(cn, string(IngressComponentName)+"-") || strings.HasPrefix(cn, string(EgressComponentName)+"-")
+}
+
 // IsFeatureEnabledInSpec reports whether the given feature is enabled in the given spec.
 // This follows the logic description in IstioControlPlane proto.
 // IsFeatureEnabledInSpec assumes that controlPlaneSpec has been validated.
@@ -69,11 +69,30 @@ func NewK8sObject(u *unstructured.Unstructured, json, yaml []byte) *K8sObject {
 	return o
 }
 
+var (
+	// clusterScopedKinds is the set of kinds that are not namespaced.
+	// TODO: replace strings with k8s const (istio/istio#17237).
+	clusterScopedKinds = map[string]bool{
+		"ClusterRole":                    true,
+		"ClusterRoleBinding":             true,
+		"CustomResourceDefinition":       true,
+		"MutatingWebhookConfiguration":   true,
+		"Namespace":                      true,
+		"PodSecurityPolicy":              true,
+		"PriorityClass":                  true,
+		"StorageClass":                   true,
+		"ValidatingWebhookConfiguration": true,
+	}
+)
+
+// IsClusterScoped reports whether objects of the given kind are cluster scoped rather than namespaced.
+func IsClusterScoped(kind string) bool {
+	return clusterScopedKinds[kind]
+}
+
 // Hash returns a unique, insecure hash based on kind, namespace and name.
 func Hash(kind, namespace, name string) string {
-	switch kind {
-	// TODO: replace strings with k8s const (istio/istio#17237).
-	case "ClusterRole", "ClusterRoleBinding":
+	if IsClusterScoped(kind) {
 		namespace = ""
 	}
 	return strings.Join([]string{kind, namespace, name}, ":")
@@ -207,6 +226,91 @@ func (o *K8sObject) AddLabels(labels map[string]string) {
 	o.yaml = nil
 }
 
+// AddAnnotations adds annotations to the K8sObject.
+// This method will override the value if there is already annotation with the same key.
+func (o *K8sObject) AddAnnotations(annotations map[string]string) {
+	merged := make(map[string]string)
+	for k, v := range o.object.GetAnnotations() {
+		merged[k] = v
+	}
+
+	for k, v := range annotations {
+		merged[k] = v
+	}
+
+	o.object.SetAnnotations(merged)
+	// Invalidate cached json
+	o.json = nil
+	o.yaml = nil
+}
+
+// AddPodTemplateLabels adds labels to the pod template of the K8sObject. Objects without a pod template are unchanged.
+// This method will override the value if there is already label with the same key.
+func (o *K8sObject) AddPodTemplateLabels(labels map[string]string) error {
+	return o.mergePodTemplateMetadata("labels", labels)
+}
+
+// AddPodTemplateAnnotations adds annotations to the pod template of the K8sObject. Objects without a pod template are
+// unchanged.
+// This method will override the value if there is already annotation with the same key.
+func (o *K8sObject) AddPodTemplateAnnotations(annotations map[string]string) error {
+	return o.mergePodTemplateMetadata("annotations", annotations)
+}
+
+// PodTemplateLabels returns the labels of the pod template of the K8sObject, or nil if it has no pod template.
+func (o *K8sObject) PodTemplateLabels() (map[string]string, error) {
+	tp := podTemplatePath(o.Kind)
+	if tp == nil {
+		return nil, nil
+	}
+	labels, _, err := unstructured.NestedStringMap(o.object.Object, append(tp, "metadata", "labels")...)
+	return labels, err
+}
+
+// mergePodTemplateMetadata merges kvs into the given pod template metadata field, if the K8sObject has a pod template.
+func (o *K8sObject) mergePodTemplateMetadata(field string, kvs map[string]string) error {
+	if len(kvs) == 0 {
+		return nil
+	}
+	tp := podTemplatePath(o.Kind)
+	if tp == nil {
+		return nil
+	}
+	if _, found, err := unstructured.NestedMap(o.object.Object, tp...); err != nil || !found {
+		return err
+	}
+	fp := append(tp, "metadata", field)
+	merged, _, err := unstructured.NestedStringMap(o.object.Object, fp...)
+	if err != nil {
+		return err
+	}
+	if merged == nil {
+		merged = make(map[string]string)
+	}
+	for k, v := range kvs {
+		merged[k] = v
+	}
+	if err := unstructured.SetNestedStringMap(o.object.Object, merged, fp...); err != nil {
+		return err
+	}
+	// Invalidate cached json
+	o.json = nil
+	o.yaml = nil
+	return nil
+}
+
+// podTemplatePath returns the path to the pod template for objects of the given kind, or nil if the kind has no pod
+// template.
+func podTemplatePath(kind string) []string {
+	switch kind {
+	case "Deployment", "DaemonSet", "StatefulSet", "ReplicaSet", "ReplicationController", "Job":
+		return []string{"spec", "template"}
+	case "CronJob":
+		return []string{"spec", "jobTemplate", "spec", "template"}
+	}
+	return nil
+}
+
 // K8sObjects holds a collection of k8s objects, so that we can filter / sequence them
 type K8sObjects []*K8sObject
 
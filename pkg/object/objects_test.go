@@ -32,6 +32,7 @@ func TestHash(t *testing.T) {
 		{"CalculateHashForObjectWithNormalCharacter", "Service", "default", "ingressgateway", "Service:default:ingressgateway"},
 		{"CalculateHashForObjectWithDash", "Deployment", "istio-system", "istio-pilot", "Deployment:istio-system:istio-pilot"},
 		{"CalculateHashForObjectWithDot", "ConfigMap", "istio-system", "my.config", "ConfigMap:istio-system:my.config"},
+		{"CalculateHashForClusterScopedObject", "PriorityClass", "istio-system", "high", "PriorityClass::high"},
 	}
 
 	for _, tt := range hashTests {
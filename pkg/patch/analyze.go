@@ -0,0 +1,257 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/object"
+	"istio.io/operator/pkg/tpath"
+	"istio.io/operator/pkg/util"
+)
+
+// OverlayReport describes the effect of a single overlay on a rendered manifest.
+type OverlayReport struct {
+	// Component is the name of the component the overlay is defined for. It is set by the caller.
+	Component string
+	// Object is the hash of the object targeted by the overlay, in the form kind:namespace:name.
+	Object string
+	// Found reports whether the target object exists in the manifest.
+	Found bool
+	// Patches holds a report for each patch in the overlay, in the order they are applied.
+	Patches []*PatchReport
+}
+
+// PatchReport describes the effect of a single path/value patch on its target object.
+type PatchReport struct {
+	// Path is the patch path.
+	Path string
+	// MatchedPath is the longest leading part of Path that matched existing nodes in the object.
+	MatchedPath string
+	// Matched reports whether the complete Path matched existing nodes in the object.
+	Matched bool
+	// Changed reports whether applying the patch changed the object.
+	Changed bool
+	// Error is the error from applying the patch, if any.
+	Error string
+}
+
+// OK reports whether the overlay target was found and every patch changed the target object.
+func (r *OverlayReport) OK() bool {
+	if !r.Found {
+		return false
+	}
+	for _, p := range r.Patches {
+		if !p.OK() {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the Stringer interface.
+func (r *OverlayReport) String() string {
+	var sb strings.Builder
+	status := "OK"
+	if !r.OK() {
+		status = "STALE"
+	}
+	if r.Component != "" {
+		sb.WriteString(fmt.Sprintf("[%s] %s overlay for %s\n", status, r.Component, r.Object))
+	} else {
+		sb.WriteString(fmt.Sprintf("[%s] overlay for %s\n", status, r.Object))
+	}
+	if !r.Found {
+		sb.WriteString("  target object not found in output manifest\n")
+		return sb.String()
+	}
+	for _, p := range r.Patches {
+		sb.WriteString("  " + p.String() + "\n")
+	}
+	return sb.String()
+}
+
+// OK reports whether the patch applied without error and changed the target object.
+func (p *PatchReport) OK() bool {
+	return p.Error == "" && p.Changed
+}
+
+// String implements the Stringer interface.
+func (p *PatchReport) String() string {
+	switch {
+	case p.Error != "":
+		return fmt.Sprintf("path %s: error: %s", p.Path, p.Error)
+	case !p.Changed && !p.Matched:
+		return fmt.Sprintf("path %s: no change, matched only %q", p.Path, p.MatchedPath)
+	case !p.Changed:
+		return fmt.Sprintf("path %s: no change, value is already set", p.Path)
+	case !p.Matched:
+		return fmt.Sprintf("path %s: added, matched %q", p.Path, p.MatchedPath)
+	}
+	return fmt.Sprintf("path %s: changed", p.Path)
+}
+
+// OverlayAnalysis collects OverlayReports across components. It is safe for concurrent use.
+type OverlayAnalysis struct {
+	mu      sync.Mutex
+	reports []*OverlayReport
+}
+
+// Add adds reports for the overlays of the given component to the analysis.
+func (a *OverlayAnalysis) Add(componentName string, reports []*OverlayReport) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, r := range reports {
+		r.Component = componentName
+		a.reports = append(a.reports, r)
+	}
+}
+
+// Reports returns all the reports in the analysis, sorted by component and object.
+func (a *OverlayAnalysis) Reports() []*OverlayReport {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := append([]*OverlayReport{}, a.reports...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Component != out[j].Component {
+			return out[i].Component < out[j].Component
+		}
+		return out[i].Object < out[j].Object
+	})
+	return out
+}
+
+// Stale returns the reports for overlays that have a missing target or contain a patch which has no effect.
+func (a *OverlayAnalysis) Stale() []*OverlayReport {
+	var out []*OverlayReport
+	for _, r := range a.Reports() {
+		if !r.OK() {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// AnalyzeYAMLManifestPatch reports, for each of the given overlays, whether its target object exists in baseYAML in
+// the given namespace, how much of each patch path matched and whether each patch changed the target object.
+// Patches are applied in order as in YAMLManifestPatch, so a patch is compared against the result of previous patches.
+// Patch errors are recorded in the reports rather than returned.
+func AnalyzeYAMLManifestPatch(baseYAML string, namespace string, overlays []*v1alpha2.K8SObjectOverlay) ([]*OverlayReport, error) {
+	baseObjs, err := object.ParseK8sObjectsFromYAMLManifest(baseYAML)
+	if err != nil {
+		return nil, err
+	}
+	bom := baseObjs.ToMap()
+
+	var out []*OverlayReport
+	for _, o := range overlays {
+		r := &OverlayReport{
+			Object: object.Hash(o.Kind, namespace, o.Name),
+		}
+		out = append(out, r)
+		bo := bom[r.Object]
+		if bo == nil {
+			continue
+		}
+		r.Found = true
+		by, err := bo.YAML()
+		if err != nil {
+			return nil, err
+		}
+		tree := make(map[interface{}]interface{})
+		if err := yaml.Unmarshal(by, tree); err != nil {
+			return nil, err
+		}
+		for _, p := range o.Patches {
+			pr, err := analyzePatch(tree, p)
+			if err != nil {
+				return nil, err
+			}
+			r.Patches = append(r.Patches, pr)
+		}
+	}
+	return out, nil
+}
+
+// analyzePatch applies p to tree and returns a report of its effect.
+func analyzePatch(tree map[interface{}]interface{}, p *v1alpha2.K8SObjectOverlay_PathValue) (*PatchReport, error) {
+	pr := &PatchReport{Path: p.Path}
+	before, err := yaml.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	path := util.PathFromString(p.Path)
+	for i := len(path); i > 0; i-- {
+		ok, err := pathExists(before, path[:i])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			pr.MatchedPath = path[:i].String()
+			pr.Matched = i == len(path)
+			break
+		}
+	}
+
+	inc, _, err := tpath.GetPathContext(tree, path)
+	if err == nil {
+		err = tpath.WritePathContext(inc, p.Value)
+	}
+	if err != nil {
+		pr.Error = err.Error()
+	}
+	after, err := yaml.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	pr.Changed = string(before) != string(after)
+	return pr, nil
+}
+
+// pathExists reports whether path matches at least one existing node in the tree given by treeYAML. Paths that
+// GetPathContext can only satisfy by creating a new leaf node are treated as not existing.
+func pathExists(treeYAML []byte, path util.Path) (bool, error) {
+	tree := make(map[interface{}]interface{})
+	if err := yaml.Unmarshal(treeYAML, tree); err != nil {
+		return false, err
+	}
+	nc, found, err := tpath.GetPathContext(tree, path)
+	if err != nil || !found || nc == nil {
+		return false, nil
+	}
+	if nc.Matches != nil {
+		// Matches for new leaves have no Node.
+		found = false
+		for _, m := range nc.Matches {
+			if m.Node != nil {
+				found = true
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	ty, err := yaml.Marshal(tree)
+	if err != nil {
+		return false, err
+	}
+	return string(ty) == string(treeYAML), nil
+}
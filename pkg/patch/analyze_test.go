@@ -0,0 +1,163 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package patch
+
+import (
+	"testing"
+
+	"github.com/kr/pretty"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+)
+
+func TestAnalyzeYAMLManifestPatch(t *testing.T) {
+	base := `
+apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: istio-citadel
+  namespace: istio-system
+a:
+  b:
+  - name: n1
+    value: v1
+  - name: n2
+    list:
+    - v1
+    - v2
+`
+	tests := []struct {
+		desc      string
+		kind      string
+		name      string
+		path      string
+		value     interface{}
+		wantFound bool
+		want      *PatchReport
+		wantOK    bool
+	}{
+		{
+			desc:      "ChangedValue",
+			kind:      "Deployment",
+			name:      "istio-citadel",
+			path:      `a.b.[name:n1].value`,
+			value:     "v2",
+			wantFound: true,
+			want:      &PatchReport{Path: `a.b.[name:n1].value`, MatchedPath: `a.b.[name:n1].value`, Matched: true, Changed: true},
+			wantOK:    true,
+		},
+		{
+			desc:      "SameValue",
+			kind:      "Deployment",
+			name:      "istio-citadel",
+			path:      `a.b.[name:n1].value`,
+			value:     "v1",
+			wantFound: true,
+			want:      &PatchReport{Path: `a.b.[name:n1].value`, MatchedPath: `a.b.[name:n1].value`, Matched: true},
+		},
+		{
+			desc:      "NewLeaf",
+			kind:      "Deployment",
+			name:      "istio-citadel",
+			path:      `a.b.[name:n1].new`,
+			value:     "v1",
+			wantFound: true,
+			want:      &PatchReport{Path: `a.b.[name:n1].new`, MatchedPath: `a.b.[name:n1]`, Changed: true},
+			wantOK:    true,
+		},
+		{
+			desc:      "MissingListEntry",
+			kind:      "Deployment",
+			name:      "istio-citadel",
+			path:      `a.b.[name:n3].value`,
+			value:     "v1",
+			wantFound: true,
+			want: &PatchReport{Path: `a.b.[name:n3].value`, MatchedPath: `a.b`,
+				Error: `path a.b.[name:n3].value: element [name:n3] not found`},
+		},
+		{
+			desc:      "WildcardNoMatch",
+			kind:      "Deployment",
+			name:      "istio-citadel",
+			path:      `a.b.[*].missing.value`,
+			value:     "v1",
+			wantFound: true,
+			want:      &PatchReport{Path: `a.b.[*].missing.value`, MatchedPath: `a.b.[*]`},
+		},
+		{
+			desc:  "MissingObject",
+			kind:  "Deployment",
+			name:  "istio-pilot",
+			path:  `a.b.[name:n1].value`,
+			value: "v2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			overlays := []*v1alpha2.K8SObjectOverlay{
+				{
+					Kind: tt.kind,
+					Name: tt.name,
+					Patches: []*v1alpha2.K8SObjectOverlay_PathValue{
+						{Path: tt.path, Value: tt.value},
+					},
+				},
+			}
+			got, err := AnalyzeYAMLManifestPatch(base, "istio-system", overlays)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("got %d reports, want 1", len(got))
+			}
+			r := got[0]
+			if r.Found != tt.wantFound {
+				t.Errorf("Found: got %v, want %v", r.Found, tt.wantFound)
+			}
+			if r.OK() != tt.wantOK {
+				t.Errorf("OK: got %v, want %v", r.OK(), tt.wantOK)
+			}
+			if !tt.wantFound {
+				return
+			}
+			if len(r.Patches) != 1 {
+				t.Fatalf("got %d patch reports, want 1", len(r.Patches))
+			}
+			if diff := pretty.Diff(r.Patches[0], tt.want); len(diff) != 0 {
+				t.Errorf("got:\n%s\nwant:\n%s\ndiff:\n%s", pretty.Sprint(r.Patches[0]), pretty.Sprint(tt.want), diff)
+			}
+		})
+	}
+}
+
+func TestOverlayAnalysisStale(t *testing.T) {
+	a := &OverlayAnalysis{}
+	a.Add("Pilot", []*OverlayReport{
+		{Object: "Deployment:istio-system:istio-pilot", Found: true, Patches: []*PatchReport{{Path: "a", Changed: true}}},
+		{Object: "Service:istio-system:istio-pilot"},
+	})
+	a.Add("Citadel", []*OverlayReport{
+		{Object: "Deployment:istio-system:istio-citadel", Found: true, Patches: []*PatchReport{{Path: "a"}}},
+	})
+
+	var got []string
+	for _, r := range a.Stale() {
+		got = append(got, r.Component+"/"+r.Object)
+	}
+	want := []string{"Citadel/Deployment:istio-system:istio-citadel", "Pilot/Service:istio-system:istio-pilot"}
+	if diff := pretty.Diff(got, want); len(diff) != 0 {
+		t.Errorf("got: %v, want: %v", got, want)
+	}
+}
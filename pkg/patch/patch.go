@@ -76,14 +76,38 @@ ADD
   value:
     new_attr: v3
 
+3. Append vv3 to the end of list, even if the list contains lists or maps
+
+  path: a.b.[name:n2].list.[+]
+  value: vv3
+
+SELECTING MULTIPLE OR INDEXED ENTRIES
+
+1. Set value for every list entry in b
+
+  path: a.b.[*].value
+  value: v1new
+
+2. Set the first entry in list
+
+  path: a.b.[name:n2].list.[0]
+  value: vv0
+
+3. Set every field called value, at any depth
+
+  path: **.value
+  value: v1new
+
 *NOTES*
 - Due to loss of string quoting during unmarshaling, keys and values should not be string quoted, even if they appear
 that way in the object being patched.
 - [key:value] treats ':' as a special separator character. Any ':' in the key or value string must be escaped as \:.
+- [N] is always treated as a list index, numeric leaf list values must be matched with a regex like [^80$].
 */
 package patch
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -175,6 +199,273 @@ func YAMLManifestPatch(baseYAML string, namespace string, overlays []*v1alpha2.K
 	return ret.String(), errs.ToError()
 }
 
+// AddObjects appends objs, a list of full k8s resource trees, to baseYAML and returns the resulting manifest YAML.
+// Namespaced objects that do not specify a namespace are placed in the given namespace. It is an error for an added
+// object to already exist in baseYAML; overlays should be used to modify existing objects instead.
+func AddObjects(baseYAML string, namespace string, objs []map[string]interface{}) (string, error) {
+	baseObjs, err := object.ParseK8sObjectsFromYAMLManifest(baseYAML)
+	if err != nil {
+		return "", err
+	}
+	bom := baseObjs.ToMap()
+
+	var ret strings.Builder
+	ret.WriteString(baseYAML)
+	if trimmed := strings.TrimSpace(baseYAML); trimmed != "" && !strings.HasSuffix(trimmed, "---") {
+		ret.WriteString(object.YAMLSeparator)
+	}
+
+	var errs util.Errors
+	for _, obj := range objs {
+		oj, err := json.Marshal(obj)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("extra object marshal error: %s", err))
+			continue
+		}
+		o, err := object.ParseJSONToK8sObject(oj)
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("extra object parse error: %s", err))
+			continue
+		}
+		if !o.Valid() {
+			errs = util.AppendErr(errs, fmt.Errorf("extra object must have a kind and metadata.name:\n%s", oj))
+			continue
+		}
+		if o.Namespace == "" && !object.IsClusterScoped(o.Kind) {
+			u := o.UnstructuredObject()
+			u.SetNamespace(namespace)
+			o = object.NewK8sObject(u, nil, nil)
+		}
+		if bom[o.Hash()] != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("extra object %s already exists in output manifest, use an overlay to modify it", o.Hash()))
+			continue
+		}
+		bom[o.Hash()] = o
+		oy, err := o.YAML()
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("object to YAML error (%s) for extra object: \n%v", err, o))
+			continue
+		}
+		if _, err := ret.Write(oy); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("write: %s", err))
+		}
+		if _, err := ret.WriteString(object.YAMLSeparator); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("writeString: %s", err))
+		}
+	}
+	return ret.String(), errs.ToError()
+}
+
+// RemoveObjects removes the objects referenced by refs from baseYAML in the given namespace and returns the
+// resulting manifest YAML. A reference with an apiVersion only matches an object with the same apiVersion. It is an
+// error for a reference not to match any object in baseYAML.
+func RemoveObjects(baseYAML string, namespace string, refs []*v1alpha2.K8SObjectReference) (string, error) {
+	baseObjs, err := object.ParseK8sObjectsFromYAMLManifest(baseYAML)
+	if err != nil {
+		return "", err
+	}
+	bom := baseObjs.ToMap()
+
+	var errs util.Errors
+	remove := make(map[string]bool)
+	for _, r := range refs {
+		k := object.Hash(r.Kind, namespace, r.Name)
+		if bom[k] == nil {
+			os := ""
+			for k2 := range bom {
+				os += k2 + "\n"
+			}
+			errs = util.AppendErr(errs, fmt.Errorf("object to remove %s does not match any object in output manifest.\n\nAvailable objects are:\n%s",
+				k, os))
+			continue
+		}
+		if av := bom[k].GroupVersionKind().GroupVersion().String(); r.ApiVersion != "" && r.ApiVersion != av {
+			errs = util.AppendErr(errs, fmt.Errorf("object to remove %s has apiVersion %s, which does not match the output manifest object apiVersion %s",
+				k, r.ApiVersion, av))
+			continue
+		}
+		remove[k] = true
+	}
+
+	var ret strings.Builder
+	// Keep the original order for the remaining objects.
+	for _, o := range baseObjs {
+		if remove[o.Hash()] {
+			continue
+		}
+		oy, err := o.YAML()
+		if err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("object to YAML error (%s) for base object: \n%v", err, o))
+			continue
+		}
+		if _, err := ret.Write(oy); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("write: %s", err))
+		}
+		if _, err := ret.WriteString(object.YAMLSeparator); err != nil {
+			errs = util.AppendErr(errs, fmt.Errorf("writeString: %s", err))
+		}
+	}
+	return ret.String(), errs.ToError()
+}
+
+// AddCommonMetadata adds the common labels and annotations from icp to every object in baseYAML, and the common pod
+// labels and annotations to the pod template of every workload in baseYAML. It returns the resulting manifest YAML.
+func AddCommonMetadata(baseYAML string, icp *v1alpha2.IstioControlPlaneSpec) (string, error) {
+	if len(icp.GetCommonLabels()) == 0 && len(icp.GetCommonAnnotations()) == 0 &&
+		len(icp.GetCommonPodLabels()) == 0 && len(icp.GetCommonPodAnnotations()) == 0 {
+		return baseYAML, nil
+	}
+	baseObjs, err := object.ParseK8sObjectsFromYAMLManifest(baseYAML)
+	if err != nil {
+		return "", err
+	}
+
+	var errs util.Errors
+	for _, o := range baseObjs {
+		if len(icp.CommonLabels) != 0 {
+			o.AddLabels(icp.CommonLabels)
+		}
+		if len(icp.CommonAnnotations) != 0 {
+			o.AddAnnotations(icp.CommonAnnotations)
+		}
+		if err := checkPodLabelConflicts(o, icp.CommonPodLabels); err != nil {
+			errs = util.AppendErrs(errs, err)
+			continue
+		}
+		errs = util.AppendErr(errs, o.AddPodTemplateLabels(icp.CommonPodLabels))
+		errs = util.AppendErr(errs, o.AddPodTemplateAnnotations(icp.CommonPodAnnotations))
+	}
+	if len(errs) != 0 {
+		return "", errs.ToError()
+	}
+	return baseObjs.YAMLManifest()
+}
+
+// RenameObjects renames the objects of a component instance in baseYAML from the default instance name from to the
+// name to, and returns the resulting manifest YAML. An object is renamed if its name is from, one of aliases, or
+// starts with from followed by a dash, in which case the suffix is kept. In renamed objects, every occurrence of from
+// in a string value is replaced by to, and every label set or selector containing one of fromLabels has fromLabels
+// replaced by toLabels. Objects which are not renamed are shared by all instances and are returned unchanged.
+// It is an error if no object in baseYAML is renamed.
+func RenameObjects(baseYAML, from, to string, aliases []string, fromLabels, toLabels map[string]string) (string, error) {
+	baseObjs, err := object.ParseK8sObjectsFromYAMLManifest(baseYAML)
+	if err != nil {
+		return "", err
+	}
+
+	renamed := false
+	var outObjs object.K8sObjects
+	for _, o := range baseObjs {
+		newName, ok := renamedObjectName(o.Name, from, to, aliases)
+		if !ok {
+			outObjs = append(outObjs, o)
+			continue
+		}
+		u := o.UnstructuredObject()
+		u.Object = renameValue(u.Object, from, to, fromLabels, toLabels).(map[string]interface{})
+		u.SetName(newName)
+		outObjs = append(outObjs, object.NewK8sObject(u, nil, nil))
+		renamed = true
+	}
+	if !renamed {
+		return "", fmt.Errorf("no object named %s to rename to %s", from, to)
+	}
+	return outObjs.YAMLManifest()
+}
+
+// renamedObjectName returns the name an object called name has after renaming from to to, and whether the object
+// is renamed at all.
+func renamedObjectName(name, from, to string, aliases []string) (string, bool) {
+	if name == from {
+		return to, true
+	}
+	if strings.HasPrefix(name, from+"-") {
+		return to + strings.TrimPrefix(name, from), true
+	}
+	for _, a := range aliases {
+		if name == a {
+			return to, true
+		}
+	}
+	return "", false
+}
+
+// renameValue returns v with every occurrence of from in string values replaced by to, and every label map or JSON
+// encoded label map containing one of fromLabels relabeled with toLabels.
+func renameValue(v interface{}, from, to string, fromLabels, toLabels map[string]string) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		if hasAnyLabel(vv, fromLabels) {
+			return relabel(vv, fromLabels, toLabels)
+		}
+		for k, e := range vv {
+			vv[k] = renameValue(e, from, to, fromLabels, toLabels)
+		}
+		return vv
+	case []interface{}:
+		for i, e := range vv {
+			vv[i] = renameValue(e, from, to, fromLabels, toLabels)
+		}
+		return vv
+	case string:
+		// Label maps are also passed to the proxy as JSON strings.
+		lm := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(vv), &lm); err == nil && hasAnyLabel(lm, fromLabels) {
+			lj, err := json.Marshal(relabel(lm, fromLabels, toLabels))
+			if err == nil {
+				return string(lj) + vv[len(strings.TrimRight(vv, " \n")):]
+			}
+		}
+		return strings.ReplaceAll(vv, from, to)
+	}
+	return v
+}
+
+// hasAnyLabel reports whether m contains any of the key:value pairs in labels.
+func hasAnyLabel(m map[string]interface{}, labels map[string]string) bool {
+	for k, v := range labels {
+		if mv, ok := m[k].(string); ok && mv == v {
+			return true
+		}
+	}
+	return false
+}
+
+// relabel returns m with the keys of fromLabels removed and toLabels added.
+func relabel(m map[string]interface{}, fromLabels, toLabels map[string]string) map[string]interface{} {
+	for k := range fromLabels {
+		delete(m, k)
+	}
+	for k, v := range toLabels {
+		m[k] = v
+	}
+	return m
+}
+
+// checkPodLabelConflicts returns an error for each of the labels that the pod template of o already sets to a different
+// value. Such labels are set by the chart and may be used by selectors, which would no longer match the pods if the
+// labels were overridden.
+func checkPodLabelConflicts(o *object.K8sObject, labels map[string]string) util.Errors {
+	tl, err := o.PodTemplateLabels()
+	if err != nil {
+		return util.NewErrs(err)
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var errs util.Errors
+	for _, k := range keys {
+		if v, ok := tl[k]; ok && v != labels[k] {
+			p := util.Path{"commonPodLabels", k}
+			errs = util.AppendErr(errs, util.NewPathError(p, fmt.Errorf("%s: label %s is already set to %s in the pod template of %s:%s",
+				p, k, v, o.Kind, o.Name)))
+		}
+	}
+	return errs
+}
+
 // applyPatches applies the given patches against the given object. It returns the resulting patched YAML if successful,
 // or a list of errors otherwise.
 func applyPatches(base *object.K8sObject, patches []*v1alpha2.K8SObjectOverlay_PathValue) (outYAML []byte, errs util.Errors) {
@@ -16,9 +16,11 @@ package patch
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/object"
 	"istio.io/operator/pkg/util"
 )
 
@@ -326,6 +328,67 @@ spec:
         ports:
         - containerPort: 443
         - containerPort: 15014
+`,
+		},
+		{
+			desc:  "UpdateAllListItems",
+			path:  `spec.template.spec.containers.[*].imagePullPolicy`,
+			value: `Always`,
+			want: `
+apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: istio-citadel
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - foo: bar
+        imagePullPolicy: Always
+        name: deleteThis
+      - command:
+        - /usr/local/bin/galley
+        - server
+        - --meshConfigFile=/etc/mesh-config/mesh
+        - --livenessProbeInterval=1s
+        - --validation-webhook-config-file
+        imagePullPolicy: Always
+        name: galley
+        ports:
+        - containerPort: 443
+        - containerPort: 15014
+        - containerPort: 9901
+`,
+		},
+		{
+			desc:  "AppendListItem",
+			path:  `spec.template.spec.containers.[-1].command.[+]`,
+			value: `--log_output_level=debug`,
+			want: `
+apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: istio-citadel
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - foo: bar
+        name: deleteThis
+      - command:
+        - /usr/local/bin/galley
+        - server
+        - --meshConfigFile=/etc/mesh-config/mesh
+        - --livenessProbeInterval=1s
+        - --validation-webhook-config-file
+        - --log_output_level=debug
+        name: galley
+        ports:
+        - containerPort: 443
+        - containerPort: 15014
+        - containerPort: 9901
 `,
 		},
 		{
@@ -461,3 +524,512 @@ func errToString(err error) string {
 	}
 	return err.Error()
 }
+
+func TestAddRemoveObjects(t *testing.T) {
+	base := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: istio-pilot-istio-system
+`
+	tests := []struct {
+		desc    string
+		k8s     string
+		want    string
+		wantErr string
+	}{
+		{
+			desc: "RemoveNamespaced",
+			k8s: `
+removeObjects:
+- kind: Service
+  name: istio-pilot
+`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: istio-pilot-istio-system
+`,
+		},
+		{
+			desc: "RemoveClusterScoped",
+			k8s: `
+removeObjects:
+- kind: ClusterRole
+  name: istio-pilot-istio-system
+`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+`,
+		},
+		{
+			desc: "RemoveWithAPIVersion",
+			k8s: `
+removeObjects:
+- apiVersion: apps/v1
+  kind: Deployment
+  name: istio-pilot
+`,
+			want: `
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: istio-pilot-istio-system
+`,
+		},
+		{
+			desc: "RemoveWrongAPIVersion",
+			k8s: `
+removeObjects:
+- apiVersion: extensions/v1beta1
+  kind: Deployment
+  name: istio-pilot
+`,
+			wantErr: "object to remove Deployment:istio-system:istio-pilot has apiVersion extensions/v1beta1, which does not match " +
+				"the output manifest object apiVersion apps/v1",
+		},
+		{
+			desc: "RemoveMissing",
+			k8s: `
+removeObjects:
+- kind: ConfigMap
+  name: istio-pilot
+`,
+			wantErr: "object to remove ConfigMap:istio-system:istio-pilot does not match any object in output manifest",
+		},
+		{
+			desc: "AddNamespacedAndClusterScoped",
+			k8s: `
+extraObjects:
+- apiVersion: networking.k8s.io/v1
+  kind: NetworkPolicy
+  metadata:
+    name: istio-pilot
+  spec:
+    podSelector:
+      matchLabels:
+        istio: pilot
+- apiVersion: scheduling.k8s.io/v1
+  kind: PriorityClass
+  metadata:
+    name: istio-high
+  value: 1000000
+`,
+			want: base + `
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+spec:
+  podSelector:
+    matchLabels:
+      istio: pilot
+---
+apiVersion: scheduling.k8s.io/v1
+kind: PriorityClass
+metadata:
+  name: istio-high
+value: 1000000
+`,
+		},
+		{
+			desc: "AddReplacesRemoved",
+			k8s: `
+removeObjects:
+- kind: Service
+  name: istio-pilot
+extraObjects:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: istio-pilot
+  spec:
+    type: NodePort
+`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: istio-pilot-istio-system
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+spec:
+  type: NodePort
+`,
+		},
+		{
+			desc: "AddExisting",
+			k8s: `
+extraObjects:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: istio-pilot
+`,
+			wantErr: "extra object Service:istio-system:istio-pilot already exists in output manifest, use an overlay to modify it",
+		},
+		{
+			desc: "AddMissingName",
+			k8s: `
+extraObjects:
+- apiVersion: v1
+  kind: ConfigMap
+`,
+			wantErr: `extra object must have a kind and metadata.name:
+{"apiVersion":"v1","kind":"ConfigMap"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rc := &v1alpha2.KubernetesResourcesSpec{}
+			if err := util.UnmarshalWithJSONPB(tt.k8s, rc); err != nil {
+				t.Fatalf("unmarshalWithJSONPB(%s): got error %s", tt.desc, err)
+			}
+			got, err := RemoveObjects(base, "istio-system", rc.RemoveObjects)
+			if err == nil {
+				got, err = AddObjects(got, "istio-system", rc.ExtraObjects)
+			}
+			gotErr := errToString(err)
+			if tt.wantErr != "" {
+				if !strings.HasPrefix(gotErr, tt.wantErr) {
+					t.Fatalf("AddObjects/RemoveObjects(%s): gotErr:%s, wantErr:%s", tt.desc, gotErr, tt.wantErr)
+				}
+				return
+			}
+			if gotErr != "" {
+				t.Fatalf("AddObjects/RemoveObjects(%s): got unexpected error: %s", tt.desc, gotErr)
+			}
+			if err := manifestsEqual(got, tt.want); err != nil {
+				t.Errorf("AddObjects/RemoveObjects(%s): %s\ngot:\n%s\n\nwant:\n%s", tt.desc, err, got, tt.want)
+			}
+		})
+	}
+}
+
+// manifestsEqual returns an error if manifests a and b don't contain the same objects in the same order.
+func manifestsEqual(a, b string) error {
+	aos, err := object.ParseK8sObjectsFromYAMLManifest(a)
+	if err != nil {
+		return err
+	}
+	bos, err := object.ParseK8sObjectsFromYAMLManifest(b)
+	if err != nil {
+		return err
+	}
+	if len(aos) != len(bos) {
+		return fmt.Errorf("got %d objects, want %d", len(aos), len(bos))
+	}
+	for i := range aos {
+		ay, err := aos[i].YAMLDebugString()
+		if err != nil {
+			return err
+		}
+		by, err := bos[i].YAMLDebugString()
+		if err != nil {
+			return err
+		}
+		if !util.IsYAMLEqual(ay, by) {
+			return fmt.Errorf("object %d differs:\n%s", i, util.YAMLDiff(ay, by))
+		}
+	}
+	return nil
+}
+
+func TestAddCommonMetadata(t *testing.T) {
+	base := `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: istio-system
+  labels:
+    istio-injection: disabled
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+  labels:
+    app: pilot
+spec:
+  template:
+    metadata:
+      labels:
+        app: pilot
+    spec:
+      containers:
+      - name: discovery
+`
+	tests := []struct {
+		desc    string
+		icp     string
+		want    string
+		wantErr string
+	}{
+		{
+			desc: "NoMetadata",
+			icp:  `profile: default`,
+			want: base,
+		},
+		{
+			desc: "AllMetadata",
+			icp: `
+commonLabels:
+  team: mesh
+  app: override
+commonAnnotations:
+  owner: mesh-team
+commonPodLabels:
+  env: prod
+commonPodAnnotations:
+  sidecar.istio.io/inject: "false"
+`,
+			want: `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: istio-system
+  annotations:
+    owner: mesh-team
+  labels:
+    app: override
+    istio-injection: disabled
+    team: mesh
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+  annotations:
+    owner: mesh-team
+  labels:
+    app: override
+    team: mesh
+spec:
+  template:
+    metadata:
+      annotations:
+        sidecar.istio.io/inject: "false"
+      labels:
+        app: pilot
+        env: prod
+    spec:
+      containers:
+      - name: discovery
+`,
+		},
+		{
+			desc: "PodLabelSameAsChart",
+			icp: `
+commonPodLabels:
+  app: pilot
+`,
+			want: base,
+		},
+		{
+			desc: "PodLabelConflictsWithChart",
+			icp: `
+commonPodLabels:
+  app: mesh
+  env: prod
+`,
+			wantErr: "commonPodLabels.app: label app is already set to pilot in the pod template of Deployment:istio-pilot",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			icp := &v1alpha2.IstioControlPlaneSpec{}
+			if err := util.UnmarshalWithJSONPB(tt.icp, icp); err != nil {
+				t.Fatalf("unmarshalWithJSONPB(%s): got error %s", tt.desc, err)
+			}
+			got, err := AddCommonMetadata(base, icp)
+			if gotErr, wantErr := errToString(err), tt.wantErr; gotErr != wantErr {
+				t.Fatalf("AddCommonMetadata(%s): got error: %s, want error: %s", tt.desc, gotErr, wantErr)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if err := manifestsEqual(got, tt.want); err != nil {
+				t.Errorf("AddCommonMetadata(%s): %s\ngot:\n%s\n\nwant:\n%s", tt.desc, err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenameObjects(t *testing.T) {
+	base := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-ingressgateway
+  namespace: istio-system
+  labels:
+    app: istio-ingressgateway
+    istio: ingressgateway
+    release: istio
+spec:
+  selector:
+    matchLabels:
+      app: istio-ingressgateway
+      istio: ingressgateway
+  template:
+    metadata:
+      labels:
+        app: istio-ingressgateway
+        istio: ingressgateway
+    spec:
+      serviceAccountName: istio-ingressgateway-service-account
+      containers:
+      - name: istio-proxy
+        env:
+        - name: ISTIO_METAJSON_LABELS
+          value: |
+            {"app":"istio-ingressgateway","istio":"ingressgateway"}
+        volumeMounts:
+        - name: ingressgateway-certs
+          mountPath: /etc/istio/ingressgateway-certs
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: istio-ingressgateway-service-account
+  namespace: istio-system
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: Gateway
+metadata:
+  name: ingressgateway
+  namespace: istio-system
+spec:
+  selector:
+    istio: ingressgateway
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: Sidecar
+metadata:
+  name: default
+  namespace: istio-system
+`
+	want := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: internal-gw
+  namespace: istio-system
+  labels:
+    app: internal-gw
+    istio: internal-gw
+    release: istio
+    zone: internal
+spec:
+  selector:
+    matchLabels:
+      app: internal-gw
+      istio: internal-gw
+      zone: internal
+  template:
+    metadata:
+      labels:
+        app: internal-gw
+        istio: internal-gw
+        zone: internal
+    spec:
+      serviceAccountName: internal-gw-service-account
+      containers:
+      - name: istio-proxy
+        env:
+        - name: ISTIO_METAJSON_LABELS
+          value: |
+            {"app":"internal-gw","istio":"internal-gw","zone":"internal"}
+        volumeMounts:
+        - name: ingressgateway-certs
+          mountPath: /etc/istio/ingressgateway-certs
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: internal-gw-service-account
+  namespace: istio-system
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: Gateway
+metadata:
+  name: internal-gw
+  namespace: istio-system
+spec:
+  selector:
+    app: internal-gw
+    istio: internal-gw
+    zone: internal
+---
+apiVersion: networking.istio.io/v1alpha3
+kind: Sidecar
+metadata:
+  name: default
+  namespace: istio-system
+`
+	fromLabels := map[string]string{"app": "istio-ingressgateway", "istio": "ingressgateway"}
+	toLabels := map[string]string{"app": "internal-gw", "istio": "internal-gw", "zone": "internal"}
+	got, err := RenameObjects(base, "istio-ingressgateway", "internal-gw", []string{"ingressgateway"}, fromLabels, toLabels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := manifestsEqual(got, want); err != nil {
+		t.Errorf("RenameObjects: %s\ngot:\n%s\n\nwant:\n%s", err, got, want)
+	}
+
+	_, err = RenameObjects(base, "istio-egressgateway", "internal-gw", nil, fromLabels, toLabels)
+	if got, want := errToString(err), "no object named istio-egressgateway to rename to internal-gw"; got != want {
+		t.Errorf("RenameObjects: got error: %s, want error: %s", got, want)
+	}
+}
@@ -0,0 +1,388 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package policy evaluates user defined rules against the k8s objects rendered for each component.
+
+A rules file is a YAML document with a list of rules, for example:
+
+  rules:
+  - name: no-privileged-containers
+    severity: ERROR
+    kinds: [Deployment, DaemonSet]
+    path: spec.template.spec.containers.[*].securityContext.privileged
+    notEquals: true
+  - name: container-limits
+    kinds: [Deployment, DaemonSet]
+    path: spec.template.spec.containers.[*]
+    required: [resources.limits.cpu, resources.limits.memory]
+
+path is a tpath, which may select any number of nodes in each object. Each selected node is checked with the single
+assertion set in the rule:
+  - forbidden: true, the path must not select any node.
+  - required, a list of paths relative to the selected node which must all be present.
+  - equals or notEquals, the selected node must or must not equal a value.
+  - matches or notMatches, the selected node must or must not match a regex.
+
+A rule applies to every object unless restricted with kinds, components or excludeComponents.
+*/
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"istio.io/operator/pkg/name"
+	"istio.io/operator/pkg/object"
+	"istio.io/operator/pkg/tpath"
+	"istio.io/operator/pkg/util"
+	"istio.io/operator/pkg/validate"
+)
+
+// Rules is the content of a rules file.
+type Rules struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// Rule is an assertion about the nodes at a path in rendered k8s objects.
+type Rule struct {
+	// Name uniquely identifies the rule.
+	Name string `json:"name"`
+	// Description is an optional description of the rule.
+	Description string `json:"description,omitempty"`
+	// Severity is the severity of violations of the rule. It defaults to ERROR.
+	Severity validate.Severity `json:"severity,omitempty"`
+	// Kinds restricts the rule to objects of the given kinds.
+	Kinds []string `json:"kinds,omitempty"`
+	// Components restricts the rule to objects of the given components.
+	Components []string `json:"components,omitempty"`
+	// ExcludeComponents excludes objects of the given components from the rule.
+	ExcludeComponents []string `json:"excludeComponents,omitempty"`
+	// Path selects the nodes in each object that the assertion is checked against.
+	Path string `json:"path"`
+
+	// Forbidden asserts that Path does not select any node.
+	Forbidden bool `json:"forbidden,omitempty"`
+	// Required asserts that each of the given paths, relative to each selected node, is present.
+	Required []string `json:"required,omitempty"`
+	// Equals asserts that each selected node equals the given value.
+	Equals interface{} `json:"equals,omitempty"`
+	// NotEquals asserts that each selected node does not equal the given value.
+	NotEquals interface{} `json:"notEquals,omitempty"`
+	// Matches asserts that each selected node matches the given regex.
+	Matches string `json:"matches,omitempty"`
+	// NotMatches asserts that each selected node does not match the given regex.
+	NotMatches string `json:"notMatches,omitempty"`
+
+	matchesRegex    *regexp.Regexp
+	notMatchesRegex *regexp.Regexp
+}
+
+// Violation is a node in a rendered object which fails a rule.
+type Violation struct {
+	// Rule is the name of the rule which failed.
+	Rule string
+	// Severity is the severity of the rule.
+	Severity validate.Severity
+	// Component is the name of the component the object belongs to.
+	Component name.ComponentName
+	// Object is the hash of the object, in kind:namespace:name format.
+	Object string
+	// Path is the path of the node in the object.
+	Path string
+	// Message describes the violation.
+	Message string
+}
+
+// String implements the Stringer interface.
+func (v *Violation) String() string {
+	return fmt.Sprintf("%s %s %s %s: %s [%s]", v.Severity, v.Component, v.Object, v.Path, v.Message, v.Rule)
+}
+
+// Violations is a slice of Violation.
+type Violations []*Violation
+
+// Errors returns the violations in v with error severity.
+func (v Violations) Errors() Violations {
+	var out Violations
+	for _, vv := range v {
+		if vv.Severity == validate.SeverityError {
+			out = append(out, vv)
+		}
+	}
+	return out
+}
+
+// String implements the Stringer interface.
+func (v Violations) String() string {
+	var out []string
+	for _, vv := range v {
+		out = append(out, vv.String())
+	}
+	return strings.Join(out, "\n")
+}
+
+// ReadRulesFile reads and parses the rules file at path.
+func ReadRulesFile(path string) (*Rules, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read rules file %s: %s", path, err)
+	}
+	rs, err := ParseRules(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("bad rules file %s: %s", path, err)
+	}
+	return rs, nil
+}
+
+// ParseRules parses and checks the rules in rulesYAML.
+func ParseRules(rulesYAML string) (*Rules, error) {
+	j, err := yaml.YAMLToJSON([]byte(rulesYAML))
+	if err != nil {
+		return nil, err
+	}
+	// Unknown fields are rejected, since a misspelled assertion or filter would silently weaken a rule.
+	dec := json.NewDecoder(bytes.NewReader(j))
+	dec.DisallowUnknownFields()
+	rs := &Rules{}
+	if err := dec.Decode(rs); err != nil {
+		return nil, err
+	}
+	var errs util.Errors
+	seen := make(map[string]bool)
+	for i, r := range rs.Rules {
+		if r.Name == "" {
+			errs = util.AppendErr(errs, fmt.Errorf("rule %d: name is required", i))
+			continue
+		}
+		if seen[r.Name] {
+			errs = util.AppendErr(errs, fmt.Errorf("rule %s: duplicate name", r.Name))
+		}
+		seen[r.Name] = true
+		errs = util.AppendErr(errs, r.init())
+	}
+	if len(errs) != 0 {
+		return nil, errs.ToError()
+	}
+	return rs, nil
+}
+
+// init checks r and fills in defaults.
+func (r *Rule) init() error {
+	switch r.Severity {
+	case "":
+		r.Severity = validate.SeverityError
+	case validate.SeverityError, validate.SeverityWarning:
+	default:
+		return fmt.Errorf("rule %s: severity must be %s or %s, got %s", r.Name, validate.SeverityError,
+			validate.SeverityWarning, r.Severity)
+	}
+	if r.Path == "" {
+		return fmt.Errorf("rule %s: path is required", r.Name)
+	}
+	assertions := 0
+	for _, set := range []bool{r.Forbidden, len(r.Required) != 0, r.Equals != nil, r.NotEquals != nil, r.Matches != "",
+		r.NotMatches != ""} {
+		if set {
+			assertions++
+		}
+	}
+	if assertions != 1 {
+		return fmt.Errorf("rule %s: exactly one of forbidden, required, equals, notEquals, matches or notMatches must be set",
+			r.Name)
+	}
+	var err error
+	if r.Matches != "" {
+		if r.matchesRegex, err = regexp.Compile(r.Matches); err != nil {
+			return fmt.Errorf("rule %s: bad regex in matches: %s", r.Name, err)
+		}
+	}
+	if r.NotMatches != "" {
+		if r.notMatchesRegex, err = regexp.Compile(r.NotMatches); err != nil {
+			return fmt.Errorf("rule %s: bad regex in notMatches: %s", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// CheckManifests evaluates the rules against the objects in each component manifest in manifests and returns all
+// violations, ordered by component, object and path.
+func (rs *Rules) CheckManifests(manifests name.ManifestMap) (Violations, error) {
+	var out Violations
+	for cn, m := range manifests {
+		objs, err := object.ParseK8sObjectsFromYAMLManifest(m)
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %s", cn, err)
+		}
+		v, err := rs.CheckObjects(cn, objs)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v...)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		switch {
+		case out[i].Component != out[j].Component:
+			return out[i].Component < out[j].Component
+		case out[i].Object != out[j].Object:
+			return out[i].Object < out[j].Object
+		}
+		return out[i].Path < out[j].Path
+	})
+	return out, nil
+}
+
+// CheckObjects evaluates the rules against objs, which belong to the component cn.
+func (rs *Rules) CheckObjects(cn name.ComponentName, objs object.K8sObjects) (Violations, error) {
+	var out Violations
+	for _, o := range objs {
+		for _, r := range rs.Rules {
+			if !r.appliesTo(cn, o) {
+				continue
+			}
+			v, err := r.check(o)
+			if err != nil {
+				return nil, fmt.Errorf("rule %s, component %s, object %s: %s", r.Name, cn, o.Hash(), err)
+			}
+			for _, vv := range v {
+				vv.Component = cn
+			}
+			out = append(out, v...)
+		}
+	}
+	return out, nil
+}
+
+// appliesTo reports whether r applies to object o of component cn.
+func (r *Rule) appliesTo(cn name.ComponentName, o *object.K8sObject) bool {
+	switch {
+	case len(r.Kinds) != 0 && !contains(r.Kinds, o.Kind):
+		return false
+	case len(r.Components) != 0 && !contains(r.Components, string(cn)):
+		return false
+	case contains(r.ExcludeComponents, string(cn)):
+		return false
+	}
+	return true
+}
+
+// check returns the violations of r in o.
+func (r *Rule) check(o *object.K8sObject) (Violations, error) {
+	ncs, err := tpath.FindNodes(o.UnstructuredObject().Object, util.PathFromString(r.Path))
+	if err != nil {
+		return nil, err
+	}
+	var out Violations
+	for _, nc := range ncs {
+		msg := r.checkNode(nc.Node)
+		if msg == "" {
+			continue
+		}
+		out = append(out, &Violation{
+			Rule:     r.Name,
+			Severity: r.Severity,
+			Object:   o.Hash(),
+			Path:     pathOf(nc).String(),
+			Message:  msg,
+		})
+	}
+	return out, nil
+}
+
+// checkNode returns a message describing why node fails the assertion in r, or an empty string if it passes.
+func (r *Rule) checkNode(node interface{}) string {
+	node = deref(node)
+	switch {
+	case r.Forbidden:
+		return "path must not be set"
+	case len(r.Required) != 0:
+		var missing []string
+		for _, p := range r.Required {
+			if ncs, err := tpath.FindNodes(node, util.PathFromString(p)); err != nil || len(ncs) == 0 {
+				missing = append(missing, p)
+			}
+		}
+		if len(missing) != 0 {
+			return "missing " + strings.Join(missing, ", ")
+		}
+	case r.Equals != nil:
+		if !valuesEqual(node, r.Equals) {
+			return fmt.Sprintf("got %v, must be %v", node, r.Equals)
+		}
+	case r.NotEquals != nil:
+		if valuesEqual(node, r.NotEquals) {
+			return fmt.Sprintf("must not be %v", r.NotEquals)
+		}
+	case r.matchesRegex != nil:
+		if !r.matchesRegex.MatchString(fmt.Sprint(node)) {
+			return fmt.Sprintf("%v does not match %s", node, r.Matches)
+		}
+	case r.notMatchesRegex != nil:
+		if r.notMatchesRegex.MatchString(fmt.Sprint(node)) {
+			return fmt.Sprintf("%v must not match %s", node, r.NotMatches)
+		}
+	}
+	return ""
+}
+
+// pathOf returns the concrete path from the root to the node in nc, with list entries selected by index.
+func pathOf(nc *tpath.PathContext) util.Path {
+	var out util.Path
+	for p := nc.Parent; p != nil; p = p.Parent {
+		pe := fmt.Sprint(p.KeyToChild)
+		if _, ok := p.KeyToChild.(int); ok {
+			pe = "[" + pe + "]"
+		}
+		out = append(util.Path{pe}, out...)
+	}
+	return out
+}
+
+// deref returns the value of node if it is a ptr to a list, as used for list nodes in a PathContext.
+func deref(node interface{}) interface{} {
+	if p, ok := node.(*interface{}); ok {
+		return *p
+	}
+	return node
+}
+
+// valuesEqual reports whether a and b are equal. Leaf values are compared in string form, since numbers parsed from
+// the rules file and from rendered objects may have different types.
+func valuesEqual(a, b interface{}) bool {
+	if util.IsValueNil(a) || util.IsValueNil(b) {
+		return util.IsValueNil(a) && util.IsValueNil(b)
+	}
+	if !tpath.IsLeafNode(a) || !tpath.IsLeafNode(b) {
+		ay, erra := yaml.Marshal(a)
+		by, errb := yaml.Marshal(b)
+		return erra == nil && errb == nil && string(ay) == string(by)
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/kr/pretty"
+
+	"istio.io/operator/pkg/name"
+)
+
+const (
+	pilotManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: discovery
+        image: docker.io/istio/pilot:1.4.0
+        resources:
+          limits:
+            cpu: 500m
+            memory: 2Gi
+      - name: istio-proxy
+        image: quay.io/other/proxyv2:1.4.0
+        resources:
+          limits:
+            cpu: 100m
+        securityContext:
+          privileged: true
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+spec:
+  ports:
+  - port: 15010
+`
+	cniManifest = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: istio-cni-node
+  namespace: kube-system
+spec:
+  template:
+    spec:
+      hostNetwork: true
+      containers:
+      - name: install-cni
+        image: docker.io/istio/install-cni:1.4.0
+        resources:
+          limits:
+            cpu: 100m
+            memory: 100Mi
+`
+)
+
+func TestCheckManifests(t *testing.T) {
+	manifests := name.ManifestMap{
+		name.PilotComponentName: pilotManifest,
+		name.CNIComponentName:   cniManifest,
+	}
+	tests := []struct {
+		desc    string
+		rules   string
+		want    []string
+		wantErr string
+	}{
+		{
+			desc: "NotEquals",
+			rules: `
+rules:
+- name: no-privileged-containers
+  kinds: [Deployment, DaemonSet]
+  path: spec.template.spec.containers.[*].securityContext.privileged
+  notEquals: true
+`,
+			want: []string{
+				"ERROR Pilot Deployment:istio-system:istio-pilot spec.template.spec.containers.[1].securityContext.privileged: " +
+					"must not be true [no-privileged-containers]",
+			},
+		},
+		{
+			desc: "Required",
+			rules: `
+rules:
+- name: container-limits
+  severity: WARNING
+  path: spec.template.spec.containers.[*]
+  required: [resources.limits.cpu, resources.limits.memory]
+`,
+			want: []string{
+				"WARNING Pilot Deployment:istio-system:istio-pilot spec.template.spec.containers.[1]: missing " +
+					"resources.limits.memory [container-limits]",
+			},
+		},
+		{
+			desc: "ExcludeComponents",
+			rules: `
+rules:
+- name: no-host-network
+  excludeComponents: [Cni]
+  path: spec.template.spec.hostNetwork
+  forbidden: true
+- name: cni-host-network
+  components: [Cni]
+  path: spec.template.spec.hostNetwork
+  equals: false
+`,
+			want: []string{
+				"ERROR Cni DaemonSet:kube-system:istio-cni-node spec.template.spec.hostNetwork: got true, must be false " +
+					"[cni-host-network]",
+			},
+		},
+		{
+			desc: "Matches",
+			rules: `
+rules:
+- name: approved-registries
+  path: "**.containers.[*].image"
+  matches: ^docker\.io/istio/
+- name: service-ports
+  kinds: [Service]
+  path: spec.ports.[*].port
+  notMatches: ^150
+`,
+			want: []string{
+				"ERROR Pilot Deployment:istio-system:istio-pilot spec.template.spec.containers.[1].image: " +
+					"quay.io/other/proxyv2:1.4.0 does not match ^docker\\.io/istio/ [approved-registries]",
+				"ERROR Pilot Service:istio-system:istio-pilot spec.ports.[0].port: 15010 must not match ^150 [service-ports]",
+			},
+		},
+		{
+			desc: "UnknownField",
+			rules: `
+rules:
+- name: typo
+  path: spec
+  notEqual: true
+`,
+			wantErr: `json: unknown field "notEqual"`,
+		},
+		{
+			desc: "BadRules",
+			rules: `
+rules:
+- path: spec
+  forbidden: true
+- name: dup
+  path: spec
+  forbidden: true
+- name: dup
+  severity: INFO
+  path: spec
+  forbidden: true
+- name: no-assertion
+  path: spec
+- name: two-assertions
+  path: spec
+  forbidden: true
+  matches: x
+- name: bad-regex
+  path: spec
+  matches: "["
+`,
+			wantErr: "rule 0: name is required, rule dup: duplicate name, rule dup: severity must be ERROR or WARNING, got INFO, " +
+				"rule no-assertion: exactly one of forbidden, required, equals, notEquals, matches or notMatches must be set, " +
+				"rule two-assertions: exactly one of forbidden, required, equals, notEquals, matches or notMatches must be set, " +
+				"rule bad-regex: bad regex in matches: error parsing regexp: missing closing ]: `[`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			rs, err := ParseRules(tt.rules)
+			if gotErr, wantErr := errToString(err), tt.wantErr; gotErr != wantErr {
+				t.Fatalf("ParseRules(%s): gotErr:%s, wantErr:%s", tt.desc, gotErr, wantErr)
+			}
+			if err != nil {
+				return
+			}
+			v, err := rs.CheckManifests(manifests)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, vv := range v {
+				got = append(got, vv.String())
+			}
+			if diff := pretty.Diff(got, tt.want); len(diff) != 0 {
+				t.Errorf("CheckManifests(%s): got:\n%s\nwant:\n%s", tt.desc, pretty.Sprint(got), pretty.Sprint(tt.want))
+			}
+		})
+	}
+}
+
+func TestReadRulesFileSample(t *testing.T) {
+	if _, err := ReadRulesFile("../../samples/policy-rules.yaml"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// errToString returns the string representation of err and the empty string if err is nil.
+func errToString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
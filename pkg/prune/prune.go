@@ -0,0 +1,158 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package prune has the safety checks shared by the CLI and controller when pruning resources which are no longer
+rendered: objects can be protected from pruning with an annotation, and the objects that are pruned can be backed up
+first, to a file or a Secret, in a form that can be applied again to restore them. Backups are kept private, since the
+pruned objects may include Secrets.
+*/
+package prune
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"istio.io/operator/pkg/name"
+	"istio.io/operator/pkg/object"
+)
+
+const (
+	// DoNotPruneKey is the annotation which protects an object from being pruned, or deleted when the installation it
+	// belongs to is deleted, when set to "true".
+	DoNotPruneKey = name.OperatorAPINamespace + "/do-not-prune"
+
+	// BackupSecretKey is the key of the backed up objects in a backup Secret.
+	BackupSecretKey = "objects.yaml"
+
+	// backupTimeFormat is the format of the time in backup names.
+	backupTimeFormat = "20060102-150405"
+)
+
+var (
+	// serverFields are the metadata fields set by the API server, which are removed from backed up objects so they
+	// can be applied to restore them.
+	serverFields = []string{"creationTimestamp", "deletionGracePeriodSeconds", "deletionTimestamp", "generation",
+		"managedFields", "resourceVersion", "selfLink", "uid"}
+)
+
+// IsProtected reports whether obj is protected from pruning.
+func IsProtected(obj metav1.Object) bool {
+	return obj.GetAnnotations()[DoNotPruneKey] == "true"
+}
+
+// Filter returns the objects in objs which may be pruned and the objects which are protected.
+func Filter(objs []unstructured.Unstructured) (prunable, protected []unstructured.Unstructured) {
+	for _, o := range objs {
+		if IsProtected(&o) {
+			protected = append(protected, o)
+		} else {
+			prunable = append(prunable, o)
+		}
+	}
+	return prunable, protected
+}
+
+// ObjectString returns a short description of obj, e.g. Deployment istio-system/istio-pilot.
+func ObjectString(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", obj.GetKind(), obj.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
+
+// BackupManifest returns a YAML manifest of objs with the fields set by the API server removed, which restores the
+// objects when it is applied.
+func BackupManifest(objs []unstructured.Unstructured) (string, error) {
+	var us []*unstructured.Unstructured
+	for _, o := range objs {
+		c := o.DeepCopy()
+		for _, f := range serverFields {
+			unstructured.RemoveNestedField(c.Object, "metadata", f)
+		}
+		unstructured.RemoveNestedField(c.Object, "status")
+		// The owner is usually deleted along with the objects, and objects with missing owners are garbage collected.
+		unstructured.RemoveNestedField(c.Object, "metadata", "ownerReferences")
+		us = append(us, c)
+	}
+	kos, err := object.K8sObjectsFromUnstructuredSlice(us)
+	if err != nil {
+		return "", err
+	}
+	return kos.YAMLManifest()
+}
+
+// BackupName returns the name of a new backup, which is prefix followed by the current time.
+func BackupName(prefix string) string {
+	return fmt.Sprintf("%s-%s", prefix, time.Now().UTC().Format(backupTimeFormat))
+}
+
+// WriteBackupFile writes a backup of objs to a new file in dir, named with BackupName(prefix), and returns the path of
+// the file. The file is only readable by its owner.
+func WriteBackupFile(dir, prefix string, objs []unstructured.Unstructured) (string, error) {
+	manifest, err := BackupManifest(objs)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, BackupName(prefix)+".yaml")
+	if err := ioutil.WriteFile(path, []byte(manifest), 0600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// BackupSecret returns a Secret with the given namespace and name which holds a backup of objs.
+func BackupSecret(namespace, name string, objs []unstructured.Unstructured) (*corev1.Secret, error) {
+	manifest, err := BackupManifest(objs)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{BackupSecretKey: []byte(manifest)},
+	}, nil
+}
+
+// RestoreManifest returns the manifest which restores the objects in backup, which is either a file written by
+// WriteBackupFile or a backup Secret in YAML.
+func RestoreManifest(backup string) (string, error) {
+	s := &corev1.Secret{}
+	if err := yaml.Unmarshal([]byte(backup), s); err == nil && s.Kind == "Secret" && len(s.Data[BackupSecretKey]) != 0 {
+		return string(s.Data[BackupSecretKey]), nil
+	}
+	objs, err := object.ParseK8sObjectsFromYAMLManifest(backup)
+	if err != nil {
+		return "", err
+	}
+	if len(objs) == 0 {
+		return "", fmt.Errorf("backup has no objects")
+	}
+	return backup, nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prune
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newObject(kind, namespace, name string, annotations map[string]string) unstructured.Unstructured {
+	o := unstructured.Unstructured{}
+	o.SetAPIVersion("v1")
+	o.SetKind(kind)
+	o.SetNamespace(namespace)
+	o.SetName(name)
+	o.SetAnnotations(annotations)
+	return o
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		desc          string
+		objs          []unstructured.Unstructured
+		wantPrunable  []string
+		wantProtected []string
+	}{
+		{
+			desc: "no annotations",
+			objs: []unstructured.Unstructured{
+				newObject("Service", "istio-system", "a", nil),
+				newObject("ClusterRole", "", "b", nil),
+			},
+			wantPrunable: []string{"Service istio-system/a", "ClusterRole b"},
+		},
+		{
+			desc: "protected",
+			objs: []unstructured.Unstructured{
+				newObject("Service", "istio-system", "a", map[string]string{DoNotPruneKey: "true"}),
+				newObject("Service", "istio-system", "b", nil),
+			},
+			wantPrunable:  []string{"Service istio-system/b"},
+			wantProtected: []string{"Service istio-system/a"},
+		},
+		{
+			desc: "annotation not true",
+			objs: []unstructured.Unstructured{
+				newObject("Service", "istio-system", "a", map[string]string{DoNotPruneKey: "false"}),
+			},
+			wantPrunable: []string{"Service istio-system/a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			prunable, protected := Filter(tt.objs)
+			if got := objectStrings(prunable); !reflect.DeepEqual(got, tt.wantPrunable) {
+				t.Errorf("%s: got prunable %v, want %v", tt.desc, got, tt.wantPrunable)
+			}
+			if got := objectStrings(protected); !reflect.DeepEqual(got, tt.wantProtected) {
+				t.Errorf("%s: got protected %v, want %v", tt.desc, got, tt.wantProtected)
+			}
+		})
+	}
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	o := newObject("ConfigMap", "istio-system", "istio", map[string]string{"foo": "bar"})
+	o.SetResourceVersion("123")
+	o.SetUID("abc")
+	o.SetGeneration(2)
+	o.Object["status"] = map[string]interface{}{"phase": "Active"}
+	o.Object["data"] = map[string]interface{}{"mesh": "disablePolicyChecks: true"}
+	objs := []unstructured.Unstructured{o}
+
+	manifest, err := BackupManifest(objs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{"resourceVersion", "uid", "generation", "status"} {
+		if strings.Contains(manifest, f) {
+			t.Errorf("got backup with field %s, want it removed:\n%s", f, manifest)
+		}
+	}
+	for _, f := range []string{"name: istio", "namespace: istio-system", "foo: bar", "disablePolicyChecks"} {
+		if !strings.Contains(manifest, f) {
+			t.Errorf("got backup without %q:\n%s", f, manifest)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "prune-backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path, err := WriteBackupFile(dir, "pilot", objs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if got, want := fi.Mode().Perm(), os.FileMode(0600); got != want {
+		t.Errorf("got backup file mode %s, want %s", got, want)
+	}
+	file, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret, err := BackupSecret("istio-system", "backup", objs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretYAML, err := yaml.Marshal(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		desc    string
+		backup  string
+		wantErr bool
+	}{
+		{
+			desc:   "file",
+			backup: string(file),
+		},
+		{
+			desc:   "Secret",
+			backup: string(secretYAML),
+		},
+		{
+			desc:    "empty",
+			backup:  "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := RestoreManifest(tt.backup)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("%s: got error %v, want error %v", tt.desc, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != manifest {
+				t.Errorf("%s: got restore manifest\n%s\nwant\n%s", tt.desc, got, manifest)
+			}
+		})
+	}
+}
+
+func objectStrings(objs []unstructured.Unstructured) []string {
+	var out []string
+	for _, o := range objs {
+		out = append(out, ObjectString(&o))
+	}
+	return out
+}
@@ -0,0 +1,263 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readiness
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	endpointsGVK = schema.GroupVersionKind{Version: "v1", Kind: "Endpoints"}
+)
+
+func init() {
+	for gk, c := range map[schema.GroupKind]Checker{
+		{Group: "apps", Kind: "Deployment"}:                                             deploymentReady,
+		{Group: "extensions", Kind: "Deployment"}:                                       deploymentReady,
+		{Group: "apps", Kind: "StatefulSet"}:                                            statefulSetReady,
+		{Group: "apps", Kind: "DaemonSet"}:                                              daemonSetReady,
+		{Group: "extensions", Kind: "DaemonSet"}:                                        daemonSetReady,
+		{Group: "apps", Kind: "ReplicaSet"}:                                             replicaSetReady,
+		{Group: "extensions", Kind: "ReplicaSet"}:                                       replicaSetReady,
+		{Kind: "ReplicationController"}:                                                 replicaSetReady,
+		{Group: "batch", Kind: "Job"}:                                                   jobReady,
+		{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:               crdReady,
+		{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:   webhookConfigurationReady,
+		{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}: webhookConfigurationReady,
+		{Kind: "Service"}:   serviceReady,
+		{Kind: "Namespace"}: namespaceReady,
+		{Kind: "Pod"}:       podReady,
+	} {
+		Register(gk.WithVersion(""), c)
+	}
+}
+
+// deploymentReady reports whether the latest generation of a Deployment has been rolled out to all its replicas,
+// and the replicas are available.
+func deploymentReady(o *unstructured.Unstructured, _ Getter) (bool, string, error) {
+	if ok, reason := generationObserved(o); !ok {
+		return false, reason, nil
+	}
+	replicas := desiredReplicas(o)
+	if updated := statusInt(o, "updatedReplicas"); updated < replicas {
+		return false, fmt.Sprintf("%d of %d replicas updated", updated, replicas), nil
+	}
+	if old := statusInt(o, "replicas") - statusInt(o, "updatedReplicas"); old > 0 {
+		return false, fmt.Sprintf("%d old replicas pending termination", old), nil
+	}
+	if available := statusInt(o, "availableReplicas"); available < replicas {
+		return false, fmt.Sprintf("%d of %d replicas available", available, replicas), nil
+	}
+	return true, "", nil
+}
+
+// statefulSetReady reports whether the latest generation of a StatefulSet has been rolled out to the replicas its
+// update strategy updates, and all its replicas are ready.
+func statefulSetReady(o *unstructured.Unstructured, _ Getter) (bool, string, error) {
+	if ok, reason := generationObserved(o); !ok {
+		return false, reason, nil
+	}
+	replicas := desiredReplicas(o)
+	strategy, _, _ := unstructured.NestedString(o.Object, "spec", "updateStrategy", "type")
+	if strategy != "OnDelete" {
+		partition, _ := nestedInt(o.Object, "spec", "updateStrategy", "rollingUpdate", "partition")
+		if updated := statusInt(o, "updatedReplicas"); updated < replicas-partition {
+			return false, fmt.Sprintf("%d of %d replicas updated", updated, replicas-partition), nil
+		}
+	}
+	if ready := statusInt(o, "readyReplicas"); ready < replicas {
+		return false, fmt.Sprintf("%d of %d replicas ready", ready, replicas), nil
+	}
+	return true, "", nil
+}
+
+// daemonSetReady reports whether the latest generation of a DaemonSet has been rolled out to all the nodes it is
+// scheduled on, and its pods are available on all of them.
+func daemonSetReady(o *unstructured.Unstructured, _ Getter) (bool, string, error) {
+	if ok, reason := generationObserved(o); !ok {
+		return false, reason, nil
+	}
+	desired := statusInt(o, "desiredNumberScheduled")
+	strategy, _, _ := unstructured.NestedString(o.Object, "spec", "updateStrategy", "type")
+	if updated := statusInt(o, "updatedNumberScheduled"); strategy != "OnDelete" && updated < desired {
+		return false, fmt.Sprintf("%d of %d pods updated", updated, desired), nil
+	}
+	if available := statusInt(o, "numberAvailable"); available < desired {
+		return false, fmt.Sprintf("%d of %d pods available", available, desired), nil
+	}
+	return true, "", nil
+}
+
+// replicaSetReady reports whether all the replicas of a ReplicaSet or ReplicationController are ready.
+func replicaSetReady(o *unstructured.Unstructured, _ Getter) (bool, string, error) {
+	if ok, reason := generationObserved(o); !ok {
+		return false, reason, nil
+	}
+	replicas := desiredReplicas(o)
+	if ready := statusInt(o, "readyReplicas"); ready < replicas {
+		return false, fmt.Sprintf("%d of %d replicas ready", ready, replicas), nil
+	}
+	return true, "", nil
+}
+
+// jobReady reports whether a Job has completed. It returns an error if the Job failed, since it will never be ready.
+func jobReady(o *unstructured.Unstructured, _ Getter) (bool, string, error) {
+	if status, message := condition(o, "Failed"); status == "True" {
+		return false, "", fmt.Errorf("job failed: %s", message)
+	}
+	if status, _ := condition(o, "Complete"); status != "True" {
+		return false, "job has not completed", nil
+	}
+	return true, "", nil
+}
+
+// crdReady reports whether a CustomResourceDefinition is established, so that resources of its kind can be created.
+func crdReady(o *unstructured.Unstructured, _ Getter) (bool, string, error) {
+	if status, _ := condition(o, "Established"); status != "True" {
+		return false, "not established", nil
+	}
+	return true, "", nil
+}
+
+// webhookConfigurationReady reports whether the services that the webhooks of a webhook configuration call have
+// ready endpoints, so that requests to the API server which call the webhooks do not fail.
+func webhookConfigurationReady(o *unstructured.Unstructured, get Getter) (bool, string, error) {
+	webhooks, _, _ := unstructured.NestedSlice(o.Object, "webhooks")
+	for _, w := range webhooks {
+		wm, ok := w.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		namespace, _, _ := unstructured.NestedString(wm, "clientConfig", "service", "namespace")
+		name, _, _ := unstructured.NestedString(wm, "clientConfig", "service", "name")
+		if name == "" {
+			// The webhook calls a URL rather than a service in the cluster.
+			continue
+		}
+		ep, err := get(endpointsGVK, namespace, name)
+		if err != nil {
+			return false, "", err
+		}
+		if ep == nil || !hasReadyAddresses(ep) {
+			return false, fmt.Sprintf("service %s/%s has no ready endpoints", namespace, name), nil
+		}
+	}
+	return true, "", nil
+}
+
+// serviceReady reports whether a Service has a cluster IP, if it is not headless, and an ingress, if it is a
+// LoadBalancer Service.
+func serviceReady(o *unstructured.Unstructured, _ Getter) (bool, string, error) {
+	serviceType, _, _ := unstructured.NestedString(o.Object, "spec", "type")
+	// ExternalName Services are external to the cluster so they are not checked.
+	if serviceType == "ExternalName" {
+		return true, "", nil
+	}
+	if clusterIP, _, _ := unstructured.NestedString(o.Object, "spec", "clusterIP"); clusterIP == "" {
+		return false, "no cluster IP assigned", nil
+	}
+	if serviceType == "LoadBalancer" {
+		ingress, _, _ := unstructured.NestedSlice(o.Object, "status", "loadBalancer", "ingress")
+		if len(ingress) == 0 {
+			return false, "no load balancer ingress assigned", nil
+		}
+	}
+	return true, "", nil
+}
+
+// namespaceReady reports whether a Namespace is active.
+func namespaceReady(o *unstructured.Unstructured, _ Getter) (bool, string, error) {
+	if phase, _, _ := unstructured.NestedString(o.Object, "status", "phase"); phase != "Active" {
+		return false, fmt.Sprintf("phase is %q", phase), nil
+	}
+	return true, "", nil
+}
+
+// podReady reports whether a Pod has the Ready condition.
+func podReady(o *unstructured.Unstructured, _ Getter) (bool, string, error) {
+	if status, _ := condition(o, "Ready"); status != "True" {
+		return false, "pod is not ready", nil
+	}
+	return true, "", nil
+}
+
+// generationObserved reports whether the controller of o has observed its latest generation.
+func generationObserved(o *unstructured.Unstructured) (bool, string) {
+	generation, _ := nestedInt(o.Object, "metadata", "generation")
+	if observed := statusInt(o, "observedGeneration"); observed < generation {
+		return false, fmt.Sprintf("generation %d not yet observed, at generation %d", generation, observed)
+	}
+	return true, ""
+}
+
+// desiredReplicas returns the number of replicas in the spec of o, which defaults to 1.
+func desiredReplicas(o *unstructured.Unstructured) int64 {
+	replicas, found := nestedInt(o.Object, "spec", "replicas")
+	if !found {
+		return 1
+	}
+	return replicas
+}
+
+// statusInt returns the integer status field of o, or 0 if it is not set.
+func statusInt(o *unstructured.Unstructured, field string) int64 {
+	v, _ := nestedInt(o.Object, "status", field)
+	return v
+}
+
+// nestedInt returns the integer field of obj at the given path, and whether it is set. Integers decoded from YAML,
+// rather than JSON, are float64.
+func nestedInt(obj map[string]interface{}, fields ...string) (int64, bool) {
+	v, found, _ := unstructured.NestedFieldNoCopy(obj, fields...)
+	switch n := v.(type) {
+	case int64:
+		return n, found
+	case float64:
+		return int64(n), found
+	}
+	return 0, false
+}
+
+// condition returns the status and message of the condition of o with the given type, or empty strings if o has
+// no such condition.
+func condition(o *unstructured.Unstructured, conditionType string) (string, string) {
+	conditions, _, _ := unstructured.NestedSlice(o.Object, "status", "conditions")
+	for _, c := range conditions {
+		cm, ok := c.(map[string]interface{})
+		if !ok || cm["type"] != conditionType {
+			continue
+		}
+		status, _ := cm["status"].(string)
+		message, _ := cm["message"].(string)
+		return status, message
+	}
+	return "", ""
+}
+
+// hasReadyAddresses reports whether the Endpoints ep have any ready address.
+func hasReadyAddresses(ep *unstructured.Unstructured) bool {
+	subsets, _, _ := unstructured.NestedSlice(ep.Object, "subsets")
+	for _, s := range subsets {
+		if sm, ok := s.(map[string]interface{}); ok {
+			if addresses, _, _ := unstructured.NestedSlice(sm, "addresses"); len(addresses) != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package readiness checks whether applied objects are ready, for both the CLI and the controller. The check for an
+object is looked up by its GroupVersionKind in a registry, which has checks for workloads, jobs, CRDs, webhook
+configurations, services and namespaces. Objects of kinds with no registered check are ready once they exist.
+
+Additional checks can be registered with Register, e.g.
+
+	readiness.Register(schema.GroupVersionKind{Group: "example.com", Kind: "Widget"}, widgetReady)
+*/
+package readiness
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"istio.io/operator/pkg/inventory"
+	"istio.io/pkg/log"
+)
+
+const (
+	// DefaultPollInterval is how often objects are checked while waiting for them to be ready, unless the caller
+	// needs a different interval.
+	DefaultPollInterval = 2 * time.Second
+)
+
+// Getter returns the object with the given kind, namespace and name, or nil if it does not exist.
+type Getter func(gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error)
+
+// Checker reports whether o is ready and, if it is not, the reason. get is used to get the objects that the
+// readiness of o depends on, such as the endpoints of a service.
+type Checker func(o *unstructured.Unstructured, get Getter) (bool, string, error)
+
+var (
+	// mu protects checkers.
+	mu sync.RWMutex
+	// checkers is the Checker for each GroupVersionKind. A Checker registered with an empty version applies to all
+	// versions of the kind.
+	checkers = make(map[schema.GroupVersionKind]Checker)
+)
+
+// Register registers checker as the readiness check for objects of the given kind, replacing any existing check. If
+// the version of gvk is empty, checker applies to every version of the kind which has no check of its own.
+func Register(gvk schema.GroupVersionKind, checker Checker) {
+	mu.Lock()
+	defer mu.Unlock()
+	checkers[gvk] = checker
+}
+
+// CheckerFor returns the readiness check for objects of the given kind, or nil if there is none.
+func CheckerFor(gvk schema.GroupVersionKind) Checker {
+	mu.RLock()
+	defer mu.RUnlock()
+	if c, ok := checkers[gvk]; ok {
+		return c
+	}
+	return checkers[schema.GroupVersionKind{Group: gvk.Group, Kind: gvk.Kind}]
+}
+
+// Check reports whether o is ready and, if it is not, the reason.
+func Check(o *unstructured.Unstructured, get Getter) (bool, string, error) {
+	c := CheckerFor(o.GroupVersionKind())
+	if c == nil {
+		return true, "", nil
+	}
+	return c(o, get)
+}
+
+// Wait waits up to timeout for the objects referred to in refs to exist and be ready, checking them every interval.
+// It returns an error naming an object which is not ready, and the reason, if the timeout is reached.
+func Wait(refs []inventory.Ref, get Getter, interval, timeout time.Duration) error {
+	var notReady string
+	err := wait.PollImmediate(interval, timeout, func() (bool, error) {
+		for _, r := range refs {
+			o, err := get(r.GroupVersionKind, r.Namespace, r.Name)
+			if err != nil {
+				return false, fmt.Errorf("could not get %s: %s", r, err)
+			}
+			reason := "it does not exist"
+			ready := false
+			if o != nil {
+				if ready, reason, err = Check(o, get); err != nil {
+					return false, fmt.Errorf("%s failed: %s", r, err)
+				}
+			}
+			if !ready {
+				notReady = fmt.Sprintf("%s is not ready: %s", r, reason)
+				log.Debugf("%s", notReady)
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return fmt.Errorf("timed out after %s, %s", timeout, notReady)
+	}
+	return err
+}
@@ -0,0 +1,394 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package readiness
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"istio.io/operator/pkg/inventory"
+)
+
+// objectFromYAML returns the object in y.
+func objectFromYAML(t *testing.T, y string) *unstructured.Unstructured {
+	t.Helper()
+	o := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(y), &o.Object); err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+// getterFor returns a Getter for objects.
+func getterFor(objects ...*unstructured.Unstructured) Getter {
+	return func(gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+		for _, o := range objects {
+			if o.GroupVersionKind() == gvk && o.GetNamespace() == namespace && o.GetName() == name {
+				return o, nil
+			}
+		}
+		return nil, nil
+	}
+}
+
+func TestCheck(t *testing.T) {
+	readyEndpoints := `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: istio-galley
+  namespace: istio-system
+subsets:
+- addresses:
+  - ip: 10.0.0.1
+`
+	tests := []struct {
+		desc       string
+		object     string
+		related    []string
+		want       bool
+		wantReason string
+		wantErr    string
+	}{
+		{
+			desc: "deployment ready",
+			object: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  generation: 2
+spec:
+  replicas: 2
+status:
+  observedGeneration: 2
+  replicas: 2
+  updatedReplicas: 2
+  availableReplicas: 2
+`,
+			want: true,
+		},
+		{
+			desc: "deployment generation not observed",
+			object: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  generation: 2
+status:
+  observedGeneration: 1
+  replicas: 1
+  updatedReplicas: 1
+  availableReplicas: 1
+`,
+			wantReason: "generation 2 not yet observed",
+		},
+		{
+			desc: "deployment with one of two replicas available",
+			object: `
+apiVersion: extensions/v1beta1
+kind: Deployment
+spec:
+  replicas: 2
+status:
+  replicas: 2
+  updatedReplicas: 2
+  availableReplicas: 1
+`,
+			wantReason: "1 of 2 replicas available",
+		},
+		{
+			desc: "deployment with old replicas",
+			object: `
+apiVersion: apps/v1
+kind: Deployment
+status:
+  replicas: 2
+  updatedReplicas: 1
+  availableReplicas: 2
+`,
+			wantReason: "1 old replicas pending termination",
+		},
+		{
+			desc: "statefulset not updated",
+			object: `
+apiVersion: apps/v1
+kind: StatefulSet
+spec:
+  replicas: 3
+status:
+  readyReplicas: 3
+  updatedReplicas: 1
+`,
+			wantReason: "1 of 3 replicas updated",
+		},
+		{
+			desc: "statefulset partitioned",
+			object: `
+apiVersion: apps/v1
+kind: StatefulSet
+spec:
+  replicas: 3
+  updateStrategy:
+    rollingUpdate:
+      partition: 2
+status:
+  readyReplicas: 3
+  updatedReplicas: 1
+`,
+			want: true,
+		},
+		{
+			desc: "daemonset not available",
+			object: `
+apiVersion: apps/v1
+kind: DaemonSet
+status:
+  desiredNumberScheduled: 3
+  updatedNumberScheduled: 3
+  numberAvailable: 2
+`,
+			wantReason: "2 of 3 pods available",
+		},
+		{
+			desc: "job running",
+			object: `
+apiVersion: batch/v1
+kind: Job
+status:
+  active: 1
+`,
+			wantReason: "job has not completed",
+		},
+		{
+			desc: "job complete",
+			object: `
+apiVersion: batch/v1
+kind: Job
+status:
+  conditions:
+  - type: Complete
+    status: "True"
+`,
+			want: true,
+		},
+		{
+			desc: "job failed",
+			object: `
+apiVersion: batch/v1
+kind: Job
+status:
+  conditions:
+  - type: Failed
+    status: "True"
+    message: BackoffLimitExceeded
+`,
+			wantErr: "job failed: BackoffLimitExceeded",
+		},
+		{
+			desc: "crd established",
+			object: `
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+status:
+  conditions:
+  - type: NamesAccepted
+    status: "True"
+  - type: Established
+    status: "True"
+`,
+			want: true,
+		},
+		{
+			desc: "crd not established",
+			object: `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+status:
+  conditions:
+  - type: Established
+    status: "False"
+`,
+			wantReason: "not established",
+		},
+		{
+			desc: "webhook with ready endpoints",
+			object: `
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+webhooks:
+- name: pilot.validation.istio.io
+  clientConfig:
+    service:
+      name: istio-galley
+      namespace: istio-system
+`,
+			related: []string{readyEndpoints},
+			want:    true,
+		},
+		{
+			desc: "webhook without endpoints",
+			object: `
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: MutatingWebhookConfiguration
+webhooks:
+- name: sidecar-injector.istio.io
+  clientConfig:
+    service:
+      name: istio-sidecar-injector
+      namespace: istio-system
+`,
+			related:    []string{readyEndpoints},
+			wantReason: "service istio-system/istio-sidecar-injector has no ready endpoints",
+		},
+		{
+			desc: "load balancer without ingress",
+			object: `
+apiVersion: v1
+kind: Service
+spec:
+  type: LoadBalancer
+  clusterIP: 10.0.0.2
+`,
+			wantReason: "no load balancer ingress assigned",
+		},
+		{
+			desc: "load balancer with ingress",
+			object: `
+apiVersion: v1
+kind: Service
+spec:
+  type: LoadBalancer
+  clusterIP: 10.0.0.2
+status:
+  loadBalancer:
+    ingress:
+    - ip: 1.2.3.4
+`,
+			want: true,
+		},
+		{
+			desc: "headless service",
+			object: `
+apiVersion: v1
+kind: Service
+spec:
+  clusterIP: None
+`,
+			want: true,
+		},
+		{
+			desc: "kind without check",
+			object: `
+apiVersion: v1
+kind: ConfigMap
+`,
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var related []*unstructured.Unstructured
+			for _, r := range tt.related {
+				related = append(related, objectFromYAML(t, r))
+			}
+			got, reason, err := Check(objectFromYAML(t, tt.object), getterFor(related...))
+			if gotErr := errToString(err); gotErr != tt.wantErr {
+				t.Fatalf("%s: got error %q, want %q", tt.desc, gotErr, tt.wantErr)
+			}
+			if got != tt.want || !strings.HasPrefix(reason, tt.wantReason) {
+				t.Errorf("%s: got ready %v, reason %q, want %v, %q", tt.desc, got, reason, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+	widget := objectFromYAML(t, "apiVersion: example.com/v1\nkind: Widget\n")
+	if ready, _, _ := Check(widget, getterFor()); !ready {
+		t.Fatalf("got unregistered kind not ready, want ready")
+	}
+	Register(schema.GroupVersionKind{Group: gvk.Group, Kind: gvk.Kind}, func(*unstructured.Unstructured, Getter) (bool, string, error) {
+		return false, "any version", nil
+	})
+	Register(gvk, func(*unstructured.Unstructured, Getter) (bool, string, error) {
+		return false, "v1", nil
+	})
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(checkers, gvk)
+		delete(checkers, schema.GroupVersionKind{Group: gvk.Group, Kind: gvk.Kind})
+	}()
+
+	tests := []struct {
+		apiVersion string
+		want       string
+	}{
+		{apiVersion: "example.com/v1", want: "v1"},
+		{apiVersion: "example.com/v2", want: "any version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.apiVersion, func(t *testing.T) {
+			widget.SetAPIVersion(tt.apiVersion)
+			if _, reason, _ := Check(widget, getterFor()); reason != tt.want {
+				t.Errorf("got check for %s %q, want %q", tt.apiVersion, reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestWait(t *testing.T) {
+	ns := objectFromYAML(t, "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: istio-system\nstatus:\n  phase: Active\n")
+	tests := []struct {
+		desc    string
+		refs    []inventory.Ref
+		wantErr string
+	}{
+		{
+			desc: "ready",
+			refs: []inventory.Ref{inventory.RefForUnstructured(ns)},
+		},
+		{
+			desc: "missing",
+			refs: []inventory.Ref{
+				inventory.RefForUnstructured(ns),
+				{GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, Name: "missing"},
+			},
+			wantErr: "v1/Namespace missing is not ready: it does not exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := Wait(tt.refs, getterFor(ns), time.Millisecond, 10*time.Millisecond)
+			if gotErr := errToString(err); !strings.Contains(gotErr, tt.wantErr) || (tt.wantErr == "") != (err == nil) {
+				t.Errorf("%s: got error %q, want %q", tt.desc, gotErr, tt.wantErr)
+			}
+		})
+	}
+}
+
+// errToString returns the string representation of err and the empty string if err is nil.
+func errToString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
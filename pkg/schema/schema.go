@@ -0,0 +1,382 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package schema generates OpenAPI v3 and JSON Schema schemas for IstioControlPlaneSpec and Values, and a
+IstioControlPlane CRD which validates with them.
+
+The schemas are derived from the Go types generated from istiocontrolplane_types.proto and values_types.proto, so they
+follow the protos without needing protoc at runtime. Field names are the JSON names from the proto descriptors, and
+for Values also the original proto names, which are the names used by the charts.
+Wrapper types which jsonpb marshals as scalars are mapped to the scalar they marshal to:
+
+	TypeBoolValueForPB, google.protobuf.BoolValue     boolean
+	TypeInt64ValueForPB, google.protobuf.Int64Value   integer
+	TypeIntOrStringForPB, IntOrString, Quantity       integer or string
+	google.protobuf.Duration                          string
+	TypeMapStringInterface                            object with any fields
+	TypeInterface                                     any value
+
+The OpenAPI schema is structural, as required for CRD validation. The JSON Schema additionally rejects unknown fields,
+which matches the validation done by the operator.
+*/
+package schema
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	gogotypes "github.com/gogo/protobuf/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/golang/protobuf/ptypes/duration"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha1"
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/util"
+)
+
+var (
+	// scalarTypes maps types which are marshaled as a scalar to their schema.
+	scalarTypes = map[reflect.Type]apiextv1beta1.JSONSchemaProps{
+		reflect.TypeOf(v1alpha2.BoolValueForPB{}):   {Type: "boolean"},
+		reflect.TypeOf(v1alpha2.Int64ValueForPB{}):  {Type: "integer", Format: "int64"},
+		reflect.TypeOf(gogotypes.BoolValue{}):       {Type: "boolean"},
+		reflect.TypeOf(gogotypes.StringValue{}):     {Type: "string"},
+		reflect.TypeOf(gogotypes.Int32Value{}):      {Type: "integer", Format: "int32"},
+		reflect.TypeOf(gogotypes.UInt32Value{}):     {Type: "integer", Format: "int32"},
+		reflect.TypeOf(gogotypes.Int64Value{}):      {Type: "integer", Format: "int64"},
+		reflect.TypeOf(gogotypes.UInt64Value{}):     {Type: "integer", Format: "int64"},
+		reflect.TypeOf(gogotypes.DoubleValue{}):     {Type: "number", Format: "double"},
+		reflect.TypeOf(gogotypes.FloatValue{}):      {Type: "number", Format: "float"},
+		reflect.TypeOf(v1alpha2.IntOrStringForPB{}): {XIntOrString: true},
+		reflect.TypeOf(v1alpha1.IntOrStringForPB{}): {XIntOrString: true},
+		reflect.TypeOf(intstr.IntOrString{}):        {XIntOrString: true},
+		reflect.TypeOf(resource.Quantity{}):         {XIntOrString: true},
+		reflect.TypeOf(duration.Duration{}):         {Type: "string"},
+		reflect.TypeOf(gogotypes.Duration{}):        {Type: "string"},
+		reflect.TypeOf(metav1.Duration{}):           {Type: "string"},
+		reflect.TypeOf(metav1.Time{}):               {Type: "string", Format: "date-time"},
+	}
+
+	// k8sAPIPkgPrefix is the package path prefix of Kubernetes API types like v1.Affinity.
+	k8sAPIPkgPrefix = "k8s.io/api/"
+
+	enumType = reflect.TypeOf((*interface {
+		EnumDescriptor() ([]byte, []int)
+	})(nil)).Elem()
+	preserveUnknownFields = true
+)
+
+// generator generates schemas from Go types.
+type generator struct {
+	// allNames adds a property for every name jsonpb accepts for a field, rather than only the descriptor JSON name.
+	allNames bool
+	// visiting holds the struct types being generated further up the tree, which are used to break cycles.
+	visiting map[reflect.Type]bool
+}
+
+// ValuesSchema returns the OpenAPI v3 schema for Values. Values are passed to the charts as they are, so the charts
+// and profiles use the original proto field names where they differ from the JSON names, and the schema accepts both.
+func ValuesSchema() (*apiextv1beta1.JSONSchemaProps, error) {
+	g := &generator{allNames: true, visiting: make(map[reflect.Type]bool)}
+	return g.newSchema(reflect.TypeOf(v1alpha1.Values{}))
+}
+
+// IstioControlPlaneSpecSchema returns the OpenAPI v3 schema for IstioControlPlaneSpec. The values field, which is a
+// map in the proto, is validated with the Values schema.
+func IstioControlPlaneSpecSchema() (*apiextv1beta1.JSONSchemaProps, error) {
+	g := &generator{visiting: make(map[reflect.Type]bool)}
+	s, err := g.newSchema(reflect.TypeOf(v1alpha2.IstioControlPlaneSpec{}))
+	if err != nil {
+		return nil, err
+	}
+	vs, err := ValuesSchema()
+	if err != nil {
+		return nil, err
+	}
+	s.Properties["values"] = *vs
+	return s, nil
+}
+
+// newSchema returns the schema for type t.
+func (g *generator) newSchema(t reflect.Type) (*apiextv1beta1.JSONSchemaProps, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if s, ok := scalarTypes[t]; ok {
+		return &s, nil
+	}
+	if t.Implements(enumType) {
+		return enumSchema(t)
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return &apiextv1beta1.JSONSchemaProps{XPreserveUnknownFields: &preserveUnknownFields}, nil
+	case reflect.Bool:
+		return &apiextv1beta1.JSONSchemaProps{Type: "boolean"}, nil
+	case reflect.String:
+		return &apiextv1beta1.JSONSchemaProps{Type: "string"}, nil
+	case reflect.Int32, reflect.Uint32, reflect.Int, reflect.Uint:
+		return &apiextv1beta1.JSONSchemaProps{Type: "integer", Format: "int32"}, nil
+	case reflect.Int64, reflect.Uint64:
+		return &apiextv1beta1.JSONSchemaProps{Type: "integer", Format: "int64"}, nil
+	case reflect.Float32:
+		return &apiextv1beta1.JSONSchemaProps{Type: "number", Format: "float"}, nil
+	case reflect.Float64:
+		return &apiextv1beta1.JSONSchemaProps{Type: "number", Format: "double"}, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &apiextv1beta1.JSONSchemaProps{Type: "string", Format: "byte"}, nil
+		}
+		is, err := g.newSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &apiextv1beta1.JSONSchemaProps{Type: "array", Items: &apiextv1beta1.JSONSchemaPropsOrArray{Schema: is}}, nil
+	case reflect.Map:
+		if t.Elem().Kind() == reflect.Interface {
+			return &apiextv1beta1.JSONSchemaProps{Type: "object", XPreserveUnknownFields: &preserveUnknownFields}, nil
+		}
+		es, err := g.newSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &apiextv1beta1.JSONSchemaProps{Type: "object", AdditionalProperties: &apiextv1beta1.JSONSchemaPropsOrBool{
+			Allows: true,
+			Schema: es,
+		}}, nil
+	case reflect.Struct:
+		if strings.HasPrefix(t.PkgPath(), k8sAPIPkgPrefix) {
+			// Kubernetes API types are validated by the API server when the rendered resources are applied. Their full
+			// schemas, repeated for every component, would make the CRD too large to apply.
+			return &apiextv1beta1.JSONSchemaProps{Type: "object", XPreserveUnknownFields: &preserveUnknownFields}, nil
+		}
+		if g.visiting[t] {
+			// Structural schemas can't have references, so a recursive type is cut off at the first repetition.
+			return &apiextv1beta1.JSONSchemaProps{Type: "object", XPreserveUnknownFields: &preserveUnknownFields}, nil
+		}
+		g.visiting[t] = true
+		defer delete(g.visiting, t)
+		s := &apiextv1beta1.JSONSchemaProps{Type: "object", Properties: make(map[string]apiextv1beta1.JSONSchemaProps)}
+		if err := g.addStructFields(s, t); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// addStructFields adds the schemas for the fields of the struct type t to the properties of s.
+func (g *generator) addStructFields(s *apiextv1beta1.JSONSchemaProps, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		names, inline := util.JSONFieldNames(f)
+		if inline {
+			if err := g.addStructFields(s, f.Type); err != nil {
+				return err
+			}
+			continue
+		}
+		if len(names) == 0 || f.PkgPath != "" {
+			continue
+		}
+		fs, err := g.newSchema(f.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %s", t.Name(), f.Name, err)
+		}
+		if !g.allNames {
+			names = names[:1]
+		}
+		for _, n := range names {
+			s.Properties[n] = *fs
+		}
+	}
+	return nil
+}
+
+// enumSchema returns the schema for the proto enum type t. jsonpb marshals enums as the value name, so the schema is a
+// string restricted to the names from the enum descriptor.
+func enumSchema(t reflect.Type) (*apiextv1beta1.JSONSchemaProps, error) {
+	ed, err := enumDescriptor(t)
+	if err != nil {
+		return nil, err
+	}
+	s := &apiextv1beta1.JSONSchemaProps{Type: "string"}
+	for _, v := range ed.GetValue() {
+		s.Enum = append(s.Enum, apiextv1beta1.JSON{Raw: []byte(fmt.Sprintf("%q", v.GetName()))})
+	}
+	return s, nil
+}
+
+// enumDescriptor returns the descriptor for the proto enum type t.
+func enumDescriptor(t reflect.Type) (*descriptor.EnumDescriptorProto, error) {
+	gz, path := reflect.Zero(t).Interface().(interface {
+		EnumDescriptor() ([]byte, []int)
+	}).EnumDescriptor()
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	fd := &descriptor.FileDescriptorProto{}
+	if err := proto.Unmarshal(b, fd); err != nil {
+		return nil, err
+	}
+	// path is the index of a top level enum, or the indexes of the enclosing nested messages followed by the index of
+	// the enum in the innermost message.
+	if len(path) == 1 {
+		return fd.GetEnumType()[path[0]], nil
+	}
+	md := fd.GetMessageType()[path[0]]
+	for _, i := range path[1 : len(path)-1] {
+		md = md.GetNestedType()[i]
+	}
+	return md.GetEnumType()[path[len(path)-1]], nil
+}
+
+// IstioControlPlaneSchema returns the OpenAPI v3 schema for an IstioControlPlane CR.
+func IstioControlPlaneSchema() (*apiextv1beta1.JSONSchemaProps, error) {
+	spec, err := IstioControlPlaneSpecSchema()
+	if err != nil {
+		return nil, err
+	}
+	spec.Description = "Specification of the desired state of the istio control plane resource. More info: " +
+		"https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#spec-and-status"
+	return &apiextv1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextv1beta1.JSONSchemaProps{
+			"apiVersion": {
+				Type: "string",
+				Description: "APIVersion defines the versioned schema of this representation of an object. Servers should " +
+					"convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: " +
+					"https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#resources",
+			},
+			"kind": {
+				Type: "string",
+				Description: "Kind is a string value representing the REST resource this object represents. Servers may " +
+					"infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: " +
+					"https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+			},
+			"metadata": {
+				Type: "object",
+			},
+			"spec": *spec,
+			"status": {
+				Type:                   "object",
+				XPreserveUnknownFields: &preserveUnknownFields,
+				Description: "Status describes each of istio control plane component status at the current time. More info: " +
+					"https://github.com/istio/operator/blob/master/pkg/apis/istio/v1alpha2/v1alpha2.pb.html",
+			},
+		},
+	}, nil
+}
+
+// IstioControlPlaneCRD returns the IstioControlPlane CRD, which validates CRs with IstioControlPlaneSchema.
+func IstioControlPlaneCRD() (*apiextv1beta1.CustomResourceDefinition, error) {
+	s, err := IstioControlPlaneSchema()
+	if err != nil {
+		return nil, err
+	}
+	return &apiextv1beta1.CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiextv1beta1.SchemeGroupVersion.String(),
+			Kind:       "CustomResourceDefinition",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "istiocontrolplanes." + v1alpha2.SchemeGroupVersion.Group,
+		},
+		Spec: apiextv1beta1.CustomResourceDefinitionSpec{
+			Group: v1alpha2.SchemeGroupVersion.Group,
+			Names: apiextv1beta1.CustomResourceDefinitionNames{
+				Kind:       "IstioControlPlane",
+				ListKind:   "IstioControlPlaneList",
+				Plural:     "istiocontrolplanes",
+				Singular:   "istiocontrolplane",
+				ShortNames: []string{"icp"},
+			},
+			Scope: apiextv1beta1.NamespaceScoped,
+			Subresources: &apiextv1beta1.CustomResourceSubresources{
+				Status: &apiextv1beta1.CustomResourceSubresourceStatus{},
+			},
+			Validation: &apiextv1beta1.CustomResourceValidation{
+				OpenAPIV3Schema: s,
+			},
+			Versions: []apiextv1beta1.CustomResourceDefinitionVersion{
+				{
+					Name:    v1alpha2.SchemeGroupVersion.Version,
+					Served:  true,
+					Storage: true,
+				},
+			},
+		},
+	}, nil
+}
+
+// ToJSONSchema converts the OpenAPI v3 schema s to a draft-07 JSON Schema with the given title. Objects with
+// properties reject unknown fields, and the Kubernetes int-or-string extension is replaced with the equivalent anyOf.
+func ToJSONSchema(s *apiextv1beta1.JSONSchemaProps, title string) (map[string]interface{}, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]interface{})
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	toJSONSchema(out)
+	out["$schema"] = "http://json-schema.org/draft-07/schema#"
+	out["title"] = title
+	return out, nil
+}
+
+func toJSONSchema(node map[string]interface{}) {
+	if node["x-kubernetes-int-or-string"] == true {
+		delete(node, "x-kubernetes-int-or-string")
+		node["anyOf"] = []interface{}{
+			map[string]interface{}{"type": "integer"},
+			map[string]interface{}{"type": "string"},
+		}
+	}
+	preserve := node["x-kubernetes-preserve-unknown-fields"] == true
+	delete(node, "x-kubernetes-preserve-unknown-fields")
+	if props, ok := node["properties"].(map[string]interface{}); ok {
+		for _, p := range props {
+			if pm, ok := p.(map[string]interface{}); ok {
+				toJSONSchema(pm)
+			}
+		}
+		if !preserve && node["additionalProperties"] == nil {
+			node["additionalProperties"] = false
+		}
+	}
+	for _, k := range []string{"items", "additionalProperties"} {
+		if c, ok := node[k].(map[string]interface{}); ok {
+			toJSONSchema(c)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	apiextv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/helm"
+	"istio.io/operator/pkg/util"
+)
+
+func TestIstioControlPlaneSchema(t *testing.T) {
+	s, err := IstioControlPlaneSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		desc string
+		path string
+		want string
+	}{
+		{
+			desc: "bool wrapper",
+			path: "spec.trafficManagement.components.pilot.enabled",
+			want: "type: boolean",
+		},
+		{
+			desc: "int or string wrapper",
+			path: "spec.trafficManagement.components.pilot.k8s.strategy.rollingUpdate.maxSurge",
+			want: "x-kubernetes-int-or-string: true",
+		},
+		{
+			desc: "map string interface",
+			path: "spec.unvalidatedValues",
+			want: "type: object\nx-kubernetes-preserve-unknown-fields: true",
+		},
+		{
+			desc: "k8s type",
+			path: "spec.trafficManagement.components.pilot.k8s.affinity",
+			want: "type: object\nx-kubernetes-preserve-unknown-fields: true",
+		},
+		{
+			desc: "values",
+			path: "spec.values.global.proxy.includeIPRanges",
+			want: "type: string",
+		},
+		{
+			desc: "values proto wrapper",
+			path: "spec.values.global.mtls.enabled",
+			want: "type: boolean",
+		},
+		{
+			desc: "values enum",
+			path: "spec.values.global.outboundTrafficPolicy.mode",
+			want: "enum:\n- ALLOW_ANY\n- REGISTRY_ONLY\ntype: string",
+		},
+		{
+			desc: "spec enum",
+			path: "spec.deletionPolicy",
+			want: "enum:\n- Delete\n- Retain\n- RetainCRDs\ntype: string",
+		},
+		{
+			desc: "values duration",
+			path: "spec.values.global.proxy.drainDuration",
+			want: "type: string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			node := *s
+			for _, p := range util.PathFromString(tt.path) {
+				var ok bool
+				if node, ok = node.Properties[p]; !ok {
+					t.Fatalf("%s not found in schema", tt.path)
+				}
+			}
+			y, err := yaml.Marshal(node)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := strings.TrimSpace(string(y)), tt.want; got != want {
+				t.Errorf("%s: got:\n%s\nwant:\n%s", tt.path, got, want)
+			}
+		})
+	}
+}
+
+func TestBuiltinProfilesMatchSchema(t *testing.T) {
+	s, err := IstioControlPlaneSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range helm.ListBuiltinProfiles() {
+		t.Run(p, func(t *testing.T) {
+			y, err := helm.ReadProfileYAML(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tree := make(map[string]interface{})
+			if err := yaml.Unmarshal([]byte(y), &tree); err != nil {
+				t.Fatal(err)
+			}
+			if err := checkTree(tree, s, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSamplesMatchSchema(t *testing.T) {
+	s, err := IstioControlPlaneSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths, err := filepath.Glob("../../samples/*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range append(paths, "../../deploy/crds/istio_v1alpha2_istiocontrolplane_cr.yaml") {
+		t.Run(filepath.Base(p), func(t *testing.T) {
+			b, err := ioutil.ReadFile(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tree := make(map[string]interface{})
+			if err := yaml.Unmarshal(b, &tree); err != nil {
+				t.Fatal(err)
+			}
+			if tree["kind"] != "IstioControlPlane" {
+				return
+			}
+			if err := checkTree(tree, s, nil); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestEnumSchema(t *testing.T) {
+	tests := []struct {
+		desc string
+		t    reflect.Type
+		want string
+	}{
+		{
+			desc: "nested enum",
+			t:    reflect.TypeOf(v1alpha2.InstallStatus_NONE),
+			want: "enum:\n- NONE\n- UPDATING\n- HEALTHY\n- ERROR\n- RECONCILING\n- BLOCKED\ntype: string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			s, err := enumSchema(tt.t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			y, err := yaml.Marshal(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := strings.TrimSpace(string(y)), tt.want; got != want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestToJSONSchema(t *testing.T) {
+	s := &apiextv1beta1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextv1beta1.JSONSchemaProps{
+			"port": {XIntOrString: true},
+			"values": {
+				Type:                   "object",
+				XPreserveUnknownFields: &preserveUnknownFields,
+			},
+			"labels": {
+				Type: "object",
+				AdditionalProperties: &apiextv1beta1.JSONSchemaPropsOrBool{
+					Allows: true,
+					Schema: &apiextv1beta1.JSONSchemaProps{Type: "string"},
+				},
+			},
+		},
+	}
+	got, err := ToJSONSchema(s, "Test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantYAML := `
+$schema: http://json-schema.org/draft-07/schema#
+title: Test
+type: object
+additionalProperties: false
+properties:
+  port:
+    anyOf:
+    - type: integer
+    - type: string
+  values:
+    type: object
+  labels:
+    type: object
+    additionalProperties:
+      type: string
+`
+	want := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(wantYAML), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		gy, _ := yaml.Marshal(got)
+		t.Errorf("got:\n%s\nwant:\n%s", gy, wantYAML)
+	}
+}
+
+// checkTree returns an error if node, a YAML tree, has a field or value type not allowed by the schema s.
+func checkTree(node interface{}, s *apiextv1beta1.JSONSchemaProps, path util.Path) error {
+	if node == nil || (s.XPreserveUnknownFields != nil && *s.XPreserveUnknownFields && s.Type == "") {
+		return nil
+	}
+	if s.XIntOrString {
+		switch node.(type) {
+		case string, float64:
+			return nil
+		}
+		return fmt.Errorf("%s: expect int or string, got %T", path, node)
+	}
+	switch s.Type {
+	case "object":
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expect object, got %T", path, node)
+		}
+		if (s.XPreserveUnknownFields != nil && *s.XPreserveUnknownFields) ||
+			(len(s.Properties) == 0 && s.AdditionalProperties == nil) {
+			return nil
+		}
+		for k, v := range m {
+			ps, ok := s.Properties[k]
+			if !ok && s.AdditionalProperties != nil {
+				ps, ok = *s.AdditionalProperties.Schema, true
+			}
+			if !ok {
+				return fmt.Errorf("%s: unknown field %s", path, k)
+			}
+			if err := checkTree(v, &ps, append(path, k)); err != nil {
+				return err
+			}
+		}
+	case "array":
+		l, ok := node.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expect array, got %T", path, node)
+		}
+		for i, le := range l {
+			if err := checkTree(le, s.Items.Schema, append(path, fmt.Sprint(i))); err != nil {
+				return err
+			}
+		}
+	case "boolean":
+		if _, ok := node.(bool); !ok {
+			return fmt.Errorf("%s: expect boolean, got %T", path, node)
+		}
+	case "string":
+		if _, ok := node.(string); !ok {
+			return fmt.Errorf("%s: expect string, got %T", path, node)
+		}
+	case "integer", "number":
+		if _, ok := node.(float64); !ok {
+			return fmt.Errorf("%s: expect number, got %T", path, node)
+		}
+	}
+	return nil
+}
@@ -18,6 +18,15 @@ Nodes in such trees have the form map[interface{}]interface{} or map[interface{}
 For some tree updates, like delete or append, it's necessary to have access to the parent node. PathContext is a
 tree constructed during tree traversal that gives access to ancestor nodes all the way up to the root, which can be
 used for this purpose.
+
+In addition to the [key:value] and [value] list selectors described in util.Path, paths may contain:
+  - [*], which selects every entry of a list
+  - [N], which selects the list entry at index N. Negative indexes count back from the end of the list, so [-1] is the
+    last entry. Numeric leaf list values must be selected with a regex like [^80$] instead.
+  - [+], which refers to a new entry appended to the end of a list. It must be the last element in the path.
+  - **, which matches zero or more path elements at any depth, e.g. **.image selects every image field in the tree.
+
+Paths containing [*] or ** can select multiple nodes.
 */
 package tpath
 
@@ -25,6 +34,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 
 	"github.com/kylelemons/godebug/pretty"
 
@@ -44,10 +54,23 @@ type PathContext struct {
 	KeyToChild interface{}
 	// Node is the actual Node in the data tree.
 	Node interface{}
+	// Matches holds the PathContexts for every node selected by a path that can match multiple nodes. Node, Parent
+	// and KeyToChild are unset in a PathContext with Matches.
+	Matches []*PathContext
 }
 
 // String implements the Stringer interface.
 func (nc *PathContext) String() string {
+	if nc.Matches != nil {
+		ret := fmt.Sprintf("\n--------------- %d matching NodeContexts ---------\n", len(nc.Matches))
+		for _, m := range nc.Matches {
+			ret += m.String()
+		}
+		return ret
+	}
+	if nc.Parent == nil {
+		return fmt.Sprintf("\nNode=\n%s\n", pretty.Sprint(nc.Node))
+	}
 	ret := "\n--------------- NodeContext ------------------\n"
 	ret += fmt.Sprintf("Parent.Node=\n%s\n", pretty.Sprint(nc.Parent.Node))
 	ret += fmt.Sprintf("KeyToChild=%v\n", nc.Parent.KeyToChild)
@@ -61,10 +84,38 @@ func (nc *PathContext) String() string {
 // a malformed path.
 // It also creates a tree of PathContexts during the traversal so that Parent nodes can be updated if required. This is
 // required when (say) appending to a list, where the parent list itself must be updated.
+// If path can match multiple nodes, the returned PathContext holds the contexts for all matching nodes in Matches.
 func GetPathContext(root interface{}, path util.Path) (*PathContext, bool, error) {
+	if util.IsWildcardPath(path) {
+		ncs, err := getPathContexts(&PathContext{Node: root}, path, path, true)
+		if err != nil {
+			return nil, false, err
+		}
+		if ncs == nil {
+			ncs = []*PathContext{}
+		}
+		return &PathContext{Matches: ncs}, len(ncs) != 0, nil
+	}
 	return getPathContext(&PathContext{Node: root}, path, path, false)
 }
 
+// FindNodes returns the PathContexts for all Nodes in root selected by path, which may contain any path element,
+// including those that can match multiple nodes. Unlike GetPathContext, it never creates missing nodes in root and
+// paths that don't select any node are not an error. Nodes with a nil value are treated as missing.
+func FindNodes(root interface{}, path util.Path) ([]*PathContext, error) {
+	ncs, err := getPathContexts(&PathContext{Node: root}, path, path, false)
+	if err != nil {
+		return nil, err
+	}
+	var out []*PathContext
+	for _, nc := range ncs {
+		if !util.IsValueNil(derefNode(nc.Node)) {
+			out = append(out, nc)
+		}
+	}
+	return out, nil
+}
+
 // getPathContext is the internal implementation of GetPathContext.
 // If createMissing is true, it creates any missing map (but NOT list) path entries in root.
 func getPathContext(nc *PathContext, fullPath, remainPath util.Path, createMissing bool) (*PathContext, bool, error) {
@@ -87,18 +138,38 @@ func getPathContext(nc *PathContext, fullPath, remainPath util.Path, createMissi
 	// form :matching_value in the case of a leaf list, or a matching key:value in the case of a non-leaf list.
 	if lst, ok := ncNode.([]interface{}); ok {
 		scope.Debug("list type")
+		if pe == util.AppendPathElement {
+			if len(remainPath) != 1 {
+				return nil, false, fmt.Errorf("path %s: %s must be the last path element", fullPath, pe)
+			}
+			nc.KeyToChild = -1
+			return &PathContext{Parent: nc}, true, nil
+		}
+		if util.IsNPathElement(pe) {
+			idx, err := listIndex(lst, pe)
+			if err != nil {
+				return nil, false, fmt.Errorf("path %s: %s", fullPath, err)
+			}
+			nn := &PathContext{
+				Parent: nc,
+				Node:   lst[idx],
+			}
+			nc.KeyToChild = idx
+			return getPathContext(nn, fullPath, remainPath[1:], createMissing)
+		}
 		for idx, le := range lst {
 			// non-leaf list, expect to match item by key:value.
-			if lm, ok := le.(map[interface{}]interface{}); ok {
+			// map[string]interface{} entries with a non key:value element fall through to regex matching below.
+			if _, ok := le.(map[interface{}]interface{}); ok || (util.IsMap(le) && util.IsKVPathElement(pe)) {
 				k, v, err := util.PathKV(pe)
 				if err != nil {
 					return nil, false, fmt.Errorf("path %s: %s", fullPath, err)
 				}
-				if stringsEqual(lm[k], v) {
+				if stringsEqual(mapValue(le, k), v) {
 					scope.Debugf("found matching kv %v:%v", k, v)
 					nn := &PathContext{
 						Parent: nc,
-						Node:   lm,
+						Node:   le,
 					}
 					nc.KeyToChild = idx
 					nn.KeyToChild = k
@@ -170,8 +241,204 @@ func getPathContext(nc *PathContext, fullPath, remainPath util.Path, createMissi
 	return nil, false, fmt.Errorf("leaf type %T in non-leaf Node %s", nc.Node, remainPath)
 }
 
+// getPathContexts returns the PathContexts for all Nodes matching remainPath from nc, for paths that can match multiple
+// nodes. Unlike getPathContext, path elements that don't match any node are not an error, and missing internal path
+// entries are never created. If createLeaf is true and the last path element is a missing map key, a PathContext for
+// a new leaf is returned.
+func getPathContexts(nc *PathContext, fullPath, remainPath util.Path, createLeaf bool) ([]*PathContext, error) {
+	scope.Debugf("getPathContexts remainPath=%s, Node=%s", remainPath, pretty.Sprint(nc.Node))
+	if len(remainPath) == 0 {
+		return []*PathContext{nc}, nil
+	}
+	pe := remainPath[0]
+
+	if pe == util.RecursiveDescentPathElement {
+		// ** matches zero path elements here, or any number of path elements below each child. New leaves are never
+		// created directly below **, since that would add them at every depth.
+		out, err := getPathContexts(nc, fullPath, remainPath[1:], false)
+		if err != nil {
+			return nil, err
+		}
+		for _, cnc := range childPathContexts(nc) {
+			ncs, err := getPathContexts(cnc, fullPath, remainPath, false)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, ncs...)
+		}
+		return out, nil
+	}
+
+	var children []*PathContext
+	switch ncNode := derefNode(nc.Node).(type) {
+	case []interface{}:
+		switch {
+		case pe == util.AppendPathElement:
+			if len(remainPath) != 1 {
+				return nil, fmt.Errorf("path %s: %s must be the last path element", fullPath, pe)
+			}
+			children = append(children, newChildPathContext(nc, -1, nil))
+		case pe == util.WildcardPathElement:
+			children = childPathContexts(nc)
+		case util.IsNPathElement(pe):
+			idx, err := listIndex(ncNode, pe)
+			if err != nil {
+				// Index is out of range for this list, which is not an error when matching multiple nodes.
+				scope.Debugf("path %s: %s", fullPath, err)
+				return nil, nil
+			}
+			children = append(children, newChildPathContext(nc, idx, ncNode[idx]))
+		default:
+			for idx, le := range ncNode {
+				if util.IsMap(le) {
+					if !util.IsKVPathElement(pe) {
+						continue
+					}
+					k, v, _ := util.PathKV(pe)
+					if stringsEqual(mapValue(le, k), v) {
+						children = append(children, newChildPathContext(nc, idx, le))
+					}
+					continue
+				}
+				if _, ok := util.RemoveBrackets(pe); !ok {
+					// Map keys never match leaf list entries.
+					continue
+				}
+				v, err := util.PathV(pe)
+				if err != nil {
+					return nil, fmt.Errorf("path %s: %s", fullPath, err)
+				}
+				if util.IsKVPathElement(pe) || !matchesRegex(v, le) {
+					continue
+				}
+				children = append(children, newChildPathContext(nc, idx, le))
+			}
+		}
+	case map[interface{}]interface{}:
+		if nn, ok := ncNode[pe]; ok || (createLeaf && len(remainPath) == 1) {
+			children = append(children, newChildPathContext(nc, pe, nn))
+		}
+	case map[string]interface{}:
+		if nn, ok := ncNode[pe]; ok || (createLeaf && len(remainPath) == 1) {
+			children = append(children, newChildPathContext(nc, pe, nn))
+		}
+	}
+
+	var out []*PathContext
+	for _, cnc := range children {
+		ncs, err := getPathContexts(cnc, fullPath, remainPath[1:], true)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, ncs...)
+	}
+	return out, nil
+}
+
+// childPathContexts returns PathContexts for all the children of the Node in nc, if it is a map or list.
+func childPathContexts(nc *PathContext) []*PathContext {
+	var out []*PathContext
+	switch ncNode := derefNode(nc.Node).(type) {
+	case []interface{}:
+		for idx, le := range ncNode {
+			out = append(out, newChildPathContext(nc, idx, le))
+		}
+	case map[interface{}]interface{}:
+		for _, k := range sortedKeys(ncNode) {
+			out = append(out, newChildPathContext(nc, k, ncNode[k]))
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(ncNode))
+		for k := range ncNode {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			out = append(out, newChildPathContext(nc, k, ncNode[k]))
+		}
+	}
+	return out
+}
+
+// newChildPathContext returns a PathContext for child, which is reached from the Node in parent through key.
+// parent is copied so that PathContexts for sibling nodes each have their own KeyToChild. Slice children are stored
+// as ptrs, so that entries can be deleted or appended and the result written back to the parent.
+func newChildPathContext(parent *PathContext, key, child interface{}) *PathContext {
+	p := *parent
+	p.KeyToChild = key
+	nn := &PathContext{
+		Parent: &p,
+		Node:   child,
+	}
+	if child != nil && util.IsSlice(child) {
+		nn.Node = &child
+	}
+	return nn
+}
+
+// sortedKeys returns the keys of m in sorted string order.
+func sortedKeys(m map[interface{}]interface{}) []interface{} {
+	keys := make([]interface{}, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
+
+// mapValue returns the value for key k in m, which must be a map[interface{}]interface{} or map[string]interface{}.
+func mapValue(m interface{}, k string) interface{} {
+	switch mm := m.(type) {
+	case map[interface{}]interface{}:
+		return mm[k]
+	case map[string]interface{}:
+		return mm[k]
+	}
+	return nil
+}
+
+// derefNode returns the value of node, following any ptr and interface.
+func derefNode(node interface{}) interface{} {
+	v := reflect.ValueOf(node)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// listIndex returns the index in lst for numeric index path element pe, or an error if the index is out of range.
+func listIndex(lst []interface{}, pe string) (int, error) {
+	idx, err := util.PathN(pe)
+	if err != nil {
+		return -1, err
+	}
+	if idx < 0 {
+		idx += len(lst)
+	}
+	if idx < 0 || idx >= len(lst) {
+		return -1, fmt.Errorf("index %s out of range for list of length %d", pe, len(lst))
+	}
+	return idx, nil
+}
+
 // WriteNode writes value to the tree in root at the given path, creating any required missing internal nodes in path.
+// If path can match multiple nodes, value is written to every matching node and no missing nodes are created.
 func WriteNode(root interface{}, path util.Path, value interface{}) error {
+	if util.IsWildcardPath(path) {
+		pc, _, err := GetPathContext(root, path)
+		if err != nil {
+			return err
+		}
+		return WritePathContext(pc, value)
+	}
 	pc, _, err := getPathContext(&PathContext{Node: root}, path, path, true)
 	if err != nil {
 		return err
@@ -180,9 +447,21 @@ func WriteNode(root interface{}, path util.Path, value interface{}) error {
 }
 
 // WritePathContext writes the given value to the Node in the given PathContext.
+// If nc has Matches, value is written to every matching Node.
 func WritePathContext(nc *PathContext, value interface{}) error {
 	scope.Debugf("WritePathContext PathContext=%s, value=%v", nc, value)
 
+	if nc.Matches != nil {
+		// Write in reverse order so that deleting list entries doesn't change the index of the entries still to be
+		// written.
+		for i := len(nc.Matches) - 1; i >= 0; i-- {
+			if err := WritePathContext(nc.Matches[i], value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	switch {
 	case value == nil:
 		scope.Debug("delete")
@@ -204,7 +483,14 @@ func WritePathContext(nc *PathContext, value interface{}) error {
 			idx := nc.Parent.KeyToChild.(int)
 			if idx == -1 {
 				scope.Debug("insert")
-
+				if err := util.AppendToSlicePtr(nc.Parent.Node, value); err != nil {
+					return err
+				}
+				if nc.Parent.Parent != nil && isMapOrInterface(nc.Parent.Parent.Node) {
+					if err := util.InsertIntoMap(nc.Parent.Parent.Node, nc.Parent.Parent.KeyToChild, nc.Parent.Node); err != nil {
+						return err
+					}
+				}
 			} else {
 				scope.Debugf("update index %d\n", idx)
 				if err := util.UpdateSlicePtr(nc.Parent.Node, idx, value); err != nil {
@@ -273,10 +559,25 @@ func GetNodeByPath(treeNode interface{}, path util.Path) (interface{}, bool) {
 
 // TODO Merge this into existing WritePathContext method (istio/istio#15494)
 // DeleteFromTree sets value at path of input untyped tree to nil
+// If path can match multiple nodes, every matching value is set to nil.
 func DeleteFromTree(valueTree map[string]interface{}, path util.Path, remainPath util.Path) (bool, error) {
 	if len(remainPath) == 0 {
 		return false, nil
 	}
+	if remainPath[0] == util.RecursiveDescentPathElement {
+		found, err := DeleteFromTree(valueTree, path, remainPath[1:])
+		if err != nil {
+			return false, err
+		}
+		for _, val := range valueTree {
+			f, err := deleteFromNode(val, path, remainPath)
+			if err != nil {
+				return false, err
+			}
+			found = found || f
+		}
+		return found, nil
+	}
 	for key, val := range valueTree {
 		if key == remainPath[0] {
 			// found the path to delete value
@@ -289,6 +590,9 @@ func DeleteFromTree(valueTree map[string]interface{}, path util.Path, remainPath
 			case map[string]interface{}:
 				return DeleteFromTree(node, path, remainPath)
 			case []interface{}:
+				if isListSelector(remainPath[0]) {
+					return deleteFromList(node, path, remainPath)
+				}
 				for _, newNode := range node {
 					newMap, ok := newNode.(map[string]interface{})
 					if !ok {
@@ -308,6 +612,75 @@ func DeleteFromTree(valueTree map[string]interface{}, path util.Path, remainPath
 	return false, nil
 }
 
+// deleteFromNode deletes the value at remainPath from node if it's a map, or from every map entry if it's a list.
+func deleteFromNode(node interface{}, path util.Path, remainPath util.Path) (bool, error) {
+	switch nn := node.(type) {
+	case map[string]interface{}:
+		return DeleteFromTree(nn, path, remainPath)
+	case []interface{}:
+		found := false
+		for _, le := range nn {
+			f, err := deleteFromNode(le, path, remainPath)
+			if err != nil {
+				return false, err
+			}
+			found = found || f
+		}
+		return found, nil
+	}
+	return false, nil
+}
+
+// deleteFromList deletes the value at remainPath from lst, where the first element of remainPath is a list selector.
+func deleteFromList(lst []interface{}, path util.Path, remainPath util.Path) (bool, error) {
+	pe := remainPath[0]
+	var idxs []int
+	switch {
+	case pe == util.AppendPathElement:
+		return false, fmt.Errorf("path %s: %s is not valid in a delete path", path, pe)
+	case pe == util.WildcardPathElement:
+		for idx := range lst {
+			idxs = append(idxs, idx)
+		}
+	case util.IsNPathElement(pe):
+		idx, err := listIndex(lst, pe)
+		if err != nil {
+			return false, nil
+		}
+		idxs = append(idxs, idx)
+	default:
+		k, v, err := util.PathKV(pe)
+		if err != nil {
+			return false, fmt.Errorf("path %s: %s", path, err)
+		}
+		for idx, le := range lst {
+			if util.IsMap(le) && stringsEqual(mapValue(le, k), v) {
+				idxs = append(idxs, idx)
+			}
+		}
+	}
+
+	found := false
+	for _, idx := range idxs {
+		if len(remainPath) == 1 {
+			lst[idx] = nil
+			found = true
+			continue
+		}
+		f, err := deleteFromNode(lst[idx], path, remainPath[1:])
+		if err != nil {
+			return false, err
+		}
+		found = found || f
+	}
+	return found, nil
+}
+
+// isListSelector reports whether pe selects entries from a list.
+func isListSelector(pe string) bool {
+	return pe == util.WildcardPathElement || pe == util.AppendPathElement || util.IsNPathElement(pe) || util.IsKVPathElement(pe)
+}
+
 func stringsEqual(a, b interface{}) bool {
 	return fmt.Sprint(a) == fmt.Sprint(b)
 }
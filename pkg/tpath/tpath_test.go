@@ -15,6 +15,7 @@
 package tpath
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/ghodss/yaml"
@@ -155,6 +156,139 @@ a:
   test: foo
 `,
 		},
+		{
+			desc:      "ModifyListEntryByIndex",
+			path:      `a.b.[0].value`,
+			value:     `v2`,
+			wantFound: true,
+			want: `
+a:
+  b:
+  - name: n1
+    value: v2
+  - list:
+    - v1
+    - v2
+    - v3_regex
+    name: n2
+`,
+		},
+		{
+			desc:      "ModifyLastListEntry",
+			path:      `a.b.[-1].list.[-1]`,
+			value:     `v4`,
+			wantFound: true,
+			want: `
+a:
+  b:
+  - name: n1
+    value: v1
+  - list:
+    - v1
+    - v2
+    - v4
+    name: n2
+`,
+		},
+		{
+			desc:      "AppendListEntry",
+			path:      `a.b.[name:n2].list.[+]`,
+			value:     `v4`,
+			wantFound: true,
+			want: `
+a:
+  b:
+  - name: n1
+    value: v1
+  - list:
+    - v1
+    - v2
+    - v3_regex
+    - v4
+    name: n2
+`,
+		},
+		{
+			desc:      "ModifyAllListEntries",
+			path:      `a.b.[*].name`,
+			value:     `n3`,
+			wantFound: true,
+			want: `
+a:
+  b:
+  - name: n3
+    value: v1
+  - list:
+    - v1
+    - v2
+    - v3_regex
+    name: n3
+`,
+		},
+		{
+			desc:      "DeleteAllListEntries",
+			path:      `a.b.[name:n2].list.[*]`,
+			wantFound: true,
+			want: `
+a:
+  b:
+  - name: n1
+    value: v1
+  - list: []
+    name: n2
+`,
+		},
+		{
+			desc:      "RecursiveDescent",
+			path:      `**.value`,
+			value:     `v5`,
+			wantFound: true,
+			want: `
+a:
+  b:
+  - name: n1
+    value: v5
+  - list:
+    - v1
+    - v2
+    - v3_regex
+    name: n2
+`,
+		},
+		{
+			desc:      "RecursiveDescentListEntry",
+			path:      `a.**.list.[v2]`,
+			value:     `v6`,
+			wantFound: true,
+			want: `
+a:
+  b:
+  - name: n1
+    value: v1
+  - list:
+    - v1
+    - v6
+    - v3_regex
+    name: n2
+`,
+		},
+		{
+			desc:      "wildcard not found",
+			path:      `a.c.[*]`,
+			wantFound: false,
+		},
+		{
+			desc:      "index out of range",
+			path:      `a.b.[2]`,
+			wantFound: false,
+			wantErr:   `path a.b.[2]: index [2] out of range for list of length 2`,
+		},
+		{
+			desc:      "append not last",
+			path:      `a.b.[+].name`,
+			wantFound: false,
+			wantErr:   `path a.b.[+].name: [+] must be the last path element`,
+		},
 		{
 			desc:      "path not found",
 			path:      `a.c.[name:n2].list.[v3]`,
@@ -277,6 +411,50 @@ a:
         - i3a: key1
           i3b:
             i1: va11
+`,
+		},
+		{
+			desc:     "wildcard",
+			baseYAML: testTreeYAML,
+			path:     "a.b.list1.[*].i3b.list2.[i3a:key1].i3b.i1",
+			value:    "val2",
+			want: `
+a:
+  b:
+    c: val1
+    list1:
+    - i1: val1
+    - i2: val2
+    - i3a: key1
+      i3b:
+        list2:
+        - i1: val1
+        - i2: val2
+        - i3a: key1
+          i3b:
+            i1: val2
+`,
+		},
+		{
+			desc:     "recursive descent",
+			baseYAML: testTreeYAML,
+			path:     "**.i1",
+			value:    "val3",
+			want: `
+a:
+  b:
+    c: val1
+    list1:
+    - i1: val3
+    - i2: val2
+    - i3a: key1
+      i3b:
+        list2:
+        - i1: val3
+        - i2: val2
+        - i3a: key1
+          i3b:
+            i1: val3
 `,
 		},
 		{
@@ -322,6 +500,226 @@ a:
 	}
 }
 
+func TestFindNodes(t *testing.T) {
+	testTreeYAML := `
+a:
+  b:
+    c: val1
+    list1:
+    - i1: val1
+    - i2: val2
+    - i3a: key1
+      i3b:
+        list2:
+        - i1: val1
+        - i2: val2
+`
+	tests := []struct {
+		desc string
+		path string
+		want []interface{}
+	}{
+		{
+			desc: "leaf",
+			path: "a.b.c",
+			want: []interface{}{"val1"},
+		},
+		{
+			desc: "missing leaf",
+			path: "a.b.d",
+		},
+		{
+			desc: "missing internal node",
+			path: "a.d.c",
+		},
+		{
+			desc: "kv selector",
+			path: "a.b.list1.[i2:val2].i2",
+			want: []interface{}{"val2"},
+		},
+		{
+			desc: "wildcard",
+			path: "a.b.list1.[*].i1",
+			want: []interface{}{"val1"},
+		},
+		{
+			desc: "recursive descent",
+			path: "**.i1",
+			want: []interface{}{"val1", "val1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root := make(map[string]interface{})
+			if err := yaml.Unmarshal([]byte(testTreeYAML), &root); err != nil {
+				t.Fatal(err)
+			}
+			ncs, err := FindNodes(root, util.PathFromString(tt.path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []interface{}
+			for _, nc := range ncs {
+				got = append(got, nc.Node)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s: got:%v, want:%v", tt.desc, got, tt.want)
+			}
+			if diff := util.YAMLDiff(util.ToYAML(root), testTreeYAML); diff != "" {
+				t.Errorf("%s: tree was modified:\n%s", tt.desc, diff)
+			}
+		})
+	}
+}
+
+func TestDeleteFromTree(t *testing.T) {
+	testTreeYAML := `
+a:
+  b:
+    c: val1
+    list1:
+    - i1: val1
+    - i2: val2
+    - i3a: key1
+      i3b:
+        i1: val3
+`
+	tests := []struct {
+		desc      string
+		path      string
+		want      string
+		wantFound bool
+		wantErr   string
+	}{
+		{
+			desc:      "leaf",
+			path:      "a.b.c",
+			wantFound: true,
+			want: `
+a:
+  b:
+    c: null
+    list1:
+    - i1: val1
+    - i2: val2
+    - i3a: key1
+      i3b:
+        i1: val3
+`,
+		},
+		{
+			desc:      "implicit list",
+			path:      "a.b.list1.i2",
+			wantFound: true,
+			want: `
+a:
+  b:
+    c: val1
+    list1:
+    - i1: val1
+    - i2: null
+    - i3a: key1
+      i3b:
+        i1: val3
+`,
+		},
+		{
+			desc:      "index",
+			path:      "a.b.list1.[-1].i3b",
+			wantFound: true,
+			want: `
+a:
+  b:
+    c: val1
+    list1:
+    - i1: val1
+    - i2: val2
+    - i3a: key1
+      i3b: null
+`,
+		},
+		{
+			desc:      "key value",
+			path:      "a.b.list1.[i3a:key1]",
+			wantFound: true,
+			want: `
+a:
+  b:
+    c: val1
+    list1:
+    - i1: val1
+    - i2: val2
+    - null
+`,
+		},
+		{
+			desc:      "wildcard",
+			path:      "a.b.list1.[*].i1",
+			wantFound: true,
+			want: `
+a:
+  b:
+    c: val1
+    list1:
+    - i1: null
+    - i2: val2
+    - i3a: key1
+      i3b:
+        i1: val3
+`,
+		},
+		{
+			desc:      "recursive descent",
+			path:      "**.i1",
+			wantFound: true,
+			want: `
+a:
+  b:
+    c: val1
+    list1:
+    - i1: null
+    - i2: val2
+    - i3a: key1
+      i3b:
+        i1: null
+`,
+		},
+		{
+			desc:      "not found",
+			path:      "a.b.list1.[5]",
+			wantFound: false,
+			want:      testTreeYAML,
+		},
+		{
+			desc:    "append",
+			path:    "a.b.list1.[+]",
+			wantErr: "path a.b.list1.[+]: [+] is not valid in a delete path",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			root := make(map[string]interface{})
+			if err := yaml.Unmarshal([]byte(testTreeYAML), &root); err != nil {
+				t.Fatal(err)
+			}
+			p := util.PathFromString(tt.path)
+			gotFound, err := DeleteFromTree(root, p, p)
+			if gotErr, wantErr := errToString(err), tt.wantErr; gotErr != wantErr {
+				t.Fatalf("%s: gotErr:%s, wantErr:%s", tt.desc, gotErr, wantErr)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if gotFound != tt.wantFound {
+				t.Errorf("%s: gotFound:%v, wantFound:%v", tt.desc, gotFound, tt.wantFound)
+			}
+			if got, want := util.ToYAML(root), tt.want; util.YAMLDiff(got, want) != "" {
+				t.Errorf("%s: got:\n%s\nwant:\n%s\ndiff:\n%s\n", tt.desc, got, want, util.YAMLDiff(got, want))
+			}
+		})
+	}
+}
+
 // errToString returns the string representation of err and the empty string if
 // err is nil.
 func errToString(err error) string {
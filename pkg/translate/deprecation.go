@@ -0,0 +1,153 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"fmt"
+
+	"istio.io/operator/pkg/tpath"
+	"istio.io/operator/pkg/util"
+)
+
+// Deprecation describes a deprecated IstioControlPlaneSpec field.
+type Deprecation struct {
+	// Path is the path of the deprecated field in the IstioControlPlaneSpec YAML, e.g. values.pilot.replicaCount.
+	Path string `yaml:"path"`
+	// Version is the version that deprecated the field.
+	Version string `yaml:"version"`
+	// Replacement is the path of the field that replaces the deprecated field, if any.
+	Replacement string `yaml:"replacement,omitempty"`
+	// Transform is the name of the DeprecationTransform which converts the deprecated field value to the replacement
+	// field value. If unset, the value is moved unchanged.
+	Transform string `yaml:"transform,omitempty"`
+}
+
+// DeprecationTransform converts the value of a deprecated field to the value of its replacement.
+type DeprecationTransform func(value interface{}) (interface{}, error)
+
+var (
+	// deprecationTransforms maps a transform name used in the translateConfig deprecations to its implementation.
+	deprecationTransforms = map[string]DeprecationTransform{
+		"cpuTargetUtilizationToMetrics": cpuTargetUtilizationToMetrics,
+	}
+)
+
+// String implements the Stringer interface.
+func (d *Deprecation) String() string {
+	if d.Replacement == "" {
+		return fmt.Sprintf("%s is deprecated since %s", d.Path, d.Version)
+	}
+	return fmt.Sprintf("%s is deprecated since %s, use %s instead", d.Path, d.Version, d.Replacement)
+}
+
+// checkDeprecations checks that the deprecations in t are well formed.
+func (t *Translator) checkDeprecations() error {
+	for i, d := range t.Deprecations {
+		if d.Path == "" || d.Version == "" {
+			return fmt.Errorf("deprecation %d must have a path and version", i)
+		}
+		if d.Transform == "" {
+			continue
+		}
+		if d.Replacement == "" {
+			return fmt.Errorf("deprecation for %s has a transform but no replacement", d.Path)
+		}
+		if deprecationTransforms[d.Transform] == nil {
+			return fmt.Errorf("deprecation for %s has unknown transform %s", d.Path, d.Transform)
+		}
+	}
+	return nil
+}
+
+// FindDeprecated returns the deprecations for each deprecated field that is set in spec, which is an
+// IstioControlPlaneSpec YAML tree.
+func (t *Translator) FindDeprecated(spec map[string]interface{}) []*Deprecation {
+	var out []*Deprecation
+	for _, d := range t.Deprecations {
+		if _, found := tpath.GetNodeByPath(spec, util.PathFromString(d.Path)); found {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// UpgradeSpec moves the value of each deprecated field set in spec, which is an IstioControlPlaneSpec YAML tree, to
+// its replacement and returns the deprecations that were applied. If the replacement is already set, it is kept and
+// the deprecated field is dropped, since the replacement already takes precedence when rendering. Deprecated fields
+// without a replacement are left unchanged.
+func (t *Translator) UpgradeSpec(spec map[string]interface{}) ([]*Deprecation, error) {
+	var out []*Deprecation
+	for _, d := range t.FindDeprecated(spec) {
+		if d.Replacement == "" {
+			continue
+		}
+		path, rpath := util.PathFromString(d.Path), util.PathFromString(d.Replacement)
+		if _, found := tpath.GetNodeByPath(spec, rpath); !found {
+			val, _ := tpath.GetNodeByPath(spec, path)
+			if d.Transform != "" {
+				var err error
+				if val, err = deprecationTransforms[d.Transform](val); err != nil {
+					return nil, fmt.Errorf("could not transform %s to %s: %s", d.Path, d.Replacement, err)
+				}
+			}
+			if err := tpath.WriteNode(spec, rpath, val); err != nil {
+				return nil, fmt.Errorf("could not write %s: %s", d.Replacement, err)
+			}
+		}
+		deleteAndPrune(spec, path)
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// deleteAndPrune deletes the map entry at path in tree, and any maps on the path which are empty as a result.
+func deleteAndPrune(tree map[string]interface{}, path util.Path) {
+	if len(path) == 0 {
+		return
+	}
+	if len(path) == 1 {
+		delete(tree, path[0])
+		return
+	}
+	child, ok := tree[path[0]].(map[string]interface{})
+	if !ok {
+		return
+	}
+	deleteAndPrune(child, path[1:])
+	if len(child) == 0 {
+		delete(tree, path[0])
+	}
+}
+
+// cpuTargetUtilizationToMetrics converts a values CPUTargetUtilizationConfig to the equivalent HPA metrics list.
+func cpuTargetUtilizationToMetrics(value interface{}) (interface{}, error) {
+	cpu, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expect map, got %T", value)
+	}
+	tau, ok := cpu["targetAverageUtilization"]
+	if !ok {
+		return nil, fmt.Errorf("targetAverageUtilization is not set")
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"type": "Resource",
+			"resource": map[string]interface{}{
+				"name":                     "cpu",
+				"targetAverageUtilization": tau,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package translate
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/kr/pretty"
+
+	"istio.io/operator/pkg/util"
+	"istio.io/operator/pkg/version"
+)
+
+func TestUpgradeSpec(t *testing.T) {
+	tests := []struct {
+		desc        string
+		specYAML    string
+		want        string
+		wantApplied []string
+	}{
+		{
+			desc: "NoDeprecatedFields",
+			specYAML: `
+values:
+  pilot:
+    traceSampling: 0.1
+`,
+			want: `
+values:
+  pilot:
+    traceSampling: 0.1
+`,
+		},
+		{
+			desc: "MoveAndTransform",
+			specYAML: `
+values:
+  pilot:
+    replicaCount: 3
+    cpu:
+      targetAverageUtilization: 60
+  gateways:
+    istio-ingressgateway:
+      rollingMaxSurge: 2
+`,
+			want: `
+gateways:
+  components:
+    ingressGateway:
+      k8s:
+        strategy:
+          rollingUpdate:
+            maxSurge: 2
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        replicaCount: 3
+        hpaSpec:
+          metrics:
+          - type: Resource
+            resource:
+              name: cpu
+              targetAverageUtilization: 60
+`,
+			wantApplied: []string{
+				"values.pilot.replicaCount is deprecated since 1.4, use trafficManagement.components.pilot.k8s.replicaCount instead",
+				"values.pilot.cpu is deprecated since 1.4, use trafficManagement.components.pilot.k8s.hpaSpec.metrics instead",
+				"values.gateways.istio-ingressgateway.rollingMaxSurge is deprecated since 1.4, use " +
+					"gateways.components.ingressGateway.k8s.strategy.rollingUpdate.maxSurge instead",
+			},
+		},
+		{
+			desc: "ReplacementAlreadySet",
+			specYAML: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        replicaCount: 2
+values:
+  pilot:
+    replicaCount: 3
+  global:
+    priorityClassName: system-cluster-critical
+`,
+			want: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        replicaCount: 2
+values:
+  global:
+    priorityClassName: system-cluster-critical
+`,
+			wantApplied: []string{
+				"values.pilot.replicaCount is deprecated since 1.4, use trafficManagement.components.pilot.k8s.replicaCount instead",
+			},
+		},
+	}
+	tr, err := NewTranslator(version.NewMinorVersion(1, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			spec := make(map[string]interface{})
+			if err := yaml.Unmarshal([]byte(tt.specYAML), &spec); err != nil {
+				t.Fatal(err)
+			}
+			applied, err := tr.UpgradeSpec(spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotApplied []string
+			for _, d := range applied {
+				gotApplied = append(gotApplied, d.String())
+			}
+			if diff := pretty.Diff(gotApplied, tt.wantApplied); len(diff) != 0 {
+				t.Errorf("UpgradeSpec(%s): got applied:\n%s\nwant:\n%s", tt.desc, pretty.Sprint(gotApplied), pretty.Sprint(tt.wantApplied))
+			}
+			if got := util.ToYAML(spec); !util.IsYAMLEqual(got, tt.want) {
+				t.Errorf("UpgradeSpec(%s): got:\n%s\nwant:\n%s\ndiff:\n%s", tt.desc, got, tt.want, util.YAMLDiff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestCheckDeprecations(t *testing.T) {
+	tests := []struct {
+		desc    string
+		deps    []*Deprecation
+		wantErr string
+	}{
+		{
+			desc: "Valid",
+			deps: []*Deprecation{{Path: "values.a", Version: "1.4", Replacement: "b", Transform: "cpuTargetUtilizationToMetrics"}},
+		},
+		{
+			desc:    "NoVersion",
+			deps:    []*Deprecation{{Path: "values.a"}},
+			wantErr: "deprecation 0 must have a path and version",
+		},
+		{
+			desc:    "TransformWithoutReplacement",
+			deps:    []*Deprecation{{Path: "values.a", Version: "1.4", Transform: "cpuTargetUtilizationToMetrics"}},
+			wantErr: "deprecation for values.a has a transform but no replacement",
+		},
+		{
+			desc:    "UnknownTransform",
+			deps:    []*Deprecation{{Path: "values.a", Version: "1.4", Replacement: "b", Transform: "bad"}},
+			wantErr: "deprecation for values.a has unknown transform bad",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			tr := &Translator{Deprecations: tt.deps}
+			if gotErr, wantErr := errToString(tr.checkDeprecations()), tt.wantErr; gotErr != wantErr {
+				t.Errorf("checkDeprecations(%s): gotErr:%s, wantErr:%s", tt.desc, gotErr, wantErr)
+			}
+		})
+	}
+}
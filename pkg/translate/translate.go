@@ -34,6 +34,7 @@ import (
 	"istio.io/operator/pkg/vfs"
 	"istio.io/pkg/log"
 
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/client-go/kubernetes/scheme"
 )
@@ -66,12 +67,17 @@ type Translator struct {
 	APIMapping map[string]*Translation `yaml:"apiMapping"`
 	// KubernetesMapping defines mappings from an IstioControlPlane API paths to k8s resource paths.
 	KubernetesMapping map[string]*Translation `yaml:"kubernetesMapping"`
+	// ContainerMapping defines mappings from ContainerSpec paths to k8s resource paths, for each container listed in
+	// a component's K8S.Containers.
+	ContainerMapping map[string]*Translation `yaml:"containerMapping"`
 	// ToFeature maps a component to its parent feature.
 	ToFeature map[name.ComponentName]name.FeatureName `yaml:"toFeature"`
 	// FeatureMaps is a set of mappings for each Istio feature.
 	FeatureMaps map[name.FeatureName]*FeatureMap `yaml:"featureMaps"`
 	// GlobalNamespaces maps feature namespaces to Helm global namespace definitions.
 	GlobalNamespaces map[name.ComponentName]string `yaml:"globalNamespaces"`
+	// Deprecations lists the deprecated IstioControlPlaneSpec fields and their replacements.
+	Deprecations []*Deprecation `yaml:"deprecations"`
 	// ComponentMaps is a set of mappings for each Istio component.
 	ComponentMaps map[name.ComponentName]*ComponentMaps `yaml:"componentMaps"`
 
@@ -101,6 +107,10 @@ type ComponentMaps struct {
 	ToHelmValuesTreeRoot string
 	// AlwaysEnabled controls whether a component can be turned off through IstioControlPlaneSpec.
 	AlwaysEnabled bool
+
+	// renamed is set for component instances whose resources are renamed from the chart defaults by
+	// WithResourceName. K8s settings for such instances must match a resource in the output manifest.
+	renamed bool
 }
 
 // TranslationFunc maps a yamlStr API path into a YAML values tree.
@@ -126,6 +136,9 @@ func NewTranslator(minorVersion version.MinorVersion) (*Translator, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not Unmarshal translateConfig file %s: %s", f, err)
 	}
+	if err := t.checkDeprecations(); err != nil {
+		return nil, fmt.Errorf("bad deprecations in translateConfig file %s: %s", f, err)
+	}
 	t.featureToComponents = make(map[name.FeatureName][]name.ComponentName)
 	for c, f := range t.ToFeature {
 		t.featureToComponents[f] = append(t.featureToComponents[f], c)
@@ -145,6 +158,7 @@ func (t *Translator) OverlayK8sSettings(yml string, icp *v1alpha2.IstioControlPl
 	}
 	// om is a map of kind:name string to Object ptr.
 	om := objects.ToNameKindMap()
+	required := t.ComponentMaps[componentName] != nil && t.ComponentMaps[componentName].renamed
 	for inPath, v := range t.KubernetesMapping {
 		inPath, err := renderFeatureComponentPathTemplate(inPath, t.ToFeature[componentName], componentName)
 		if err != nil {
@@ -173,32 +187,129 @@ func (t *Translator) OverlayK8sSettings(yml string, icp *v1alpha2.IstioControlPl
 		if err != nil {
 			return "", err
 		}
-		log.Debugf("path has value in IstioControlPlaneSpec, mapping to output path %s", outPath)
-		path := util.PathFromString(outPath)
-		pe := path[0]
-		// Output path must start with [kind:name], which is used to map to the object to overlay.
-		if !util.IsKVPathElement(pe) {
-			return "", fmt.Errorf("path %s has an unexpected first element %s in OverlayK8sSettings", path, pe)
+		if err := overlayK8sSetting(om, m, outPath, required); err != nil {
+			p := icpYAMLPath(inPath)
+			return "", util.NewPathError(p, fmt.Errorf("%s: %s", p, err))
 		}
-		// After brackets are removed, the remaining "kind:name" is the same format as the keys in om.
-		pe, _ = util.RemoveBrackets(pe)
-		oo, ok := om[pe]
-		if !ok {
-			// skip to overlay the K8s settings if the corresponding resource doesn't exist.
-			log.Infof("resource Kind:name %s doesn't exist in the output manifest, skip overlay.", pe)
+	}
+	if err := t.overlayContainerSettings(om, icp, componentName, required); err != nil {
+		return "", err
+	}
+
+	return objects.YAMLManifest()
+}
+
+// overlayContainerSettings overlays the settings for each container in the K8S.Containers map of the given component
+// over the objects in om, based on t's container mappings.
+func (t *Translator) overlayContainerSettings(om map[string]*object.K8sObject, icp *v1alpha2.IstioControlPlaneSpec,
+	componentName name.ComponentName, required bool) error {
+	k8sPath, err := renderFeatureComponentPathTemplate("{{.FeatureName}}.Components.{{.ComponentName}}.K8S",
+		t.ToFeature[componentName], componentName)
+	if err != nil {
+		return err
+	}
+	k8s, found, err := tpath.GetFromStructPath(icp, k8sPath)
+	if err != nil || !found {
+		return err
+	}
+	containers := k8s.(*v1alpha2.KubernetesResourcesSpec).GetContainers()
+	cns := make([]string, 0, len(containers))
+	for cn := range containers {
+		cns = append(cns, cn)
+	}
+	sort.Strings(cns)
+	for _, cn := range cns {
+		if containers[cn] == nil {
 			continue
 		}
-
-		// strategic merge overlay m to the base object oo
-		mergedObj, err := mergeK8sObject(oo, m, path[1:])
-		if err != nil {
-			return "", err
+		for inPath, v := range t.ContainerMapping {
+			m, found, err := tpath.GetFromStructPath(containers[cn], inPath)
+			if err != nil {
+				return err
+			}
+			if !found {
+				continue
+			}
+			if mstr, ok := m.(string); ok && mstr == "" {
+				continue
+			}
+			outPath, err := t.renderResourceContainerPathTemplate(v.OutPath, componentName, cn)
+			if err != nil {
+				return err
+			}
+			containerPath := append(icpYAMLPath(k8sPath), "containers", cn)
+			if err := checkContainerExists(om, outPath, cn); err != nil {
+				return util.NewPathError(containerPath, fmt.Errorf("component %s: %s", componentName, err))
+			}
+			if err := overlayK8sSetting(om, m, outPath, required); err != nil {
+				return util.NewPathError(containerPath, fmt.Errorf("%s: %s", containerPath, err))
+			}
 		}
-		// Update the original object in objects slice, since the output should be ordered.
-		*(om[pe]) = *mergedObj
 	}
+	return nil
+}
 
-	return objects.YAMLManifest()
+// icpYAMLPath returns the YAML path for structPath, a path of Go field names in IstioControlPlaneSpec.
+func icpYAMLPath(structPath string) util.Path {
+	return util.ToJSONPath(reflect.TypeOf(v1alpha2.IstioControlPlaneSpec{}), util.PathFromString(structPath))
+}
+
+// overlayK8sSetting strategic merges m at outPath into the object in om that outPath refers to. outPath must start
+// with a [kind:name] element. Objects that are not in om are skipped, unless required is set, in which case it is an
+// error.
+func overlayK8sSetting(om map[string]*object.K8sObject, m interface{}, outPath string, required bool) error {
+	log.Debugf("path has value in IstioControlPlaneSpec, mapping to output path %s", outPath)
+	path := util.PathFromString(outPath)
+	pe := path[0]
+	// Output path must start with [kind:name], which is used to map to the object to overlay.
+	if !util.IsKVPathElement(pe) {
+		return fmt.Errorf("path %s has an unexpected first element %s in OverlayK8sSettings", path, pe)
+	}
+	// After brackets are removed, the remaining "kind:name" is the same format as the keys in om.
+	pe, _ = util.RemoveBrackets(pe)
+	oo, ok := om[pe]
+	if !ok && required {
+		return fmt.Errorf("resource Kind:name %s is not in the output manifest", pe)
+	}
+	if !ok {
+		// skip to overlay the K8s settings if the corresponding resource doesn't exist.
+		log.Infof("resource Kind:name %s doesn't exist in the output manifest, skip overlay.", pe)
+		return nil
+	}
+
+	// strategic merge overlay m to the base object oo
+	mergedObj, err := mergeK8sObject(oo, m, path[1:])
+	if err != nil {
+		return err
+	}
+	// Update the original object in objects slice, since the output should be ordered.
+	*(om[pe]) = *mergedObj
+	return nil
+}
+
+// checkContainerExists returns an error if the object that outPath refers to exists in om but has no pod template
+// container called containerName. Without this check, the strategic merge would add a new, incomplete container.
+func checkContainerExists(om map[string]*object.K8sObject, outPath, containerName string) error {
+	pe, _ := util.RemoveBrackets(util.PathFromString(outPath)[0])
+	oo, ok := om[pe]
+	if !ok {
+		return nil
+	}
+	containers, _, err := unstructured.NestedSlice(oo.UnstructuredObject().Object, "spec", "template", "spec", "containers")
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, c := range containers {
+		if cm, ok := c.(map[string]interface{}); ok {
+			n, _ := cm["name"].(string)
+			if n == containerName {
+				return nil
+			}
+			names = append(names, n)
+		}
+	}
+	return fmt.Errorf("container %s not found in %s, available containers are: %s", containerName, pe, strings.Join(names, ", "))
 }
 
 // ProtoToValues traverses the supplied IstioControlPlaneSpec and returns a values.yaml translation from it.
@@ -290,6 +401,25 @@ func (t *Translator) TranslateHelmValues(icp *v1alpha2.IstioControlPlaneSpec, co
 	return string(mergedYAML), err
 }
 
+// WithResourceName returns a copy of t in which the resources of the component componentName are named resourceName.
+// It is used to render several instances of a component, such as named gateways, from the same chart. The rendered
+// resources must be renamed to resourceName before the k8s settings of the copy are overlaid, and a k8s setting that
+// matches no resource is an error rather than being skipped.
+func (t *Translator) WithResourceName(componentName name.ComponentName, resourceName string) *Translator {
+	out := *t
+	out.ComponentMaps = make(map[name.ComponentName]*ComponentMaps, len(t.ComponentMaps))
+	for cn, cm := range t.ComponentMaps {
+		out.ComponentMaps[cn] = cm
+	}
+	if cm := t.ComponentMaps[componentName]; cm != nil {
+		ncm := *cm
+		ncm.ResourceName = resourceName
+		ncm.renamed = true
+		out.ComponentMaps[componentName] = &ncm
+	}
+	return &out
+}
+
 // Components returns the Components under the featureName feature.
 func (t *Translator) Components(featureName name.FeatureName) []name.ComponentName {
 	return t.featureToComponents[featureName]
@@ -485,6 +615,21 @@ func renderFeatureComponentPathTemplate(tmpl string, featureName name.FeatureNam
 	return renderTemplate(tmpl, ts)
 }
 
+// renderResourceContainerPathTemplate is like renderResourceComponentPathTemplate but renders {{.ContainerName}} with
+// the given containerName rather than the main container of the component.
+func (t *Translator) renderResourceContainerPathTemplate(tmpl string, componentName name.ComponentName, containerName string) (string, error) {
+	ts := struct {
+		ResourceType  string
+		ResourceName  string
+		ContainerName string
+	}{
+		ResourceType:  t.ComponentMaps[componentName].ResourceType,
+		ResourceName:  t.ComponentMaps[componentName].ResourceName,
+		ContainerName: containerName,
+	}
+	return renderTemplate(tmpl, ts)
+}
+
 // renderResourceComponentPathTemplate renders a template of the form <path>{{.ResourceName}}<path>{{.ContainerName}}<path> with
 // the supplied parameters.
 func (t *Translator) renderResourceComponentPathTemplate(tmpl string, componentName name.ComponentName) (string, error) {
@@ -15,11 +15,18 @@
 package translate
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/ghodss/yaml"
 	"github.com/kr/pretty"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/helm"
+	"istio.io/operator/pkg/object"
+	"istio.io/operator/pkg/patch"
 	"istio.io/operator/pkg/util"
 	"istio.io/operator/pkg/version"
 )
@@ -198,3 +205,401 @@ func errToString(err error) string {
 	}
 	return err.Error()
 }
+
+func TestOverlayK8sSettings(t *testing.T) {
+	manifest := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      serviceAccountName: istio-pilot-service-account
+      containers:
+      - name: discovery
+        image: pilot
+`
+	sidecarManifest := manifest + `      - name: istio-proxy
+        image: proxyv2
+`
+	tests := []struct {
+		desc     string
+		manifest string
+		icpYAML  string
+		want     string
+		wantErr  string
+	}{
+		{
+			desc: "SecurityContextsAndPullSecrets",
+			icpYAML: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        securityContext:
+          runAsUser: 1337
+        podSecurityContext:
+          fsGroup: 1337
+        imagePullSecrets:
+        - name: registry-creds
+        serviceAccountName: istio-pilot-psp
+`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      serviceAccountName: istio-pilot-psp
+      securityContext:
+        fsGroup: 1337
+      imagePullSecrets:
+      - name: registry-creds
+      containers:
+      - name: discovery
+        image: pilot
+        securityContext:
+          runAsUser: 1337
+`,
+		},
+		{
+			desc: "ProbesLifecycleAndTermination",
+			icpYAML: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: 8080
+          periodSeconds: 10
+        startupProbe:
+          tcpSocket:
+            port: http
+          failureThreshold: 30
+        lifecycle:
+          preStop:
+            exec:
+              command:
+              - sleep
+              - "5"
+        terminationGracePeriodSeconds: 60
+`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      serviceAccountName: istio-pilot-service-account
+      terminationGracePeriodSeconds: 60
+      containers:
+      - name: discovery
+        image: pilot
+        livenessProbe:
+          httpGet:
+            path: /healthz
+            port: 8080
+          periodSeconds: 10
+        startupProbe:
+          tcpSocket:
+            port: http
+          failureThreshold: 30
+        lifecycle:
+          preStop:
+            exec:
+              command:
+              - sleep
+              - "5"
+`,
+		},
+		{
+			desc: "ZeroTerminationGracePeriod",
+			icpYAML: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        terminationGracePeriodSeconds: 0
+`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      serviceAccountName: istio-pilot-service-account
+      terminationGracePeriodSeconds: 0
+      containers:
+      - name: discovery
+        image: pilot
+`,
+		},
+		{
+			desc: "TopologySpreadConstraints",
+			icpYAML: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        topologySpreadConstraints:
+        - maxSkew: 1
+          topologyKey: topology.kubernetes.io/zone
+          whenUnsatisfiable: ScheduleAnyway
+          labelSelector:
+            matchLabels:
+              app: pilot
+`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      serviceAccountName: istio-pilot-service-account
+      topologySpreadConstraints:
+      - maxSkew: 1
+        topologyKey: topology.kubernetes.io/zone
+        whenUnsatisfiable: ScheduleAnyway
+        labelSelector:
+          matchLabels:
+            app: pilot
+      containers:
+      - name: discovery
+        image: pilot
+`,
+		},
+		{
+			desc:     "PerContainerSettings",
+			manifest: sidecarManifest,
+			icpYAML: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        imagePullPolicy: Always
+        containers:
+          istio-proxy:
+            imagePullPolicy: IfNotPresent
+            resources:
+              limits:
+                cpu: 500m
+            env:
+            - name: PROXY_LOG_LEVEL
+              value: debug
+            securityContext:
+              runAsUser: 1337
+`,
+			want: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: istio-pilot
+  namespace: istio-system
+spec:
+  template:
+    spec:
+      serviceAccountName: istio-pilot-service-account
+      containers:
+      - name: discovery
+        image: pilot
+        imagePullPolicy: Always
+      - name: istio-proxy
+        image: proxyv2
+        imagePullPolicy: IfNotPresent
+        resources:
+          limits:
+            cpu: 500m
+        env:
+        - name: PROXY_LOG_LEVEL
+          value: debug
+        securityContext:
+          runAsUser: 1337
+`,
+		},
+		{
+			desc: "UnknownContainer",
+			icpYAML: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        containers:
+          istio-proxy:
+            imagePullPolicy: IfNotPresent
+`,
+			wantErr: "component Pilot: container istio-proxy not found in Deployment:istio-pilot, available containers are: discovery",
+		},
+	}
+	tr, err := NewTranslator(version.NewMinorVersion(1, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			icp := &v1alpha2.IstioControlPlaneSpec{}
+			if err := util.UnmarshalWithJSONPB(tt.icpYAML, icp); err != nil {
+				t.Fatal(err)
+			}
+			m := manifest
+			if tt.manifest != "" {
+				m = tt.manifest
+			}
+			got, err := tr.OverlayK8sSettings(m, icp, "Pilot")
+			if gotErr, wantErr := errToString(err), tt.wantErr; gotErr != wantErr {
+				t.Fatalf("OverlayK8sSettings(%s): got error: %s, want error: %s", tt.desc, gotErr, wantErr)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			if !util.IsYAMLEqual(got, tt.want) {
+				t.Errorf("OverlayK8sSettings(%s): got:\n%s\nwant:\n%s\ndiff:\n%s", tt.desc, got, tt.want, util.YAMLDiff(got, tt.want))
+			}
+		})
+	}
+}
+
+func TestWithResourceName(t *testing.T) {
+	icpYAML := `
+gateways:
+  components:
+    ingressGateway:
+      enabled: true
+      k8s:
+        replicaCount: 3
+        service:
+          type: ClusterIP
+`
+	tr, err := NewTranslator(version.NewMinorVersion(1, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gtr := tr.WithResourceName("IngressGateway", "internal-ingressgateway")
+	if got, want := tr.ComponentMaps["IngressGateway"].ResourceName, "istio-ingressgateway"; got != want {
+		t.Errorf("original translator ResourceName: got %s, want %s", got, want)
+	}
+	// Like a named gateway, the base component settings are replaced by those of the gateway.
+	icp := readDefaultProfileSpec(t)
+	gw := &v1alpha2.IstioControlPlaneSpec{}
+	if err := util.UnmarshalWithJSONPB(icpYAML, gw); err != nil {
+		t.Fatal(err)
+	}
+	icp.Gateways.Components.IngressGateway = gw.Gateways.Components.IngressGateway
+
+	// Render the objects of the real ingress gateway chart.
+	values, err := gtr.TranslateHelmValues(icp, "IngressGateway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := helm.NewVFSRenderer(gtr.ComponentMaps["IngressGateway"].HelmSubdir, "IngressGateway", "istio-system")
+	if err := r.Run(); err != nil {
+		t.Fatal(err)
+	}
+	chartYAML, err := r.RenderManifest(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("NotRenamed", func(t *testing.T) {
+		// The chart objects are named istio-ingressgateway, so the settings for internal-ingressgateway match nothing.
+		_, err := gtr.OverlayK8sSettings(chartYAML, icp, "IngressGateway")
+		if got, want := errToString(err), ":internal-ingressgateway is not in the output manifest"; !strings.HasSuffix(got, want) {
+			t.Errorf("OverlayK8sSettings: got error: %s, want error ending with: %s", got, want)
+		}
+	})
+
+	t.Run("Renamed", func(t *testing.T) {
+		wantLabels := map[string]interface{}{"app": "internal-ingressgateway", "istio": "internal-ingressgateway"}
+		renamed, err := patch.RenameObjects(chartYAML, "istio-ingressgateway", "internal-ingressgateway",
+			[]string{"ingressgateway"}, map[string]string{"app": "istio-ingressgateway", "istio": "ingressgateway"},
+			map[string]string{"app": "internal-ingressgateway", "istio": "internal-ingressgateway"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := gtr.OverlayK8sSettings(renamed, icp, "IngressGateway")
+		if err != nil {
+			t.Fatal(err)
+		}
+		objs, err := object.ParseK8sObjectsFromYAMLManifest(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		om := objs.ToNameKindMap()
+		for _, o := range objs {
+			if o.Name == "istio-ingressgateway" || o.Name == "ingressgateway" {
+				t.Errorf("%s:%s was not renamed", o.Kind, o.Name)
+			}
+		}
+		for _, tt := range []struct {
+			obj   string
+			path  []string
+			value interface{}
+		}{
+			{obj: "Deployment:internal-ingressgateway", path: []string{"spec", "replicas"}, value: int64(3)},
+			{obj: "Deployment:internal-ingressgateway", path: []string{"spec", "selector", "matchLabels"}, value: wantLabels},
+			{obj: "Deployment:internal-ingressgateway", path: []string{"spec", "template", "metadata", "labels", "app"},
+				value: "internal-ingressgateway"},
+			{obj: "Deployment:internal-ingressgateway", path: []string{"spec", "template", "metadata", "labels", "istio"},
+				value: "internal-ingressgateway"},
+			{obj: "Deployment:internal-ingressgateway", path: []string{"spec", "template", "spec", "serviceAccountName"},
+				value: "internal-ingressgateway-service-account"},
+			{obj: "Service:internal-ingressgateway", path: []string{"spec", "type"}, value: "ClusterIP"},
+			{obj: "Service:internal-ingressgateway", path: []string{"spec", "selector"}, value: wantLabels},
+			{obj: "Gateway:internal-ingressgateway", path: []string{"spec", "selector"}, value: wantLabels},
+			{obj: "HorizontalPodAutoscaler:internal-ingressgateway", path: []string{"spec", "scaleTargetRef", "name"},
+				value: "internal-ingressgateway"},
+			{obj: "ServiceAccount:internal-ingressgateway-service-account", path: []string{"metadata", "labels", "app"},
+				value: "internal-ingressgateway"},
+		} {
+			o, ok := om[tt.obj]
+			if !ok {
+				t.Errorf("%s is not in the manifest", tt.obj)
+				continue
+			}
+			v, _, err := unstructured.NestedFieldNoCopy(o.UnstructuredObject().Object, tt.path...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(v, tt.value) {
+				t.Errorf("%s %v: got %v, want %v", tt.obj, tt.path, v, tt.value)
+			}
+		}
+	})
+}
+
+// readDefaultProfileSpec returns the IstioControlPlaneSpec of the default profile.
+func readDefaultProfileSpec(t *testing.T) *v1alpha2.IstioControlPlaneSpec {
+	t.Helper()
+	py, err := helm.ReadProfileYAML("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(py), &profile); err != nil {
+		t.Fatal(err)
+	}
+	sy, err := yaml.Marshal(profile["spec"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	icp := &v1alpha2.IstioControlPlaneSpec{}
+	if err := util.UnmarshalWithJSONPB(string(sy), icp); err != nil {
+		t.Fatal(err)
+	}
+	return icp
+}
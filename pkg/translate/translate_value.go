@@ -66,6 +66,17 @@ var (
 				"{{.ValueComponentName}}.resources":             "{{.FeatureName}}.Components.{{.ComponentName}}.K8s.Resources",
 				"{{.ValueComponentName}}.rollingMaxSurge":       "{{.FeatureName}}.Components.{{.ComponentName}}.K8s.Strategy",
 				"{{.ValueComponentName}}.rollingMaxUnavailable": "{{.FeatureName}}.Components.{{.ComponentName}}.K8s.Strategy",
+				"{{.ValueComponentName}}.securityContext":       "{{.FeatureName}}.Components.{{.ComponentName}}.K8s.SecurityContext",
+				"{{.ValueComponentName}}.podSecurityContext":    "{{.FeatureName}}.Components.{{.ComponentName}}.K8s.PodSecurityContext",
+				"{{.ValueComponentName}}.imagePullSecrets":      "{{.FeatureName}}.Components.{{.ComponentName}}.K8s.ImagePullSecrets",
+				"{{.ValueComponentName}}.serviceAccountName":    "{{.FeatureName}}.Components.{{.ComponentName}}.K8s.ServiceAccountName",
+				"{{.ValueComponentName}}.livenessProbe":         "{{.FeatureName}}.Components.{{.ComponentName}}.K8s.LivenessProbe",
+				"{{.ValueComponentName}}.startupProbe":          "{{.FeatureName}}.Components.{{.ComponentName}}.K8s.StartupProbe",
+				"{{.ValueComponentName}}.lifecycle":             "{{.FeatureName}}.Components.{{.ComponentName}}.K8s.Lifecycle",
+				"{{.ValueComponentName}}.terminationGracePeriodSeconds": "{{.FeatureName}}.Components.{{.ComponentName}}." +
+					"K8s.TerminationGracePeriodSeconds",
+				"{{.ValueComponentName}}.topologySpreadConstraints": "{{.FeatureName}}.Components.{{.ComponentName}}." +
+					"K8s.TopologySpreadConstraints",
 			},
 			KubernetesMapping:     map[string]*Translation{},
 			ValuesToComponentName: map[string]name.ComponentName{},
@@ -400,6 +411,31 @@ func translateAffinity(outPath string, value interface{}, cpSpecTree map[string]
 	return nil
 }
 
+// translateImagePullSecrets translates imagePullSecrets from helm values.yaml tree. Values may list secrets either by
+// name, as in global.imagePullSecrets, or as k8s LocalObjectReferences.
+func translateImagePullSecrets(outPath string, value interface{}, cpSpecTree map[string]interface{}) error {
+	secrets, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("expect imagePullSecrets node type to be []interface{} but got: %T", value)
+	}
+	outSecrets := make([]interface{}, 0, len(secrets))
+	for _, s := range secrets {
+		switch st := s.(type) {
+		case string:
+			outSecrets = append(outSecrets, map[string]interface{}{"name": st})
+		case map[string]interface{}:
+			outSecrets = append(outSecrets, st)
+		default:
+			return fmt.Errorf("expect imagePullSecrets entry type to be string or map but got: %T", s)
+		}
+	}
+	log.Infof("path has value in helm Value.yaml tree, mapping to output path %s", outPath)
+	if err := tpath.WriteNode(cpSpecTree, util.ToYAMLPath(outPath), outSecrets); err != nil {
+		return err
+	}
+	return nil
+}
+
 // translateK8sTree is internal method for translating K8s configurations from value.yaml tree.
 func (t *ReverseTranslator) translateK8sTree(valueTree map[string]interface{},
 	cpSpecTree map[string]interface{}) error {
@@ -456,6 +492,12 @@ func (t *ReverseTranslator) translateK8sTree(valueTree map[string]interface{},
 				return fmt.Errorf("error in translating k8s Strategy: %s", err)
 			}
 
+		case "imagePullSecrets":
+			err := translateImagePullSecrets(v.OutPath, m, cpSpecTree)
+			if err != nil {
+				return fmt.Errorf("error in translating k8s ImagePullSecrets: %s", err)
+			}
+
 		default:
 			output := util.ToYAMLPath(v.OutPath)
 			log.Infof("path has value in helm Value.yaml tree, mapping to output path %s", output)
@@ -419,3 +419,118 @@ autoInjection:
 		})
 	}
 }
+
+func TestValueToProtoK8sSettings(t *testing.T) {
+	tests := []struct {
+		desc      string
+		valueYAML string
+		want      string
+		wantErr   string
+	}{
+		{
+			desc: "SecurityContextsAndPullSecrets",
+			valueYAML: `
+pilot:
+  enabled: true
+  securityContext:
+    runAsUser: 1337
+    allowPrivilegeEscalation: false
+  podSecurityContext:
+    fsGroup: 1337
+  imagePullSecrets:
+  - registry-creds
+  - name: other-creds
+  serviceAccountName: istio-pilot-psp
+`,
+			// jsonpb outputs int64 values as strings.
+			want: `
+securityContext:
+  runAsUser: "1337"
+  allowPrivilegeEscalation: false
+podSecurityContext:
+  fsGroup: "1337"
+imagePullSecrets:
+- name: registry-creds
+- name: other-creds
+serviceAccountName: istio-pilot-psp
+`,
+		},
+		{
+			desc: "ProbesLifecycleAndTopology",
+			valueYAML: `
+pilot:
+  enabled: true
+  livenessProbe:
+    httpGet:
+      path: /healthz
+      port: 8080
+  startupProbe:
+    tcpSocket:
+      port: 15010
+    failureThreshold: 30
+  lifecycle:
+    preStop:
+      exec:
+        command: ["sleep", "5"]
+  terminationGracePeriodSeconds: 60
+  topologySpreadConstraints:
+  - maxSkew: 1
+    topologyKey: topology.kubernetes.io/zone
+    whenUnsatisfiable: DoNotSchedule
+`,
+			want: `
+livenessProbe:
+  httpGet:
+    path: /healthz
+    port: 8080
+startupProbe:
+  tcpSocket:
+    port: 15010
+  failureThreshold: 30
+lifecycle:
+  preStop:
+    exec:
+      command: ["sleep", "5"]
+terminationGracePeriodSeconds: 60
+topologySpreadConstraints:
+- maxSkew: 1
+  topologyKey: topology.kubernetes.io/zone
+  whenUnsatisfiable: DoNotSchedule
+`,
+		},
+		{
+			desc: "BadPullSecrets",
+			valueYAML: `
+pilot:
+  enabled: true
+  imagePullSecrets: registry-creds
+`,
+			wantErr: "error when translating value.yaml tree with kubernetes mapping: error in translating k8s ImagePullSecrets: " +
+				"expect imagePullSecrets node type to be []interface{} but got: string",
+		},
+	}
+	tr, err := NewReverseTranslator(version.NewMinorVersion(1, 4))
+	if err != nil {
+		t.Fatal("fail to get helm value.yaml translator")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, err := tr.TranslateFromValueToSpec([]byte(tt.valueYAML))
+			if gotErr, wantErr := errToString(err), tt.wantErr; gotErr != wantErr {
+				t.Fatalf("ValuesToProto(%s)(%v): gotErr:%s, wantErr:%s", tt.desc, tt.valueYAML, gotErr, wantErr)
+			}
+			if tt.wantErr != "" {
+				return
+			}
+			ms := jsonpb.Marshaler{}
+			gotString, err := ms.MarshalToString(got.TrafficManagement.Components.Pilot.K8S)
+			if err != nil {
+				t.Fatalf("error when marshal translated KubernetesResourcesSpec: %s", err)
+			}
+			if want := tt.want; !util.IsYAMLEqual(gotString, want) {
+				t.Errorf("ValuesToProto(%s): got:\n%s\n\nwant:\n%s\nDiff:\n%s\n", tt.desc, gotString, want, util.YAMLDiff(gotString, want))
+			}
+		})
+	}
+}
@@ -105,3 +105,49 @@ func EqualErrors(a, b Errors) bool {
 	}
 	return true
 }
+
+// PathError is an error about the node at Path in a YAML tree, like an IstioControlPlaneSpec. Its message is the
+// message of Err, which usually already includes the path; Path allows the error to be traced back to its source.
+type PathError struct {
+	// Path is the YAML path of the node the error is about.
+	Path Path
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error#Error method.
+func (e *PathError) Error() string {
+	return e.Err.Error()
+}
+
+// NewPathError returns a PathError for err at path, or nil if err is nil.
+func NewPathError(path Path, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PathError{Path: append(Path{}, path...), Err: err}
+}
+
+// WithPath returns errs with each error that is not already a PathError wrapped in a PathError for path.
+func WithPath(path Path, errs Errors) Errors {
+	var out Errors
+	for _, e := range errs {
+		if _, ok := e.(*PathError); !ok {
+			e = NewPathError(path, e)
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// PrefixPaths returns errs with prefix added to the path of each PathError.
+func PrefixPaths(prefix Path, errs Errors) Errors {
+	var out Errors
+	for _, e := range errs {
+		if pe, ok := e.(*PathError); ok {
+			e = NewPathError(append(append(Path{}, prefix...), pe.Path...), pe.Err)
+		}
+		out = append(out, e)
+	}
+	return out
+}
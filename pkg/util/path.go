@@ -18,12 +18,19 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
 const (
 	// PathSeparator is the separator between path elements.
 	PathSeparator = "."
+	// RecursiveDescentPathElement is a path element that matches zero or more path elements at any depth.
+	RecursiveDescentPathElement = "**"
+	// WildcardPathElement is a path element that matches every entry of a list.
+	WildcardPathElement = "[*]"
+	// AppendPathElement is a path element that refers to a new entry at the end of a list.
+	AppendPathElement = "[+]"
 	// KVSeparator is the separator between the key and value in a key/value path element,
 	KVSeparator     = string(kvSeparatorRune)
 	kvSeparatorRune = ':'
@@ -100,6 +107,28 @@ func IsVPathElement(pe string) bool {
 	return len(pe) > 0
 }
 
+// IsNPathElement report whether pe is a numeric index path element like [0] or [-1]. Negative indexes count back from
+// the end of the list.
+func IsNPathElement(pe string) bool {
+	pe, ok := RemoveBrackets(pe)
+	if !ok {
+		return false
+	}
+
+	_, err := strconv.Atoi(pe)
+	return err == nil
+}
+
+// IsWildcardPath reports whether p contains any path elements that can match more than one node.
+func IsWildcardPath(p Path) bool {
+	for _, pe := range p {
+		if pe == WildcardPathElement || pe == RecursiveDescentPathElement {
+			return true
+		}
+	}
+	return false
+}
+
 // Path KVreturns the key and value string parts of the entire key/value path element.
 // It returns an error if pe is not a key/value path element.
 func PathKV(pe string) (k, v string, err error) {
@@ -121,6 +150,16 @@ func PathV(pe string) (string, error) {
 	return v, nil
 }
 
+// PathN returns the index from the numeric index path element pe.
+// It returns an error if pe is not a numeric index path element.
+func PathN(pe string) (int, error) {
+	if !IsNPathElement(pe) {
+		return -1, fmt.Errorf("%s is not a valid index path element", pe)
+	}
+	v, _ := RemoveBrackets(pe)
+	return strconv.Atoi(v)
+}
+
 // RemoveBrackets removes the [] around pe and returns the resulting string. It returns false if pe is not surrounded
 // by [].
 func RemoveBrackets(pe string) (string, bool) {
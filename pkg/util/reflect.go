@@ -17,6 +17,7 @@ package util
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 
 	"github.com/kr/pretty"
 )
@@ -254,6 +255,21 @@ func UpdateSlicePtr(parentSlice interface{}, index int, value interface{}) error
 	return nil
 }
 
+// AppendToSlicePtr appends value to the parent, which must be a slice ptr.
+func AppendToSlicePtr(parentSlice interface{}, value interface{}) error {
+	scope.Debugf("AppendToSlicePtr parent=\n%s\n, value=\n%v", pretty.Sprint(parentSlice), value)
+	pv := reflect.ValueOf(parentSlice)
+	v := reflect.ValueOf(value)
+
+	if !IsSliceInterfacePtr(parentSlice) {
+		return fmt.Errorf("appendToSlicePtr parent type is %T, must be *[]interface{}", parentSlice)
+	}
+
+	pv.Elem().Set(reflect.Append(pv.Elem().Elem(), v))
+
+	return nil
+}
+
 // InsertIntoMap inserts value with key into parent which must be a map, map ptr, or interface to map.
 func InsertIntoMap(parentMap interface{}, key interface{}, value interface{}) error {
 	scope.Debugf("InsertIntoMap key=%v, value=%s, map=\n%s", key, pretty.Sprint(value), pretty.Sprint(parentMap))
@@ -310,3 +326,39 @@ func IsUintKind(k reflect.Kind) bool {
 	}
 	return false
 }
+
+// ToJSONPath converts path, made of Go field names of the struct type t and its descendants like
+// TrafficManagement.Components.Pilot.K8S, to the path with the names used in JSON and YAML, like
+// trafficManagement.components.pilot.k8s. Map keys and elements in free form trees are unchanged and slice indexes are
+// converted to [i]. Path elements which are not found in t have their first character lower cased.
+func ToJSONPath(t reflect.Type, path Path) Path {
+	var out Path
+	for _, pe := range path {
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch {
+		case t != nil && t.Kind() == reflect.Struct:
+			f, ok := t.FieldByName(pe)
+			if names, _ := JSONFieldNames(f); ok && len(names) != 0 {
+				out, t = append(out, names[0]), f.Type
+				continue
+			}
+		case t != nil && t.Kind() == reflect.Map:
+			out, t = append(out, pe), t.Elem()
+			continue
+		case t != nil && t.Kind() == reflect.Interface:
+			// Free form trees like values have no field names to convert.
+			out = append(out, pe)
+			continue
+		case t != nil && t.Kind() == reflect.Slice:
+			if _, err := strconv.Atoi(pe); err == nil {
+				pe = "[" + pe + "]"
+			}
+			out, t = append(out, pe), t.Elem()
+			continue
+		}
+		out, t = append(out, firstCharToLowerCase(pe)), nil
+	}
+	return out
+}
@@ -339,6 +339,24 @@ func TestUpdateSlicePtr(t *testing.T) {
 	}
 }
 
+func TestAppendToSlicePtr(t *testing.T) {
+	parentSlice := []int{42, 43}
+	var parentSliceI interface{} = parentSlice
+	if err := AppendToSlicePtr(&parentSliceI, 44); err != nil {
+		t.Fatalf("got error: %s, want error: nil", err)
+	}
+	wantSlice := []int{42, 43, 44}
+	if got, want := parentSliceI, wantSlice; !reflect.DeepEqual(got, want) {
+		t.Errorf("got:\n%v\nwant:\n%v\n", got, want)
+	}
+
+	badParent := struct{}{}
+	wantErr := `appendToSlicePtr parent type is *struct {}, must be *[]interface{}`
+	if got, want := errToString(AppendToSlicePtr(&badParent, 44)), wantErr; got != want {
+		t.Fatalf("got error: %s, want error: %s", got, want)
+	}
+}
+
 func TestInsertIntoMap(t *testing.T) {
 	parentMap := map[int]string{42: "forty two", 43: "forty three"}
 	key := 44
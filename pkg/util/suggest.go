@@ -0,0 +1,238 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// UnknownFieldError returns an error for the first field in tree, in path order, which has no corresponding field in
+// out, or nil if all fields in tree are known. tree is a YAML or JSON tree and out is the struct, usually a proto
+// message, that it is unmarshaled into. Field names are taken from the protobuf struct tags generated from the proto
+// descriptors, or from json tags for non-proto types. The error includes the path of the field prefixed with path and,
+// if a known field has a similar name, a suggestion for it. The returned error is a PathError.
+func UnknownFieldError(tree interface{}, out interface{}, path Path) error {
+	return unknownFieldError(tree, reflect.TypeOf(out), path)
+}
+
+func unknownFieldError(node interface{}, t reflect.Type, path Path) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if hasCustomUnmarshal(t) {
+		// Types like BoolValueForPB, IntOrStringForPB or resource.Quantity parse their own value, so there are no fields to check.
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		fields, names := structFields(t)
+		for _, k := range sortedStringKeys(m) {
+			ft, ok := fields[k]
+			if !ok {
+				return unknownField(append(path, k), k, names)
+			}
+			if err := unknownFieldError(m[k], ft, append(path, k)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for _, k := range sortedStringKeys(m) {
+			if err := unknownFieldError(m[k], t.Elem(), append(path, k)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		l, ok := node.([]interface{})
+		if !ok {
+			return nil
+		}
+		for i, le := range l {
+			if err := unknownFieldError(le, t.Elem(), append(path, fmt.Sprintf("[%d]", i))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unknownField returns the error for the unknown field k at path, with a suggestion from the known field names.
+func unknownField(path Path, k string, names []string) error {
+	if s := Suggest(k, names); s != "" {
+		return NewPathError(path, fmt.Errorf("unknown field %s; did you mean %s?", path, s))
+	}
+	return NewPathError(path, fmt.Errorf("unknown field %s", path))
+}
+
+// hasCustomUnmarshal reports whether values of type t are unmarshaled by a method of the type rather than field by
+// field.
+func hasCustomUnmarshal(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface {
+		return true
+	}
+	pt := reflect.PtrTo(t)
+	for _, m := range []string{"UnmarshalJSON", "UnmarshalJSONPB", "XXX_WellKnownType"} {
+		if _, ok := pt.MethodByName(m); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// structFields returns a map of every name that can be used in JSON or YAML for a field of the struct type t to the
+// type of the field, and a sorted list of the preferred names.
+func structFields(t reflect.Type) (map[string]reflect.Type, []string) {
+	fields := make(map[string]reflect.Type)
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		fnames, inline := JSONFieldNames(f)
+		if inline {
+			// Embedded structs like k8s TypeMeta are inlined.
+			ef, en := structFields(f.Type)
+			for k, v := range ef {
+				fields[k] = v
+			}
+			names = append(names, en...)
+			continue
+		}
+		for _, n := range fnames {
+			fields[n] = f.Type
+		}
+		if len(fnames) != 0 {
+			names = append(names, fnames[0])
+		}
+	}
+	sort.Strings(names)
+	return fields, names
+}
+
+// JSONFieldNames returns the names that can be used for the struct field f in JSON or YAML, preferred name first, and
+// whether f is an embedded struct whose fields are inlined. jsonpb accepts the json tag name as well as the original
+// proto field name and the JSON name from the descriptor. The descriptor JSON name is the one used in the docs and
+// examples, so it is preferred. No names are returned for fields which are not marshaled.
+func JSONFieldNames(f reflect.StructField) (names []string, inline bool) {
+	if strings.HasPrefix(f.Name, "XXX_") {
+		return nil, false
+	}
+	jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+	switch {
+	case jsonName == "-":
+		return nil, false
+	case f.Anonymous && jsonName == "":
+		return nil, true
+	case jsonName == "":
+		jsonName = f.Name
+	}
+	var protoName, protoJSONName string
+	for _, tv := range strings.Split(f.Tag.Get("protobuf"), ",") {
+		switch {
+		case strings.HasPrefix(tv, "name="):
+			protoName = strings.TrimPrefix(tv, "name=")
+		case strings.HasPrefix(tv, "json="):
+			protoJSONName = strings.TrimPrefix(tv, "json=")
+		}
+	}
+	for _, n := range []string{protoJSONName, protoName, jsonName} {
+		if n != "" && !containsString(names, n) {
+			names = append(names, n)
+		}
+	}
+	return names, false
+}
+
+func containsString(l []string, s string) bool {
+	for _, ls := range l {
+		if ls == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Suggest returns the candidate closest to s, or an empty string if no candidate is close. A candidate which is equal
+// to s ignoring case is preferred, otherwise the candidate with the smallest edit distance is returned if the distance
+// is at most a third of the length of s.
+func Suggest(s string, candidates []string) string {
+	for _, c := range candidates {
+		if strings.EqualFold(s, c) {
+			return c
+		}
+	}
+	best, bestDist := "", len(s)/3+1
+	if bestDist < 2 {
+		bestDist = 2
+	}
+	for _, c := range candidates {
+		if d := editDistance(strings.ToLower(s), strings.ToLower(c)); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the edit distance between a and b, counting insertions, deletions, substitutions and
+// transpositions of adjacent characters.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func minInt(a int, b ...int) int {
+	for _, bb := range b {
+		if bb < a {
+			a = bb
+		}
+	}
+	return a
+}
+
+func sortedStringKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
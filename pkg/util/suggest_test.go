@@ -0,0 +1,202 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+)
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"pilot", "policy", "proxy", "replicaCount", "trafficManagement"}
+	tests := []struct {
+		desc string
+		in   string
+		want string
+	}{
+		{
+			desc: "plural",
+			in:   "pilots",
+			want: "pilot",
+		},
+		{
+			desc: "case",
+			in:   "trafficmanagement",
+			want: "trafficManagement",
+		},
+		{
+			desc: "transposed",
+			in:   "replicaCuont",
+			want: "replicaCount",
+		},
+		{
+			desc: "too far",
+			in:   "telemetry",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := Suggest(tt.in, candidates); got != tt.want {
+				t.Errorf("Suggest(%s): got %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+type testSpec struct {
+	TrafficManagement *testFeature           `protobuf:"bytes,1,opt,name=traffic_management,json=trafficManagement,proto3" json:"traffic_management,omitempty"`
+	Values            map[string]interface{} `protobuf:"bytes,2,opt,name=values,proto3" json:"values,omitempty"`
+	Labels            map[string]string      `json:"labels,omitempty"`
+	Ports             []*testPort            `json:"ports,omitempty"`
+	XXX_unrecognized  []byte                 `json:"-"`
+}
+
+type testFeature struct {
+	Enabled    *testBool                 `json:"enabled,omitempty"`
+	Components map[string]*testComponent `json:"components,omitempty"`
+}
+
+// testBool has its own unmarshaler, so its value is not checked for fields.
+type testBool struct {
+	Value bool
+}
+
+func (b *testBool) UnmarshalJSON(in []byte) error {
+	return json.Unmarshal(in, &b.Value)
+}
+
+type testComponent struct {
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type testPort struct {
+	Port int32  `json:"port,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+func TestUnknownFieldError(t *testing.T) {
+	tests := []struct {
+		desc    string
+		yamlStr string
+		wantErr string
+	}{
+		{
+			desc: "known",
+			yamlStr: `
+traffic_management:
+  enabled: true
+  components:
+    pilot:
+      namespace: istio-system
+trafficManagement:
+  enabled: false
+values:
+  anything: goes
+labels:
+  app: foo
+ports:
+- port: 80
+  name: http
+`,
+		},
+		{
+			desc: "top level",
+			yamlStr: `
+trafficmanagement:
+  enabled: true
+`,
+			wantErr: "unknown field trafficmanagement; did you mean trafficManagement?",
+		},
+		{
+			desc: "nested",
+			yamlStr: `
+trafficManagement:
+  enabled: true
+  components:
+    pilot:
+      namespaces: istio-system
+`,
+			wantErr: "unknown field trafficManagement.components.pilot.namespaces; did you mean namespace?",
+		},
+		{
+			desc: "list",
+			yamlStr: `
+ports:
+- port: 80
+- prot: 81
+`,
+			wantErr: "unknown field ports.[1].prot; did you mean port?",
+		},
+		{
+			desc: "no suggestion",
+			yamlStr: `
+foo: bar
+`,
+			wantErr: "unknown field foo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var tree interface{}
+			if err := yaml.Unmarshal([]byte(tt.yamlStr), &tree); err != nil {
+				t.Fatal(err)
+			}
+			err := UnknownFieldError(tree, &testSpec{}, nil)
+			if got, want := errToString(err), tt.wantErr; got != want {
+				t.Errorf("%s: got error %s, want error %s", tt.desc, got, want)
+			}
+		})
+	}
+}
+
+func TestToJSONPath(t *testing.T) {
+	tests := []struct {
+		desc string
+		in   string
+		want string
+	}{
+		{
+			desc: "struct fields",
+			in:   "TrafficManagement.Components.pilot.Namespace",
+			want: "trafficManagement.components.pilot.namespace",
+		},
+		{
+			desc: "slice index",
+			in:   "Ports.1.Name",
+			want: "ports.[1].name",
+		},
+		{
+			desc: "map",
+			in:   "Values.global.Hub",
+			want: "values.global.Hub",
+		},
+		{
+			desc: "unknown field",
+			in:   "Foo.Bar",
+			want: "foo.bar",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if got := ToJSONPath(reflect.TypeOf(testSpec{}), PathFromString(tt.in)).String(); got != tt.want {
+				t.Errorf("ToJSONPath(%s): got %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package util
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -75,7 +76,7 @@ func UnmarshalWithJSONPB(y string, out proto.Message) error {
 	u := jsonpb.Unmarshaler{AllowUnknownFields: false}
 	err = u.Unmarshal(bytes.NewReader(jb), out)
 	if err != nil {
-		return err
+		return withUnknownField(err, jb, out)
 	}
 	return nil
 }
@@ -89,11 +90,27 @@ func UnmarshalValuesWithJSONPB(y string, out proto.Message, allowUnknown bool) e
 	u := jsonpb2.Unmarshaler{AllowUnknownFields: allowUnknown}
 	err = u.Unmarshal(bytes.NewReader(jb), out)
 	if err != nil {
-		return err
+		return withUnknownField(err, jb, out)
 	}
 	return nil
 }
 
+// withUnknownField returns an error with the path of the unknown field and a suggestion if err is a jsonpb unknown
+// field error for unmarshaling jb into out, or err otherwise.
+func withUnknownField(err error, jb []byte, out interface{}) error {
+	if !strings.HasPrefix(err.Error(), "unknown field") {
+		return err
+	}
+	var tree interface{}
+	if json.Unmarshal(jb, &tree) != nil {
+		return err
+	}
+	if uerr := UnknownFieldError(tree, out, nil); uerr != nil {
+		return uerr
+	}
+	return err
+}
+
 /*func ObjectsInManifest(mstr string) string {
 	ao, err := manifest.ParseObjectsFromYAMLManifest(mstr)
 	if err != nil {
@@ -0,0 +1,52 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+
+	"istio.io/operator/pkg/translate"
+)
+
+const (
+	// deprecatedRuleName is the rule name used in findings for deprecated fields.
+	deprecatedRuleName = "deprecated-field"
+)
+
+// CheckDeprecations returns a warning for each field in specYAML, an IstioControlPlaneSpec YAML, which is deprecated
+// according to t. Unlike CheckSemantics, it should be run over the user supplied spec rather than the spec merged with
+// its profile, since only fields set by the user can be changed by them.
+func CheckDeprecations(specYAML string, t *translate.Translator) (Findings, error) {
+	spec := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(specYAML), &spec); err != nil {
+		return nil, fmt.Errorf("could not unmarshal spec: %s", err)
+	}
+	var out Findings
+	for _, d := range t.FindDeprecated(spec) {
+		msg := fmt.Sprintf("deprecated since %s", d.Version)
+		if d.Replacement != "" {
+			msg += fmt.Sprintf(", use %s instead or run mesh manifest migrate --upgrade-spec", d.Replacement)
+		}
+		out = append(out, &Finding{
+			Rule:     deprecatedRuleName,
+			Path:     d.Path,
+			Severity: SeverityWarning,
+			Message:  msg,
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/util"
+)
+
+// Severity is the severity of a semantic rule finding.
+type Severity string
+
+const (
+	// SeverityWarning is used for settings which are likely to be a mistake but still result in a working install.
+	SeverityWarning Severity = "WARNING"
+	// SeverityError is used for settings which result in a broken install or which are rejected by k8s.
+	SeverityError Severity = "ERROR"
+)
+
+// Finding is a violation of a semantic rule.
+type Finding struct {
+	// Rule is the name of the rule that produced the finding.
+	Rule string
+	// Path is the path of the offending field in the IstioControlPlaneSpec.
+	Path string
+	// Severity is the severity of the finding.
+	Severity Severity
+	// Message describes the problem.
+	Message string
+}
+
+// String implements the Stringer interface.
+func (f *Finding) String() string {
+	return fmt.Sprintf("%s %s: %s [%s]", f.Severity, f.Path, f.Message, f.Rule)
+}
+
+// Findings is a slice of Finding.
+type Findings []*Finding
+
+// Errors returns the findings in f with SeverityError.
+func (f Findings) Errors() Findings {
+	return f.withSeverity(SeverityError)
+}
+
+// Warnings returns the findings in f with SeverityWarning.
+func (f Findings) Warnings() Findings {
+	return f.withSeverity(SeverityWarning)
+}
+
+// String implements the Stringer interface.
+func (f Findings) String() string {
+	var out []string
+	for _, ff := range f {
+		out = append(out, ff.String())
+	}
+	return strings.Join(out, "\n")
+}
+
+func (f Findings) withSeverity(s Severity) Findings {
+	var out Findings
+	for _, ff := range f {
+		if ff.Severity == s {
+			out = append(out, ff)
+		}
+	}
+	return out
+}
+
+// SemanticRule checks a relationship between several fields of a merged IstioControlPlaneSpec which cannot be checked
+// by validating each field on its own.
+type SemanticRule struct {
+	// Name uniquely identifies the rule.
+	Name string
+	// Description is a short description of what the rule checks.
+	Description string
+	// Check returns a finding for each violation of the rule in icp, the merged spec. userICP is the spec supplied by
+	// the user before it is overlaid on its profile, for rules which only apply to settings the user chose. The Rule
+	// field of returned findings is set by CheckSemantics.
+	Check func(icp, userICP *v1alpha2.IstioControlPlaneSpec) Findings
+}
+
+var (
+	// semanticRulesMu protects semanticRules.
+	semanticRulesMu sync.RWMutex
+	// semanticRules is the list of registered semantic rules, in registration order.
+	semanticRules []*SemanticRule
+)
+
+// RegisterSemanticRule adds r to the rules run by CheckSemantics. It panics if a rule with the same name is already
+// registered.
+func RegisterSemanticRule(r *SemanticRule) {
+	semanticRulesMu.Lock()
+	defer semanticRulesMu.Unlock()
+	for _, rr := range semanticRules {
+		if rr.Name == r.Name {
+			panic("semantic rule " + r.Name + " is already registered")
+		}
+	}
+	semanticRules = append(semanticRules, r)
+}
+
+// SemanticRules returns the registered semantic rules, in registration order.
+func SemanticRules() []*SemanticRule {
+	semanticRulesMu.RLock()
+	defer semanticRulesMu.RUnlock()
+	return append([]*SemanticRule{}, semanticRules...)
+}
+
+// CheckSemantics runs all registered semantic rules over icp and returns the findings, sorted by path. icp is expected
+// to be the result of overlaying userICP, the user spec, over its profile, since most rules depend on the settings
+// inherited from the profile.
+func CheckSemantics(icp, userICP *v1alpha2.IstioControlPlaneSpec) Findings {
+	var out Findings
+	for _, r := range SemanticRules() {
+		for _, f := range r.Check(icp, userICP) {
+			f.Rule = r.Name
+			out = append(out, f)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Path < out[j].Path
+	})
+	return out
+}
+
+// k8sSpecs returns the KubernetesResourcesSpec of each component in icp, keyed by its path.
+func k8sSpecs(icp *v1alpha2.IstioControlPlaneSpec) map[string]*v1alpha2.KubernetesResourcesSpec {
+	out := make(map[string]*v1alpha2.KubernetesResourcesSpec)
+	collectK8sSpecs(reflect.ValueOf(icp), nil, out)
+	return out
+}
+
+func collectK8sSpecs(v reflect.Value, path util.Path, out map[string]*v1alpha2.KubernetesResourcesSpec) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		if k8s, ok := v.Interface().(*v1alpha2.KubernetesResourcesSpec); ok {
+			out[path.String()] = k8s
+			return
+		}
+		collectK8sSpecs(v.Elem(), path, out)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if a, ok := v.Type().Field(i).Tag.Lookup("json"); ok && a == "-" {
+				continue
+			}
+			collectK8sSpecs(v.Field(i), append(path, v.Type().Field(i).Name), out)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			collectK8sSpecs(v.Index(i), append(path, fmt.Sprint(i)), out)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/name"
+	"istio.io/operator/pkg/tpath"
+	"istio.io/operator/pkg/util"
+)
+
+func init() {
+	RegisterSemanticRule(&SemanticRule{
+		Name:        "citadel-required",
+		Description: "The node agent and SDS need a CA, which is Citadel unless another CA is configured.",
+		Check:       checkCitadelRequired,
+	})
+	RegisterSemanticRule(&SemanticRule{
+		Name:        "gateways-feature",
+		Description: "Gateways are only installed when the Gateways feature is enabled.",
+		Check:       checkGatewaysFeature,
+	})
+	RegisterSemanticRule(&SemanticRule{
+		Name:        "hpa-replicas",
+		Description: "HorizontalPodAutoscaler minReplicas must not be greater than maxReplicas.",
+		Check:       checkHPAReplicas,
+	})
+	RegisterSemanticRule(&SemanticRule{
+		Name:        "pdb-min-available",
+		Description: "A PodDisruptionBudget minAvailable at or above the replica count blocks rolling upgrades and node drains.",
+		Check:       checkPDBMinAvailable,
+	})
+	RegisterSemanticRule(&SemanticRule{
+		Name:        "gateway-ports",
+		Description: "Gateway service ports must be unique.",
+		Check:       checkGatewayPorts,
+	})
+}
+
+// checkCitadelRequired reports the node agent or SDS being enabled while Citadel is disabled.
+func checkCitadelRequired(icp, _ *v1alpha2.IstioControlPlaneSpec) Findings {
+	citadel, err := name.IsComponentEnabledInSpec(name.SecurityFeatureName, name.CitadelComponentName, icp)
+	if err != nil || citadel {
+		return nil
+	}
+	var out Findings
+	if na, err := name.IsComponentEnabledInSpec(name.SecurityFeatureName, name.NodeAgentComponentName, icp); err == nil && na {
+		out = append(out, &Finding{
+			Path:     "Security.Components.NodeAgent.Enabled",
+			Severity: SeverityWarning,
+			Message:  "node agent is enabled but Citadel is disabled, another CA must be configured for the node agent",
+		})
+	}
+	if sds, found, err := tpath.GetFromTreePath(icp.Values, util.PathFromString("global.sds.enabled")); err == nil && found && sds == true {
+		out = append(out, &Finding{
+			Path:     "Values.global.sds.enabled",
+			Severity: SeverityWarning,
+			Message:  "SDS is enabled but Citadel is disabled, another CA must be configured for SDS",
+		})
+	}
+	return out
+}
+
+// checkGatewaysFeature reports gateways which are enabled while the Gateways feature is disabled: the IngressGateway
+// and EgressGateway components, if the user enabled them rather than the profile, and the named gateways which are
+// listed.
+func checkGatewaysFeature(icp, userICP *v1alpha2.IstioControlPlaneSpec) Findings {
+	if fe := icp.GetGateways().GetEnabled(); fe == nil || fe.Value {
+		return nil
+	}
+	var out Findings
+	gcs := icp.GetGateways().GetComponents()
+	ugcs := userICP.GetGateways().GetComponents()
+	for _, gw := range []struct {
+		field   string
+		enabled *v1alpha2.BoolValueForPB
+	}{
+		{"IngressGateway", ugcs.GetIngressGateway().GetEnabled()},
+		{"EgressGateway", ugcs.GetEgressGateway().GetEnabled()},
+	} {
+		if gw.enabled == nil || !gw.enabled.Value {
+			continue
+		}
+		out = append(out, &Finding{
+			Path:     fmt.Sprintf("Gateways.Components.%s.Enabled", gw.field),
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("component %s is enabled but feature Gateways is disabled, so it will not be installed", gw.field),
+		})
+	}
+	for _, gws := range []struct {
+		field string
+		list  []*v1alpha2.GatewaySpec
+	}{
+		{"IngressGateways", gcs.GetIngressGateways()},
+		{"EgressGateways", gcs.GetEgressGateways()},
+	} {
+		for i, gw := range gws.list {
+			if gw.GetEnabled() != nil && !gw.GetEnabled().Value {
+				continue
+			}
+			out = append(out, &Finding{
+				Path:     fmt.Sprintf("Gateways.Components.%s.%d", gws.field, i),
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("gateway %s is listed but feature Gateways is disabled, so it will not be installed", gw.Name),
+			})
+		}
+	}
+	return out
+}
+
+// checkHPAReplicas reports HPA settings with minReplicas greater than maxReplicas.
+func checkHPAReplicas(icp, _ *v1alpha2.IstioControlPlaneSpec) Findings {
+	var out Findings
+	for path, k8s := range k8sSpecs(icp) {
+		hpa := k8s.HpaSpec
+		if hpa == nil || hpa.MinReplicas == nil || *hpa.MinReplicas <= hpa.MaxReplicas {
+			continue
+		}
+		out = append(out, &Finding{
+			Path:     path + ".HpaSpec.MinReplicas",
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("minReplicas %d is greater than maxReplicas %d", *hpa.MinReplicas, hpa.MaxReplicas),
+		})
+	}
+	return out
+}
+
+// checkPDBMinAvailable reports PDB settings with minAvailable greater than or equal to the minimum replica count, which
+// is the HPA minReplicas if set, or otherwise the replica count.
+func checkPDBMinAvailable(icp, _ *v1alpha2.IstioControlPlaneSpec) Findings {
+	var out Findings
+	for path, k8s := range k8sSpecs(icp) {
+		pdb := k8s.PodDisruptionBudget
+		if pdb == nil || pdb.MinAvailable == 0 {
+			continue
+		}
+		replicas, from := int64(k8s.ReplicaCount), "replicaCount"
+		if k8s.HpaSpec != nil && k8s.HpaSpec.MinReplicas != nil {
+			replicas, from = int64(*k8s.HpaSpec.MinReplicas), "HPA minReplicas"
+		}
+		if replicas == 0 || int64(pdb.MinAvailable) < replicas {
+			continue
+		}
+		out = append(out, &Finding{
+			Path:     path + ".PodDisruptionBudget.MinAvailable",
+			Severity: SeverityWarning,
+			Message: fmt.Sprintf("minAvailable %d is not less than %s %d, so no pod can be evicted during rolling upgrades "+
+				"or node drains", pdb.MinAvailable, from, replicas),
+		})
+	}
+	return out
+}
+
+// checkGatewayPorts reports duplicate port numbers or names in the gateway service settings and gateway values.
+func checkGatewayPorts(icp, _ *v1alpha2.IstioControlPlaneSpec) Findings {
+	var out Findings
+	for path, k8s := range k8sSpecs(icp) {
+		if !strings.HasPrefix(path, string(name.GatewayFeatureName)+".") || k8s.Service == nil {
+			continue
+		}
+		var ports []map[string]interface{}
+		for _, p := range k8s.Service.Ports {
+			ports = append(ports, map[string]interface{}{"port": p.Port, "name": p.Name})
+		}
+		out = append(out, duplicatePorts(path+".Service.Ports", ports)...)
+	}
+
+	gws, ok := icp.Values["gateways"].(map[string]interface{})
+	if !ok {
+		return out
+	}
+	for gn, gv := range gws {
+		gm, ok := gv.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pl, ok := gm["ports"].([]interface{})
+		if !ok {
+			continue
+		}
+		var ports []map[string]interface{}
+		for _, p := range pl {
+			if pm, ok := p.(map[string]interface{}); ok {
+				ports = append(ports, pm)
+			}
+		}
+		out = append(out, duplicatePorts(fmt.Sprintf("Values.gateways.%s.ports", gn), ports)...)
+	}
+	return out
+}
+
+// duplicatePorts returns a finding for each entry in ports, found at path, which has the same port number or name
+// as an earlier entry.
+func duplicatePorts(path string, ports []map[string]interface{}) Findings {
+	var out Findings
+	seen := make(map[string]bool)
+	for i, p := range ports {
+		for _, key := range []string{"port", "name"} {
+			v, ok := p[key]
+			if !ok || v == nil || v == "" {
+				continue
+			}
+			k := key + "=" + fmt.Sprint(v)
+			if seen[k] {
+				out = append(out, &Finding{
+					Path:     fmt.Sprintf("%s.%d", path, i),
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("duplicate port %s %v", key, v),
+				})
+			}
+			seen[k] = true
+		}
+	}
+	return out
+}
@@ -0,0 +1,263 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/kr/pretty"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/helm"
+	"istio.io/operator/pkg/manifest"
+	"istio.io/operator/pkg/translate"
+	"istio.io/operator/pkg/util"
+	"istio.io/operator/pkg/version"
+)
+
+func TestCheckSemantics(t *testing.T) {
+	tests := []struct {
+		desc    string
+		yamlStr string
+		// userYAML is the user spec, if it is not yamlStr.
+		userYAML string
+		want     []string
+	}{
+		{
+			desc: "NoFindings",
+			yamlStr: `
+security:
+  enabled: true
+  components:
+    citadel:
+      enabled: true
+    nodeAgent:
+      enabled: true
+trafficManagement:
+  enabled: true
+  components:
+    pilot:
+      k8s:
+        replicaCount: 3
+        podDisruptionBudget:
+          minAvailable: 2
+        hpaSpec:
+          minReplicas: 3
+          maxReplicas: 5
+`,
+		},
+		{
+			desc: "NodeAgentAndSDSWithoutCitadel",
+			yamlStr: `
+security:
+  enabled: true
+  components:
+    citadel:
+      enabled: false
+    nodeAgent:
+      enabled: true
+values:
+  global:
+    sds:
+      enabled: true
+`,
+			want: []string{
+				"WARNING Security.Components.NodeAgent.Enabled: node agent is enabled but Citadel is disabled, another CA must " +
+					"be configured for the node agent [citadel-required]",
+				"WARNING Values.global.sds.enabled: SDS is enabled but Citadel is disabled, another CA must be configured for " +
+					"SDS [citadel-required]",
+			},
+		},
+		{
+			desc: "GatewayEnabledFeatureDisabled",
+			yamlStr: `
+gateways:
+  enabled: false
+  components:
+    ingressGateway:
+      enabled: true
+    egressGateway:
+      enabled: false
+    ingressGateways:
+    - name: disabled-ingressgateway
+      enabled: false
+    - name: internal-ingressgateway
+    egressGateways:
+    - name: internal-egressgateway
+`,
+			want: []string{
+				"WARNING Gateways.Components.EgressGateways.0: gateway internal-egressgateway is listed but feature Gateways " +
+					"is disabled, so it will not be installed [gateways-feature]",
+				"WARNING Gateways.Components.IngressGateway.Enabled: component IngressGateway is enabled but feature Gateways " +
+					"is disabled, so it will not be installed [gateways-feature]",
+				"WARNING Gateways.Components.IngressGateways.1: gateway internal-ingressgateway is listed but feature Gateways " +
+					"is disabled, so it will not be installed [gateways-feature]",
+			},
+		},
+		{
+			desc: "GatewayEnabledByProfileFeatureDisabled",
+			yamlStr: `
+gateways:
+  enabled: false
+  components:
+    ingressGateway:
+      enabled: true
+`,
+			userYAML: `
+gateways:
+  enabled: false
+`,
+		},
+		{
+			desc: "HPAAndPDB",
+			yamlStr: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        podDisruptionBudget:
+          minAvailable: 2
+        hpaSpec:
+          minReplicas: 5
+          maxReplicas: 3
+policy:
+  components:
+    policy:
+      k8s:
+        replicaCount: 1
+        podDisruptionBudget:
+          minAvailable: 1
+`,
+			want: []string{
+				"WARNING Policy.Components.Policy.K8S.PodDisruptionBudget.MinAvailable: minAvailable 1 is not less than " +
+					"replicaCount 1, so no pod can be evicted during rolling upgrades or node drains [pdb-min-available]",
+				"ERROR TrafficManagement.Components.Pilot.K8S.HpaSpec.MinReplicas: minReplicas 5 is greater than maxReplicas 3 " +
+					"[hpa-replicas]",
+			},
+		},
+		{
+			desc: "DuplicateGatewayPorts",
+			yamlStr: `
+gateways:
+  components:
+    ingressGateways:
+    - name: internal-ingressgateway
+      k8s:
+        service:
+          ports:
+          - name: http2
+            port: 80
+          - name: https
+            port: 80
+values:
+  gateways:
+    istio-ingressgateway:
+      ports:
+      - name: http2
+        port: 80
+      - name: http2
+        port: 8080
+`,
+			want: []string{
+				"ERROR Gateways.Components.IngressGateways.0.K8S.Service.Ports.1: duplicate port port 80 [gateway-ports]",
+				"ERROR Values.gateways.istio-ingressgateway.ports.1: duplicate port name http2 [gateway-ports]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			icp := &v1alpha2.IstioControlPlaneSpec{}
+			if err := util.UnmarshalWithJSONPB(tt.yamlStr, icp); err != nil {
+				t.Fatal(err)
+			}
+			userICP := icp
+			if tt.userYAML != "" {
+				userICP = &v1alpha2.IstioControlPlaneSpec{}
+				if err := util.UnmarshalWithJSONPB(tt.userYAML, userICP); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var got []string
+			for _, f := range CheckSemantics(icp, userICP) {
+				got = append(got, f.String())
+			}
+			if diff := pretty.Diff(got, tt.want); len(diff) != 0 {
+				t.Errorf("CheckSemantics(%s): got:\n%s\nwant:\n%s", tt.desc, pretty.Sprint(got), pretty.Sprint(tt.want))
+			}
+		})
+	}
+}
+
+func TestCheckSemanticsBuiltinProfiles(t *testing.T) {
+	defaultYAML, err := helm.ReadProfileYAML("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range helm.ListBuiltinProfiles() {
+		t.Run(p, func(t *testing.T) {
+			py, err := helm.ReadProfileYAML(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			merged, err := util.OverlayYAML(defaultYAML, py)
+			if err != nil {
+				t.Fatal(err)
+			}
+			icp, _, err := manifest.ParseK8SYAMLToIstioControlPlaneSpec(merged)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The user only selects the profile.
+			if f := CheckSemantics(icp, &v1alpha2.IstioControlPlaneSpec{Profile: p}); len(f) != 0 {
+				t.Errorf("profile %s has semantic findings:\n%s", p, f)
+			}
+		})
+	}
+}
+
+func TestCheckDeprecations(t *testing.T) {
+	tr, err := translate.NewTranslator(version.NewMinorVersion(1, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	specYAML := `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        replicaCount: 2
+values:
+  pilot:
+    replicaCount: 3
+  global:
+    priorityClassName: system-cluster-critical
+`
+	want := []string{
+		"WARNING values.pilot.replicaCount: deprecated since 1.4, use trafficManagement.components.pilot.k8s.replicaCount " +
+			"instead or run mesh manifest migrate --upgrade-spec [deprecated-field]",
+		"WARNING values.global.priorityClassName: deprecated since 1.4 [deprecated-field]",
+	}
+	findings, err := CheckDeprecations(specYAML, tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.String())
+	}
+	if diff := pretty.Diff(got, want); len(diff) != 0 {
+		t.Errorf("CheckDeprecations: got:\n%s\nwant:\n%s", pretty.Sprint(got), pretty.Sprint(want))
+	}
+}
@@ -19,7 +19,10 @@ import (
 	"net/url"
 	"reflect"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
 	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/dependency"
 	"istio.io/operator/pkg/util"
 )
 
@@ -40,11 +43,26 @@ var (
 
 // CheckIstioControlPlaneSpec validates the values in the given Installer spec, using the field map defaultValidations to
 // call the appropriate validation function.
+// Errors about a field are PathErrors with the YAML path of the field.
 func CheckIstioControlPlaneSpec(is *v1alpha2.IstioControlPlaneSpec, checkRequired bool) (errs util.Errors) {
-	errs = CheckValues(is.Values)
+	errs = util.PrefixPaths(util.Path{"values"}, CheckValues(is.Values))
+	if err := dependency.Validate(is.GetComponentDependencies()); err != nil {
+		errs = util.AppendErrs(errs, withYAMLPath(util.Path{"ComponentDependencies"}, util.NewErrs(err)))
+	}
+	for i, k := range is.GetRetainKinds() {
+		if gk := schema.ParseGroupKind(k); gk.Kind == "" {
+			errs = util.AppendErrs(errs, withYAMLPath(util.Path{"RetainKinds", fmt.Sprint(i)},
+				util.NewErrs(fmt.Errorf("invalid value RetainKinds.%d: %q (must be Kind or Kind.group)", i, k))))
+		}
+	}
 	return util.AppendErrs(errs, validate(defaultValidations, is, nil, checkRequired))
 }
 
+// withYAMLPath returns errs as PathErrors for the IstioControlPlaneSpec field at path, which is made of Go field names.
+func withYAMLPath(path util.Path, errs util.Errors) util.Errors {
+	return util.WithPath(util.ToJSONPath(reflect.TypeOf(v1alpha2.IstioControlPlaneSpec{}), path), errs)
+}
+
 func validate(validations map[string]ValidatorFunc, structPtr interface{}, path util.Path, checkRequired bool) (errs util.Errors) {
 	scope.Debugf("validate with path %s, %v (%T)", path, structPtr, structPtr)
 	if structPtr == nil {
@@ -66,6 +84,13 @@ func validate(validations map[string]ValidatorFunc, structPtr interface{}, path
 		return
 	}
 
+	if k8s, ok := structPtr.(*v1alpha2.KubernetesResourcesSpec); ok {
+		errs = util.AppendErrs(errs, withYAMLPath(path, validateK8SResourcesSpec(path, k8s)))
+	}
+	if gc, ok := structPtr.(*v1alpha2.GatewayFeatureSpec_Components); ok {
+		errs = util.AppendErrs(errs, withYAMLPath(path, validateGateways(path, gc)))
+	}
+
 	for i := 0; i < structElems.NumField(); i++ {
 		fieldName := structElems.Type().Field(i).Name
 		fieldValue := structElems.Field(i)
@@ -86,7 +111,11 @@ func validate(validations map[string]ValidatorFunc, structPtr interface{}, path
 			}
 		case reflect.Slice:
 			for i := 0; i < fieldValue.Len(); i++ {
-				errs = util.AppendErrs(errs, validate(validations, fieldValue.Index(i).Interface(), path, checkRequired))
+				// Scalar lists and free form trees like extra objects have no defined validations.
+				if !util.IsPtr(fieldValue.Index(i).Interface()) {
+					continue
+				}
+				errs = util.AppendErrs(errs, validate(validations, fieldValue.Index(i).Interface(), append(path, fieldName, fmt.Sprint(i)), checkRequired))
 			}
 		case reflect.Ptr:
 			if util.IsNilOrInvalidValue(fieldValue.Elem()) {
@@ -112,7 +141,7 @@ func validateLeaf(validations map[string]ValidatorFunc, path util.Path, val inte
 	msg := fmt.Sprintf("validate %s:%v(%T) ", pstr, val, val)
 	if util.IsValueNil(val) || util.IsEmptyString(val) {
 		if checkRequired && requiredValues[pstr] {
-			return util.NewErrs(fmt.Errorf("field %s is required but not set", util.ToYAMLPathString(pstr)))
+			return withYAMLPath(path, util.NewErrs(fmt.Errorf("field %s is required but not set", util.ToYAMLPathString(pstr))))
 		}
 		msg += fmt.Sprintf("validate %s: OK (empty value)", pstr)
 		scope.Debug(msg)
@@ -127,7 +156,7 @@ func validateLeaf(validations map[string]ValidatorFunc, path util.Path, val inte
 		return nil
 	}
 	scope.Debug(msg)
-	return vf(path, val)
+	return withYAMLPath(path, vf(path, val))
 }
 
 func validateHub(path util.Path, val interface{}) util.Errors {
@@ -0,0 +1,162 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"istio.io/operator/pkg/apis/istio/v1alpha2"
+	"istio.io/operator/pkg/name"
+	"istio.io/operator/pkg/util"
+)
+
+// validateK8SResourcesSpec checks that the k8s settings in spec, found at path, are valid values for the
+// corresponding k8s types.
+func validateK8SResourcesSpec(path util.Path, spec *v1alpha2.KubernetesResourcesSpec) (errs util.Errors) {
+	if spec.ServiceAccountName != "" {
+		errs = util.AppendErrs(errs, validateK8SName(append(path, "ServiceAccountName"), spec.ServiceAccountName))
+	}
+	for i, s := range spec.ImagePullSecrets {
+		errs = util.AppendErrs(errs, validateK8SName(append(path, "ImagePullSecrets", fmt.Sprint(i), "Name"), s.Name))
+	}
+	if spec.SecurityContext != nil {
+		errs = util.AppendErrs(errs, validateSecurityContext(append(path, "SecurityContext"), spec.SecurityContext))
+	}
+	if spec.PodSecurityContext != nil {
+		errs = util.AppendErrs(errs, validatePodSecurityContext(append(path, "PodSecurityContext"), spec.PodSecurityContext))
+	}
+	for i, c := range spec.TopologySpreadConstraints {
+		errs = util.AppendErrs(errs, validateTopologySpreadConstraint(append(path, "TopologySpreadConstraints", fmt.Sprint(i)), c))
+	}
+	cns := make([]string, 0, len(spec.Containers))
+	for cn := range spec.Containers {
+		cns = append(cns, cn)
+	}
+	sort.Strings(cns)
+	for _, cn := range cns {
+		errs = util.AppendErrs(errs, validateK8SName(append(path, "Containers", cn), cn))
+		if sc := spec.Containers[cn].GetSecurityContext(); sc != nil {
+			errs = util.AppendErrs(errs, validateSecurityContext(append(path, "Containers", cn, "SecurityContext"), sc))
+		}
+	}
+	return errs
+}
+
+// validateGateways checks that the named gateways in gc have valid and unique names.
+func validateGateways(path util.Path, gc *v1alpha2.GatewayFeatureSpec_Components) (errs util.Errors) {
+	seen := make(map[string]bool)
+	for _, gws := range []struct {
+		field string
+		base  name.ComponentName
+		list  []*v1alpha2.GatewaySpec
+	}{
+		{"IngressGateways", name.IngressComponentName, gc.IngressGateways},
+		{"EgressGateways", name.EgressComponentName, gc.EgressGateways},
+	} {
+		for i, gw := range gws.list {
+			gwp := append(path, gws.field, fmt.Sprint(i))
+			np := append(gwp, "Name")
+			switch {
+			case gw.Name == "":
+				// The name is not set, so the error is about the gateway.
+				errs = util.AppendErrs(errs, withYAMLPath(gwp, util.NewErrs(fmt.Errorf("invalid value %s: gateway name is required", np))))
+			case seen[gw.Name]:
+				errs = util.AppendErrs(errs, withYAMLPath(np, util.NewErrs(fmt.Errorf("invalid value %s: %s (gateway names must be unique)", np, gw.Name))))
+			default:
+				errs = util.AppendErrs(errs, validationErrs(np, gw.Name, validation.IsDNS1123Label(gw.Name)))
+				// The gateway component name is the value of the component label on the gateway resources.
+				cn := string(name.GatewayComponentName(gws.base, gw.Name))
+				errs = util.AppendErrs(errs, validationErrs(np, gw.Name, validation.IsValidLabelValue(cn)))
+			}
+			seen[gw.Name] = true
+		}
+	}
+	return errs
+}
+
+// validateTopologySpreadConstraint checks c using the same rules as k8s applies to a pod topologySpreadConstraint.
+func validateTopologySpreadConstraint(path util.Path, c *v1alpha2.TopologySpreadConstraint) (errs util.Errors) {
+	if c.MaxSkew <= 0 {
+		errs = util.AppendErr(errs, fmt.Errorf("invalid value %s: %d (must be greater than zero)", append(path, "MaxSkew"), c.MaxSkew))
+	}
+	if c.TopologyKey == "" {
+		errs = util.AppendErr(errs, fmt.Errorf("invalid value %s: topologyKey is required", path))
+	}
+	if c.WhenUnsatisfiable != "DoNotSchedule" && c.WhenUnsatisfiable != "ScheduleAnyway" {
+		errs = util.AppendErr(errs, fmt.Errorf("invalid value %s: %q (must be DoNotSchedule or ScheduleAnyway)",
+			append(path, "WhenUnsatisfiable"), c.WhenUnsatisfiable))
+	}
+	return errs
+}
+
+// validateK8SName checks that name is a valid k8s object name.
+func validateK8SName(path util.Path, name string) util.Errors {
+	return validationErrs(path, name, validation.IsDNS1123Subdomain(name))
+}
+
+// validateSecurityContext checks sc using the same rules as k8s applies to a container securityContext.
+func validateSecurityContext(path util.Path, sc *v1.SecurityContext) (errs util.Errors) {
+	if sc.RunAsUser != nil {
+		errs = util.AppendErrs(errs, validationErrs(append(path, "RunAsUser"), *sc.RunAsUser, validation.IsValidUserID(*sc.RunAsUser)))
+	}
+	if sc.RunAsGroup != nil {
+		errs = util.AppendErrs(errs, validationErrs(append(path, "RunAsGroup"), *sc.RunAsGroup, validation.IsValidGroupID(*sc.RunAsGroup)))
+	}
+	if sc.Privileged != nil && *sc.Privileged && sc.AllowPrivilegeEscalation != nil && !*sc.AllowPrivilegeEscalation {
+		errs = util.AppendErr(errs, fmt.Errorf("invalid value %s: cannot set AllowPrivilegeEscalation to false and Privileged to true",
+			path))
+	}
+	if sc.ProcMount != nil && *sc.ProcMount != v1.DefaultProcMount && *sc.ProcMount != v1.UnmaskedProcMount {
+		errs = util.AppendErr(errs, fmt.Errorf("invalid value %s: %s, must be %s or %s",
+			append(path, "ProcMount"), *sc.ProcMount, v1.DefaultProcMount, v1.UnmaskedProcMount))
+	}
+	return errs
+}
+
+// validatePodSecurityContext checks psc using the same rules as k8s applies to a pod securityContext.
+func validatePodSecurityContext(path util.Path, psc *v1.PodSecurityContext) (errs util.Errors) {
+	if psc.RunAsUser != nil {
+		errs = util.AppendErrs(errs, validationErrs(append(path, "RunAsUser"), *psc.RunAsUser, validation.IsValidUserID(*psc.RunAsUser)))
+	}
+	if psc.RunAsGroup != nil {
+		errs = util.AppendErrs(errs, validationErrs(append(path, "RunAsGroup"), *psc.RunAsGroup, validation.IsValidGroupID(*psc.RunAsGroup)))
+	}
+	if psc.FSGroup != nil {
+		errs = util.AppendErrs(errs, validationErrs(append(path, "FSGroup"), *psc.FSGroup, validation.IsValidGroupID(*psc.FSGroup)))
+	}
+	for i, g := range psc.SupplementalGroups {
+		errs = util.AppendErrs(errs, validationErrs(append(path, "SupplementalGroups", fmt.Sprint(i)), g, validation.IsValidGroupID(g)))
+	}
+	for i, s := range psc.Sysctls {
+		if s.Name == "" {
+			errs = util.AppendErr(errs, fmt.Errorf("invalid value %s: sysctl name is required",
+				append(path, "Sysctls", fmt.Sprint(i))))
+		}
+	}
+	return errs
+}
+
+// validationErrs converts msgs, the output of a k8s validation function for val at path, to Errors.
+func validationErrs(path util.Path, val interface{}, msgs []string) util.Errors {
+	if len(msgs) == 0 {
+		return nil
+	}
+	return withYAMLPath(path, util.NewErrs(fmt.Errorf("invalid value %s: %v (%s)", path, val, strings.Join(msgs, ", "))))
+}
@@ -98,6 +98,147 @@ values:
 
 `,
 		},
+		{
+			desc: "ExtraAndRemoveObjects",
+			yamlStr: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        extraObjects:
+        - apiVersion: networking.k8s.io/v1
+          kind: NetworkPolicy
+          metadata:
+            name: istio-pilot
+        removeObjects:
+        - kind: HorizontalPodAutoscaler
+          name: istio-pilot
+`,
+		},
+		{
+			desc: "SecurityContexts",
+			yamlStr: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        securityContext:
+          runAsUser: 1337
+          runAsNonRoot: true
+          allowPrivilegeEscalation: false
+          capabilities:
+            drop:
+            - ALL
+        podSecurityContext:
+          fsGroup: 1337
+          supplementalGroups:
+          - 1338
+        imagePullSecrets:
+        - name: registry-creds
+        serviceAccountName: istio-pilot-psp
+`,
+		},
+		{
+			desc: "BadSecurityContexts",
+			yamlStr: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        securityContext:
+          runAsUser: -1
+          privileged: true
+          allowPrivilegeEscalation: false
+        podSecurityContext:
+          fsGroup: -2
+        imagePullSecrets:
+        - name: Registry_Creds
+        serviceAccountName: istio.pilot.
+`,
+			wantErrs: makeErrors([]string{
+				`invalid value TrafficManagement.Components.Pilot.K8S.ServiceAccountName: istio.pilot. (a DNS-1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*'))`,
+				`invalid value TrafficManagement.Components.Pilot.K8S.ImagePullSecrets.0.Name: Registry_Creds (a DNS-1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*'))`,
+				`invalid value TrafficManagement.Components.Pilot.K8S.SecurityContext.RunAsUser: -1 (must be between 0 and 2147483647, inclusive)`,
+				`invalid value TrafficManagement.Components.Pilot.K8S.SecurityContext: cannot set AllowPrivilegeEscalation to false and Privileged to true`,
+				`invalid value TrafficManagement.Components.Pilot.K8S.PodSecurityContext.FSGroup: -2 (must be between 0 and 2147483647, inclusive)`,
+			}),
+		},
+		{
+			desc: "BadTopologySpreadConstraints",
+			yamlStr: `
+gateways:
+  components:
+    ingressGateway:
+      k8s:
+        topologySpreadConstraints:
+        - maxSkew: 0
+          topologyKey: topology.kubernetes.io/zone
+          whenUnsatisfiable: Sometimes
+`,
+			wantErrs: makeErrors([]string{
+				`invalid value Gateways.Components.IngressGateway.K8S.TopologySpreadConstraints.0.MaxSkew: 0 (must be greater than zero)`,
+				`invalid value Gateways.Components.IngressGateway.K8S.TopologySpreadConstraints.0.WhenUnsatisfiable: "Sometimes" (must be DoNotSchedule or ScheduleAnyway)`,
+			}),
+		},
+		{
+			desc: "BadContainerSettings",
+			yamlStr: `
+gateways:
+  components:
+    ingressGateway:
+      k8s:
+        containers:
+          istio-proxy:
+            securityContext:
+              runAsUser: -1
+          Bad_Name:
+            imagePullPolicy: Always
+`,
+			wantErrs: makeErrors([]string{
+				`invalid value Gateways.Components.IngressGateway.K8S.Containers.Bad_Name: Bad_Name (a DNS-1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*'))`,
+				`invalid value Gateways.Components.IngressGateway.K8S.Containers.istio-proxy.SecurityContext.RunAsUser: -1 (must be between 0 and 2147483647, inclusive)`,
+			}),
+		},
+		{
+			desc: "NamedGateways",
+			yamlStr: `
+gateways:
+  components:
+    ingressGateways:
+    - name: public-ingressgateway
+      k8s:
+        service:
+          type: LoadBalancer
+    - name: internal-ingressgateway
+      namespace: istio-internal
+      label:
+        istio: internal-ingressgateway
+    egressGateways:
+    - name: partner-egressgateway
+`,
+		},
+		{
+			desc: "BadNamedGateways",
+			yamlStr: `
+gateways:
+  components:
+    ingressGateways:
+    - name: public-ingressgateway
+    - namespace: istio-internal
+    - name: Bad_Gateway
+    egressGateways:
+    - name: public-ingressgateway
+      k8s:
+        securityContext:
+          runAsUser: -1
+`,
+			wantErrs: makeErrors([]string{
+				`invalid value Gateways.Components.IngressGateways.1.Name: gateway name is required`,
+				`invalid value Gateways.Components.IngressGateways.2.Name: Bad_Gateway (a DNS-1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?'))`,
+				`invalid value Gateways.Components.EgressGateways.0.Name: public-ingressgateway (gateway names must be unique)`,
+				`invalid value Gateways.Components.EgressGateways.0.K8S.SecurityContext.RunAsUser: -1 (must be between 0 and 2147483647, inclusive)`,
+			}),
+		},
 		{
 			desc: "BadTag",
 			yamlStr: `
@@ -112,6 +253,23 @@ hub: docker.io:tag/istio
 `,
 			wantErrs: makeErrors([]string{`invalid value Hub: docker.io:tag/istio`}),
 		},
+		{
+			desc: "GoodDeletionPolicy",
+			yamlStr: `
+deletionPolicy: RetainCRDs
+retainKinds:
+- Namespace
+- ValidatingWebhookConfiguration.admissionregistration.k8s.io
+`,
+		},
+		{
+			desc: "BadRetainKinds",
+			yamlStr: `
+retainKinds:
+- .istio.io
+`,
+			wantErrs: makeErrors([]string{`invalid value RetainKinds.0: ".istio.io" (must be Kind or Kind.group)`}),
+		},
 		{
 			desc: "GoodURL",
 			yamlStr: `
@@ -154,3 +312,90 @@ values:
 		})
 	}
 }
+
+func TestValidateErrorPaths(t *testing.T) {
+	tests := []struct {
+		desc     string
+		yamlStr  string
+		wantPath string
+	}{
+		{
+			desc: "spec field",
+			yamlStr: `
+hub: docker.io:tag/istio
+`,
+			wantPath: "hub",
+		},
+		{
+			desc: "k8s field",
+			yamlStr: `
+trafficManagement:
+  components:
+    pilot:
+      k8s:
+        securityContext:
+          runAsUser: -1
+`,
+			wantPath: "trafficManagement.components.pilot.k8s.securityContext.runAsUser",
+		},
+		{
+			desc: "gateway without name",
+			yamlStr: `
+gateways:
+  components:
+    ingressGateways:
+    - namespace: istio-internal
+`,
+			wantPath: "gateways.components.ingressGateways.[0]",
+		},
+		{
+			desc: "values",
+			yamlStr: `
+values:
+  global:
+    proxy:
+      includeIPRanges: "1.1.0.300/16"
+`,
+			wantPath: "values.global.proxy.includeIPRanges",
+		},
+		{
+			desc: "unknown values field",
+			yamlStr: `
+values:
+  global:
+    proxy:
+      foo: bar
+`,
+			wantPath: "values.global.proxy.foo",
+		},
+		{
+			desc: "component dependency cycle",
+			yamlStr: `
+componentDependencies:
+  Pilot: Galley
+  Galley: Pilot
+`,
+			wantPath: "componentDependencies",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ispec := &v1alpha2.IstioControlPlaneSpec{}
+			if err := util.UnmarshalWithJSONPB(tt.yamlStr, ispec); err != nil {
+				t.Fatalf("unmarshalWithJSONPB(%s): got error %s", tt.desc, err)
+			}
+			errs := CheckIstioControlPlaneSpec(ispec, false)
+			if len(errs) != 1 {
+				t.Fatalf("%s: got errors %s, want one error", tt.desc, errs)
+			}
+			pe, ok := errs[0].(*util.PathError)
+			if !ok {
+				t.Fatalf("%s: got %T, want *util.PathError", tt.desc, errs[0])
+			}
+			if got := pe.Path.String(); got != tt.wantPath {
+				t.Errorf("%s: got path %s, want %s", tt.desc, got, tt.wantPath)
+			}
+		})
+	}
+}
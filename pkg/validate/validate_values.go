@@ -49,7 +49,7 @@ func validateValues(node interface{}, path util.Path) (errs util.Errors) {
 	scope.Debugf("validateValues %s", pstr)
 	vf := defaultValuesValidations[pstr]
 	if vf != nil {
-		errs = util.AppendErrs(errs, vf(path, node))
+		errs = util.AppendErrs(errs, util.WithPath(path, vf(path, node)))
 	}
 
 	nn, ok := node.(map[string]interface{})
@@ -130,7 +130,7 @@ global:
   proxy:
     foo: "bar"
 `,
-			wantErrs: makeErrors([]string{`unknown field "foo" in v1alpha1.ProxyConfig`}),
+			wantErrs: makeErrors([]string{`unknown field global.proxy.foo`}),
 		},
 		{
 			desc: "unknown field",
@@ -138,7 +138,16 @@ global:
 cni:
   foo: "bar"
 `,
-			wantErrs: makeErrors([]string{`unknown field "foo" in v1alpha1.CNIConfig`}),
+			wantErrs: makeErrors([]string{`unknown field cni.foo`}),
+		},
+		{
+			desc: "misspelled field",
+			yamlStr: `
+global:
+  proxy:
+    includeInboundPort: "111"
+`,
+			wantErrs: makeErrors([]string{`unknown field global.proxy.includeInboundPort; did you mean includeInboundPorts?`}),
 		},
 	}
 
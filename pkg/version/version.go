@@ -31,6 +31,42 @@ type CompatibilityMapping struct {
 	RecommendedIstioVersions goversion.Constraints `json:"recommendedIstioVersions,omitempty"`
 }
 
+// FindCompatibilityMapping returns the mapping for operatorVersion in b, a YAML formatted list of mappings such as
+// data/versions.yaml.
+func FindCompatibilityMapping(b []byte, operatorVersion *goversion.Version) (*CompatibilityMapping, error) {
+	var mappings []*CompatibilityMapping
+	if err := yaml.Unmarshal(b, &mappings); err != nil {
+		return nil, err
+	}
+	for _, m := range mappings {
+		if m.OperatorVersion.Equal(operatorVersion) {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("this operator version %s was not found in the version map", operatorVersion.String())
+}
+
+// CheckUpgrade returns an error if upgrading from version from to version to is not supported according to the
+// mapping for version to in b, a YAML formatted list of mappings such as data/versions.yaml.
+func CheckUpgrade(b []byte, from, to string) error {
+	toVersion, err := goversion.NewVersion(to)
+	if err != nil {
+		return fmt.Errorf("failed to parse the target version: %v", to)
+	}
+	mapping, err := FindCompatibilityMapping(b, toVersion)
+	if err != nil {
+		return err
+	}
+	fromVersion, err := goversion.NewVersion(from)
+	if err != nil {
+		return fmt.Errorf("failed to parse the current version: %v, error: %v", from, err)
+	}
+	if !mapping.SupportedIstioVersions.Check(fromVersion) {
+		return fmt.Errorf("upgrade is currently not supported: %v -> %v", from, to)
+	}
+	return nil
+}
+
 // NewVersionFromString creates a new Version from the provided SemVer formatted string and returns a pointer to it.
 func NewVersionFromString(s string) (*Version, error) {
 	ver, err := goversion.NewVersion(s)
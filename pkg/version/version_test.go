@@ -177,6 +177,64 @@ badField: ">= 1, < 1.4"
 
 }
 
+func TestCheckUpgrade(t *testing.T) {
+	versions := `
+- operatorVersion: 1.3.0
+  supportedIstioVersions: 1.3.0
+- operatorVersion: 1.4.0
+  supportedIstioVersions: ">=1.3.3, <1.6"
+`
+	tests := []struct {
+		desc    string
+		from    string
+		to      string
+		wantErr string
+	}{
+		{
+			desc: "supported",
+			from: "1.3.5",
+			to:   "1.4.0",
+		},
+		{
+			desc: "same version",
+			from: "1.3.0",
+			to:   "1.3.0",
+		},
+		{
+			desc:    "unsupported",
+			from:    "1.3.0",
+			to:      "1.4.0",
+			wantErr: "upgrade is currently not supported: 1.3.0 -> 1.4.0",
+		},
+		{
+			desc:    "unknown target",
+			from:    "1.3.0",
+			to:      "1.5.0",
+			wantErr: "this operator version 1.5.0 was not found in the version map",
+		},
+		{
+			desc:    "bad target",
+			from:    "1.3.0",
+			to:      "latest",
+			wantErr: "failed to parse the target version: latest",
+		},
+		{
+			desc:    "bad source",
+			from:    "latest",
+			to:      "1.4.0",
+			wantErr: "failed to parse the current version: latest, error: Malformed version: latest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := CheckUpgrade([]byte(versions), tt.from, tt.to)
+			if gotErr, wantErr := errToString(err), tt.wantErr; gotErr != wantErr {
+				t.Errorf("CheckUpgrade(%s, %s): got error: %s, want error: %s", tt.from, tt.to, gotErr, wantErr)
+			}
+		})
+	}
+}
+
 // errToString returns the string representation of err and the empty string if
 // err is nil.
 func errToString(err error) string {
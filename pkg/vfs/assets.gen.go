@@ -38130,10 +38130,47 @@ kubernetesMapping:
     outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.replicas"
   "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.Resources":
     outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].resources"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.Service":
+    outPath: "[Service:{{.ResourceName}}].spec"
   "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.Strategy":
     outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.strategy"
   "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.Tolerations":
     outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.tolerations"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.SecurityContext":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].securityContext"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.PodSecurityContext":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.securityContext"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.ImagePullSecrets":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.imagePullSecrets"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.ServiceAccountName":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.serviceAccountName"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.LivenessProbe":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].livenessProbe"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.StartupProbe":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].startupProbe"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.Lifecycle":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].lifecycle"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.TerminationGracePeriodSeconds":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.terminationGracePeriodSeconds"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.TopologySpreadConstraints":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.topologySpreadConstraints"
+containerMapping:
+  Resources:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].resources"
+  Env:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].env"
+  ImagePullPolicy:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].imagePullPolicy"
+  ReadinessProbe:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].readinessProbe"
+  LivenessProbe:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].livenessProbe"
+  StartupProbe:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].startupProbe"
+  SecurityContext:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].securityContext"
+  Lifecycle:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].lifecycle"
 toFeature:
     crds:               Base
     Pilot:              TrafficManagement
@@ -38357,10 +38394,47 @@ kubernetesMapping:
     outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.replicas"
   "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.Resources":
     outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].resources"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.Service":
+    outPath: "[Service:{{.ResourceName}}].spec"
   "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.Strategy":
     outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.strategy"
   "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.Tolerations":
     outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.tolerations"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.SecurityContext":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].securityContext"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.PodSecurityContext":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.securityContext"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.ImagePullSecrets":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.imagePullSecrets"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.ServiceAccountName":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.serviceAccountName"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.LivenessProbe":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].livenessProbe"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.StartupProbe":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].startupProbe"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.Lifecycle":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].lifecycle"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.TerminationGracePeriodSeconds":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.terminationGracePeriodSeconds"
+  "{{.FeatureName}}.Components.{{.ComponentName}}.K8S.TopologySpreadConstraints":
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.topologySpreadConstraints"
+containerMapping:
+  Resources:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].resources"
+  Env:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].env"
+  ImagePullPolicy:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].imagePullPolicy"
+  ReadinessProbe:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].readinessProbe"
+  LivenessProbe:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].livenessProbe"
+  StartupProbe:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].startupProbe"
+  SecurityContext:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].securityContext"
+  Lifecycle:
+    outPath: "[{{.ResourceType}}:{{.ResourceName}}].spec.template.spec.containers.[name:{{.ContainerName}}].lifecycle"
 toFeature:
     Base:               Base
     Pilot:              TrafficManagement
@@ -38537,6 +38611,113 @@ componentMaps:
     ContainerName:        "prometheus"
     HelmSubdir:           "istio-telemetry/prometheus"
     ToHelmValuesTreeRoot: "prometheus"
+deprecations:
+  - path: values.pilot.replicaCount
+    version: "1.4"
+    replacement: trafficManagement.components.pilot.k8s.replicaCount
+  - path: values.pilot.resources
+    version: "1.4"
+    replacement: trafficManagement.components.pilot.k8s.resources
+  - path: values.pilot.cpu
+    version: "1.4"
+    replacement: trafficManagement.components.pilot.k8s.hpaSpec.metrics
+    transform: cpuTargetUtilizationToMetrics
+  - path: values.galley.replicaCount
+    version: "1.4"
+    replacement: configManagement.components.galley.k8s.replicaCount
+  - path: values.galley.resources
+    version: "1.4"
+    replacement: configManagement.components.galley.k8s.resources
+  - path: values.galley.rollingMaxSurge
+    version: "1.4"
+    replacement: configManagement.components.galley.k8s.strategy.rollingUpdate.maxSurge
+  - path: values.galley.rollingMaxUnavailable
+    version: "1.4"
+    replacement: configManagement.components.galley.k8s.strategy.rollingUpdate.maxUnavailable
+  - path: values.mixer.policy.replicaCount
+    version: "1.4"
+    replacement: policy.components.policy.k8s.replicaCount
+  - path: values.mixer.policy.resources
+    version: "1.4"
+    replacement: policy.components.policy.k8s.resources
+  - path: values.mixer.policy.podAnnotations
+    version: "1.4"
+    replacement: policy.components.policy.k8s.podAnnotations
+  - path: values.mixer.policy.cpu
+    version: "1.4"
+    replacement: policy.components.policy.k8s.hpaSpec.metrics
+    transform: cpuTargetUtilizationToMetrics
+  - path: values.mixer.telemetry.replicaCount
+    version: "1.4"
+    replacement: telemetry.components.telemetry.k8s.replicaCount
+  - path: values.mixer.telemetry.resources
+    version: "1.4"
+    replacement: telemetry.components.telemetry.k8s.resources
+  - path: values.mixer.telemetry.nodeSelector
+    version: "1.4"
+    replacement: telemetry.components.telemetry.k8s.nodeSelector
+  - path: values.mixer.telemetry.podAnnotations
+    version: "1.4"
+    replacement: telemetry.components.telemetry.k8s.podAnnotations
+  - path: values.mixer.telemetry.tolerations
+    version: "1.4"
+    replacement: telemetry.components.telemetry.k8s.tolerations
+  - path: values.mixer.telemetry.cpu
+    version: "1.4"
+    replacement: telemetry.components.telemetry.k8s.hpaSpec.metrics
+    transform: cpuTargetUtilizationToMetrics
+  - path: values.mixer.telemetry.rollingMaxSurge
+    version: "1.4"
+    replacement: telemetry.components.telemetry.k8s.strategy.rollingUpdate.maxSurge
+  - path: values.mixer.telemetry.rollingMaxUnavailable
+    version: "1.4"
+    replacement: telemetry.components.telemetry.k8s.strategy.rollingUpdate.maxUnavailable
+  - path: values.gateways.istio-ingressgateway.replicaCount
+    version: "1.4"
+    replacement: gateways.components.ingressGateway.k8s.replicaCount
+  - path: values.gateways.istio-ingressgateway.resources
+    version: "1.4"
+    replacement: gateways.components.ingressGateway.k8s.resources
+  - path: values.gateways.istio-ingressgateway.nodeSelector
+    version: "1.4"
+    replacement: gateways.components.ingressGateway.k8s.nodeSelector
+  - path: values.gateways.istio-ingressgateway.podAnnotations
+    version: "1.4"
+    replacement: gateways.components.ingressGateway.k8s.podAnnotations
+  - path: values.gateways.istio-ingressgateway.cpu
+    version: "1.4"
+    replacement: gateways.components.ingressGateway.k8s.hpaSpec.metrics
+    transform: cpuTargetUtilizationToMetrics
+  - path: values.gateways.istio-ingressgateway.rollingMaxSurge
+    version: "1.4"
+    replacement: gateways.components.ingressGateway.k8s.strategy.rollingUpdate.maxSurge
+  - path: values.gateways.istio-ingressgateway.rollingMaxUnavailable
+    version: "1.4"
+    replacement: gateways.components.ingressGateway.k8s.strategy.rollingUpdate.maxUnavailable
+  - path: values.gateways.istio-egressgateway.resources
+    version: "1.4"
+    replacement: gateways.components.egressGateway.k8s.resources
+  - path: values.gateways.istio-egressgateway.nodeSelector
+    version: "1.4"
+    replacement: gateways.components.egressGateway.k8s.nodeSelector
+  - path: values.gateways.istio-egressgateway.podAnnotations
+    version: "1.4"
+    replacement: gateways.components.egressGateway.k8s.podAnnotations
+  - path: values.gateways.istio-egressgateway.cpu
+    version: "1.4"
+    replacement: gateways.components.egressGateway.k8s.hpaSpec.metrics
+    transform: cpuTargetUtilizationToMetrics
+  - path: values.nodeagent.nodeSelector
+    version: "1.4"
+    replacement: security.components.nodeAgent.k8s.nodeSelector
+  - path: values.nodeagent.tolerations
+    version: "1.4"
+    replacement: security.components.nodeAgent.k8s.tolerations
+  - path: values.cni.podAnnotations
+    version: "1.4"
+    replacement: cni.components.cni.k8s.podAnnotations
+  - path: values.global.priorityClassName
+    version: "1.4"
 `)
 
 func translateconfigTranslateconfig14YamlBytes() ([]byte, error) {
@@ -0,0 +1,183 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package yamlpos records the source positions of the nodes in YAML input files, so that errors about a path in the
+parsed tree can be reported at the file, line and column the path was set at, in the format used by compilers:
+
+	samples/pilot-k8s.yaml:12:9: unknown field trafficManagement.components.pilot.k8s.replicaCont; did you mean replicaCount?
+
+Errors are matched to positions through util.PathError, which carries the YAML path of the node an error is about.
+*/
+package yamlpos
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"istio.io/operator/pkg/util"
+)
+
+// Position is the location of a node in a YAML file.
+type Position struct {
+	// File is the name of the file.
+	File string
+	// Line is the line number, starting at 1.
+	Line int
+	// Column is the column number, starting at 1.
+	Column int
+}
+
+// String implements the Stringer interface.
+func (p Position) String() string {
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Error is an error at a position in a YAML file.
+type Error struct {
+	Position
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error#Error method.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %s", e.Position, e.Err)
+}
+
+// Positions maps the paths of the nodes in a YAML file to their positions.
+type Positions struct {
+	nodes map[string]Position
+}
+
+// Parse returns the positions of the nodes in the subtree at root of y, the contents of file. Paths are relative to
+// root, e.g. root spec for an IstioControlPlane CR gives paths relative to the IstioControlPlaneSpec. Only the first
+// document in y is used.
+func Parse(file string, y []byte, root util.Path) (*Positions, error) {
+	p := &Positions{nodes: make(map[string]Position)}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(y, doc); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %s", file, err)
+	}
+	if len(doc.Content) == 0 {
+		return p, nil
+	}
+	node := doc.Content[0]
+	for _, pe := range root {
+		if node = mapValue(node, pe); node == nil {
+			return p, nil
+		}
+	}
+	p.add(file, node, nil)
+	return p, nil
+}
+
+// add records the positions of node, which is at path, and its descendants. Map entries are recorded at the position
+// of their key, which is where the field is set.
+func (p *Positions) add(file string, node *yaml.Node, path util.Path) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			kp := append(path, k.Value)
+			p.nodes[key(kp)] = Position{File: file, Line: k.Line, Column: k.Column}
+			p.add(file, v, kp)
+		}
+	case yaml.SequenceNode:
+		for i, v := range node.Content {
+			ip := append(path, fmt.Sprintf("[%d]", i))
+			p.nodes[key(ip)] = Position{File: file, Line: v.Line, Column: v.Column}
+			p.add(file, v, ip)
+		}
+	case yaml.AliasNode:
+		p.add(file, node.Alias, path)
+	}
+}
+
+// Lookup returns the position of the node at path and whether it was found. Path elements are matched ignoring case
+// and underscores, since jsonpb accepts both the proto and JSON names of fields, and list indexes may be given with or
+// without brackets.
+func (p *Positions) Lookup(path util.Path) (Position, bool) {
+	if p == nil {
+		return Position{}, false
+	}
+	pos, ok := p.nodes[key(path)]
+	return pos, ok
+}
+
+// Annotate returns errs with each util.PathError whose path is found in p replaced by an Error at that position.
+// Other errors are unchanged.
+func (p *Positions) Annotate(errs util.Errors) util.Errors {
+	var out util.Errors
+	for _, e := range errs {
+		if pe, ok := e.(*util.PathError); ok {
+			if pos, ok := p.Lookup(pe.Path); ok {
+				e = &Error{Position: pos, Err: pe.Err}
+			}
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// AnnotateError is like Annotate for a single error, which may be a util.Errors.
+func (p *Positions) AnnotateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errs, ok := err.(util.Errors); ok {
+		return p.Annotate(errs)
+	}
+	return p.Annotate(util.Errors{err})[0]
+}
+
+// key returns the normalized map key for path.
+func key(path util.Path) string {
+	var out []string
+	for _, pe := range path {
+		pe = strings.ToLower(strings.Replace(pe, "_", "", -1))
+		if !strings.HasPrefix(pe, "[") && isIndex(pe) {
+			pe = "[" + pe + "]"
+		}
+		out = append(out, pe)
+	}
+	return strings.Join(out, ".")
+}
+
+func isIndex(pe string) bool {
+	if pe == "" {
+		return false
+	}
+	for _, c := range pe {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// mapValue returns the value for key k in the mapping node, or nil if node is not a mapping or has no key k.
+func mapValue(node *yaml.Node, k string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == k {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yamlpos
+
+import (
+	"fmt"
+	"testing"
+
+	"istio.io/operator/pkg/util"
+)
+
+const testCR = `apiVersion: install.istio.io/v1alpha2
+kind: IstioControlPlane
+spec:
+  traffic_management:
+    components:
+      pilot:
+        k8s:
+          env:
+          - name: A
+            value: b
+          replicaCount: 2
+  values:
+    global:
+      hub: docker.io/istio
+`
+
+func TestLookup(t *testing.T) {
+	pos, err := Parse("cr.yaml", []byte(testCR), util.Path{"spec"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		desc      string
+		path      string
+		want      string
+		wantFound bool
+	}{
+		{
+			desc:      "map key",
+			path:      "values.global.hub",
+			want:      "cr.yaml:14:7",
+			wantFound: true,
+		},
+		{
+			desc:      "json name",
+			path:      "trafficManagement.components.pilot.k8s.replicaCount",
+			want:      "cr.yaml:11:11",
+			wantFound: true,
+		},
+		{
+			desc:      "list index",
+			path:      "trafficManagement.components.pilot.k8s.env.[0].value",
+			want:      "cr.yaml:10:13",
+			wantFound: true,
+		},
+		{
+			desc:      "list index without brackets",
+			path:      "trafficManagement.components.pilot.k8s.env.0",
+			want:      "cr.yaml:9:13",
+			wantFound: true,
+		},
+		{
+			desc: "not found",
+			path: "trafficManagement.components.pilot.k8s.hpaSpec",
+		},
+		{
+			desc: "outside root",
+			path: "kind",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			got, found := pos.Lookup(util.PathFromString(tt.path))
+			if found != tt.wantFound {
+				t.Fatalf("Lookup(%s): got found %v, want %v", tt.path, found, tt.wantFound)
+			}
+			if found && got.String() != tt.want {
+				t.Errorf("Lookup(%s): got %s, want %s", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	pos, err := Parse("cr.yaml", []byte(testCR), util.Path{"spec"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := util.Errors{
+		util.NewPathError(util.PathFromString("values.global.hub"), fmt.Errorf("bad hub")),
+		util.NewPathError(util.PathFromString("values.global.tag"), fmt.Errorf("bad tag")),
+		fmt.Errorf("no path"),
+	}
+	got := pos.Annotate(errs)
+	want := "cr.yaml:14:7: bad hub, bad tag, no path"
+	if got.Error() != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+	if _, ok := got[0].(*Error); !ok {
+		t.Errorf("got %T, want *Error", got[0])
+	}
+
+	var nilPos *Positions
+	if got := nilPos.Annotate(errs); got.Error() != "bad hub, bad tag, no path" {
+		t.Errorf("nil Positions: got %s", got)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	_, err := Parse("cr.yaml", []byte("spec:\n  a: b\n c: d\n"), util.Path{"spec"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}